@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/geraldbahati/ecommerce/internal/database"
 	"github.com/geraldbahati/ecommerce/pkg/handlers"
 	"github.com/geraldbahati/ecommerce/pkg/middleware"
+	"github.com/geraldbahati/ecommerce/pkg/payment"
 	"github.com/geraldbahati/ecommerce/pkg/repository/sqlc"
+	"github.com/geraldbahati/ecommerce/pkg/tax"
 	"github.com/geraldbahati/ecommerce/pkg/usecases"
 
 	"github.com/geraldbahati/ecommerce/pkg/config"
@@ -30,27 +34,100 @@ func main() {
 	productRepo := sqlc.NewSQLProductRepository(db)
 	categoryRepo := sqlc.NewSQLCategoryRepository(db)
 	subCategoryRepo := sqlc.NewSQLSubCategoryRepository(db)
+	cartRepo := sqlc.NewSQLCartRepository(conn, db)
+	checkoutRepo := sqlc.NewSQLCheckoutRepository(conn, db)
+	orderRepo := sqlc.NewSQLOrderRepository(conn, db)
+	returnRepo := sqlc.NewSQLReturnRepository(conn, db)
+	paymentRepo := sqlc.NewSQLPaymentRepository(conn, db)
+	refundRepo := sqlc.NewSQLRefundRepository(conn, db)
+	idempotencyRepo := sqlc.NewSQLIdempotencyRepository(db)
+	taxRepo := sqlc.NewSQLTaxRepository(db)
+	shippingRepo := sqlc.NewSQLShippingRepository(db)
+	promotionRepo := sqlc.NewSQLPromotionRepository(conn, db)
+	currencyRepo := sqlc.NewSQLCurrencyRepository(conn, db)
+	giftCardRepo := sqlc.NewSQLGiftCardRepository(conn, db)
+	storeCreditRepo := sqlc.NewSQLStoreCreditRepository(conn, db)
+	addressRepo := sqlc.NewSQLAddressRepository(conn, db)
+	reviewRepo := sqlc.NewSQLReviewRepository(conn, db)
+	recentlyViewedRepo := sqlc.NewSQLRecentlyViewedRepository(conn, db)
+	recommendationRepo := sqlc.NewSQLRecommendationRepository(conn, db)
+
+	// initialize payment gateway
+	paymentGateway := payment.NewFakeGateway()
+
+	// initialize tax calculator
+	taxCalculator := tax.NewTableCalculator(taxRepo)
 
 	// initialize services
 	userService := usecases.NewUserService(userRepo)
-	productService := usecases.NewProductService(productRepo)
+	productService := usecases.NewProductService(productRepo, recentlyViewedRepo, cfg.RecentlyViewedRetention)
 	categoryService := usecases.NewCategoryService(categoryRepo)
 	subCategoryService := usecases.NewSubCategoryService(subCategoryRepo)
+	taxService := usecases.NewTaxService(taxRepo, taxCalculator)
+	shippingService := usecases.NewShippingService(shippingRepo)
+	promotionService := usecases.NewPromotionService(promotionRepo)
+	cartService := usecases.NewCartService(cartRepo, productRepo, taxService, shippingService, promotionService)
+	paymentService := usecases.NewPaymentService(paymentRepo, paymentGateway, cfg.PaymentWebhookSecrets)
+	addressService := usecases.NewAddressService(addressRepo)
+	checkoutService := usecases.NewCheckoutService(checkoutRepo, cartRepo, paymentService, taxService, shippingService, promotionService, addressService)
+	orderService := usecases.NewOrderService(orderRepo, refundRepo)
+	returnService := usecases.NewReturnService(returnRepo, orderRepo, cfg.ReturnWindowDays)
+	refundService := usecases.NewRefundService(refundRepo, paymentGateway)
+	currencyService := usecases.NewCurrencyService(currencyRepo)
+	giftCardService := usecases.NewGiftCardService(giftCardRepo)
+	storeCreditService := usecases.NewStoreCreditService(storeCreditRepo)
+	recommendationService := usecases.NewRecommendationService(recommendationRepo, productRepo)
+	reviewService := usecases.NewReviewService(reviewRepo, cfg.ReviewBannedWords, cfg.ReviewReportThreshold)
 
 	// initialize handlers
 	userHandler := handlers.NewUserHandler(userService)
 	productHandler := handlers.NewProductHandler(productService)
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
 	subCategoryHandler := handlers.NewSubCategoryHandler(subCategoryService)
+	cartHandler := handlers.NewCartHandler(cartService)
+	checkoutHandler := handlers.NewCheckoutHandler(checkoutService)
+	orderHandler := handlers.NewOrderHandler(orderService)
+	returnHandler := handlers.NewReturnHandler(returnService)
+	paymentHandler := handlers.NewPaymentHandler(paymentService)
+	refundHandler := handlers.NewRefundHandler(refundService)
+	taxHandler := handlers.NewTaxHandler(taxService)
+	shippingHandler := handlers.NewShippingHandler(shippingService)
+	promotionHandler := handlers.NewPromotionHandler(promotionService)
+	currencyHandler := handlers.NewCurrencyHandler(currencyService)
+	giftCardHandler := handlers.NewGiftCardHandler(giftCardService)
+	storeCreditHandler := handlers.NewStoreCreditHandler(storeCreditService)
+	addressHandler := handlers.NewAddressHandler(addressService)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+
+	// keep product recommendations up to date with new orders
+	recommendationService.StartRefreshing(context.Background(), time.Duration(cfg.RecommendationRefreshMinutes)*time.Minute)
 
 	// setup routes
 	r := mux.NewRouter()
 	r.Use(middleware.CORS)
+	idempotency := middleware.Idempotency(idempotencyRepo)
+	currency := middleware.Currency(currencyRepo)
 
 	getUserRouter(r, userHandler)
-	getProductRouter(r, productHandler)
+	getProductRouter(r, productHandler, currency)
 	getCategoryRouter(r, categoryHandler)
-	getSubCategoryRouter(r, subCategoryHandler)
+	getSubCategoryRouter(r, subCategoryHandler, currency)
+	getCartRouter(r, cartHandler, currency)
+	getCheckoutRouter(r, checkoutHandler, idempotency, currency)
+	getOrderRouter(r, orderHandler)
+	getReturnRouter(r, returnHandler)
+	getPaymentRouter(r, paymentHandler, idempotency)
+	getRefundRouter(r, refundHandler, idempotency)
+	getTaxRouter(r, taxHandler)
+	getShippingRouter(r, shippingHandler)
+	getPromotionRouter(r, promotionHandler)
+	getCurrencyRouter(r, currencyHandler)
+	getGiftCardRouter(r, giftCardHandler)
+	getStoreCreditRouter(r, storeCreditHandler)
+	getAddressRouter(r, addressHandler)
+	getReviewRouter(r, reviewHandler)
+	getRecommendationRouter(r, recommendationHandler, currency)
 
 	// start server
 	log.Printf("Server listening on port %s", cfg.Port)
@@ -74,23 +151,31 @@ func getUserRouter(r *mux.Router, userHandler *handlers.UserHandler) {
 	protectedUserRouter.HandleFunc("/reset-password", userHandler.RequestPasswordReset).Methods(http.MethodPut)
 }
 
-func getProductRouter(r *mux.Router, productHandler *handlers.ProductHandler) {
+func getProductRouter(r *mux.Router, productHandler *handlers.ProductHandler, currency mux.MiddlewareFunc) {
 	productRouter := r.PathPrefix("/api/products").Subrouter()
+	productRouter.Use(currency)
 	productRouter.HandleFunc("/list", productHandler.GetProducts).Methods(http.MethodGet)
 	productRouter.HandleFunc("/create", productHandler.CreateProduct).Methods(http.MethodPost)
 	productRouter.HandleFunc("/update", productHandler.UpdateProduct).Methods(http.MethodPut)
 	productRouter.HandleFunc("/delete", productHandler.DeleteProduct).Methods(http.MethodDelete)
-	productRouter.HandleFunc("/detail", productHandler.GetProductById).Methods(http.MethodGet)
+	productRouter.Handle("/detail", middleware.OptionalAuth(http.HandlerFunc(productHandler.GetProductById))).Methods(http.MethodGet)
 	productRouter.HandleFunc("/list/available", productHandler.GetAvailableProducts).Methods(http.MethodGet)
 	//productRouter.HandleFunc("/list/filtered", productHandler.GetFilteredProducts).Methods(http.MethodGet)
 	//productRouter.HandleFunc("/list/paginated", productHandler.GetPaginatedProducts).Methods(http.MethodGet)
-	//productRouter.HandleFunc("/recommended", productHandler.GetProductWithRecommendations).Methods(http.MethodGet)
 	productRouter.HandleFunc("/{category_id}/", productHandler.GetProductsByCategory).Methods(http.MethodGet)
 	productRouter.HandleFunc("/search", productHandler.SearchProducts).Methods(http.MethodGet)
 	productRouter.HandleFunc("/trend", productHandler.GetSalesTrends).Methods(http.MethodGet)
 	productRouter.HandleFunc("/trending", productHandler.GetTrendingProducts).Methods(http.MethodGet)
 	productRouter.HandleFunc("/colours", productHandler.GetAllColours).Methods(http.MethodGet)
 	productRouter.HandleFunc("/materials", productHandler.GetAllMaterials).Methods(http.MethodGet)
+
+	recentlyViewedRouter := r.PathPrefix("/api/users/recently-viewed").Subrouter()
+	recentlyViewedRouter.Use(middleware.Auth, currency)
+	recentlyViewedRouter.HandleFunc("", productHandler.GetRecentlyViewedProducts).Methods(http.MethodGet)
+
+	adminProductRouter := r.PathPrefix("/api/admin/products").Subrouter()
+	adminProductRouter.Use(middleware.Auth, middleware.Admin)
+	adminProductRouter.HandleFunc("/{id}/sale", productHandler.UpdateProductSale).Methods(http.MethodPut)
 }
 
 func getCategoryRouter(r *mux.Router, categoryHandler *handlers.CategoryHandler) {
@@ -108,8 +193,9 @@ func getCategoryRouter(r *mux.Router, categoryHandler *handlers.CategoryHandler)
 	protectedCategoryRouter.HandleFunc("", categoryHandler.CreateCategory).Methods(http.MethodPost)
 }
 
-func getSubCategoryRouter(r *mux.Router, subCategoryHandler *handlers.SubCategoryHandler) {
+func getSubCategoryRouter(r *mux.Router, subCategoryHandler *handlers.SubCategoryHandler, currency mux.MiddlewareFunc) {
 	subCategoryRouter := r.PathPrefix("/api/sub-categories").Subrouter()
+	subCategoryRouter.Use(currency)
 	subCategoryRouter.HandleFunc("", subCategoryHandler.GetAllSubCategories).Methods(http.MethodGet)
 	//subCategoryRouter.HandleFunc("/{id}/", subCategoryHandler.GetSubCategoryById).Methods(http.MethodGet)
 	subCategoryRouter.HandleFunc("/{categoryId}", subCategoryHandler.ListSubCategoriesByCategory).Methods(http.MethodGet)
@@ -121,3 +207,196 @@ func getSubCategoryRouter(r *mux.Router, subCategoryHandler *handlers.SubCategor
 	//protectedSubCategoryRouter.HandleFunc("/{id}/", subCategoryHandler.UpdateSubCategory).Methods(http.MethodPut)
 	//protectedSubCategoryRouter.HandleFunc("/{id}/", subCategoryHandler.DeleteSubCategory).Methods(http.MethodDelete)
 }
+
+func getCartRouter(r *mux.Router, cartHandler *handlers.CartHandler, currency mux.MiddlewareFunc) {
+	cartRouter := r.PathPrefix("/api/cart").Subrouter()
+	cartRouter.Use(middleware.Auth, currency)
+	cartRouter.HandleFunc("", cartHandler.GetCart).Methods(http.MethodGet)
+	cartRouter.HandleFunc("/shipping-options", cartHandler.GetShippingOptions).Methods(http.MethodGet)
+	cartRouter.HandleFunc("/promotion", cartHandler.ApplyPromotion).Methods(http.MethodPost)
+	cartRouter.HandleFunc("/promotion", cartHandler.RemovePromotion).Methods(http.MethodDelete)
+	cartRouter.HandleFunc("/items", cartHandler.AddItem).Methods(http.MethodPost)
+	cartRouter.HandleFunc("/items/{productId}", cartHandler.UpdateItemQuantity).Methods(http.MethodPut)
+	cartRouter.HandleFunc("/items/{productId}", cartHandler.RemoveItem).Methods(http.MethodDelete)
+}
+
+func getCheckoutRouter(r *mux.Router, checkoutHandler *handlers.CheckoutHandler, idempotency mux.MiddlewareFunc, currency mux.MiddlewareFunc) {
+	checkoutRouter := r.PathPrefix("/api/checkout").Subrouter()
+	checkoutRouter.Use(middleware.Auth, currency, idempotency)
+	checkoutRouter.HandleFunc("", checkoutHandler.Checkout).Methods(http.MethodPost)
+}
+
+func getOrderRouter(r *mux.Router, orderHandler *handlers.OrderHandler) {
+	orderRouter := r.PathPrefix("/api/orders").Subrouter()
+	orderRouter.Use(middleware.Auth)
+	orderRouter.HandleFunc("", orderHandler.GetOrders).Methods(http.MethodGet)
+	orderRouter.HandleFunc("/{id}", orderHandler.GetOrder).Methods(http.MethodGet)
+	orderRouter.HandleFunc("/{id}/timeline", orderHandler.GetOrderTimeline).Methods(http.MethodGet)
+	orderRouter.HandleFunc("/{id}/cancel", orderHandler.CancelOrder).Methods(http.MethodPost)
+
+	adminOrderRouter := r.PathPrefix("/api/admin/orders").Subrouter()
+	adminOrderRouter.Use(middleware.Auth, middleware.Admin)
+	adminOrderRouter.HandleFunc("/{id}/transition", orderHandler.TransitionOrder).Methods(http.MethodPost)
+	adminOrderRouter.HandleFunc("/{id}/cancel", orderHandler.AdminCancelOrder).Methods(http.MethodPost)
+}
+
+func getReturnRouter(r *mux.Router, returnHandler *handlers.ReturnHandler) {
+	orderReturnRouter := r.PathPrefix("/api/orders/{id}/returns").Subrouter()
+	orderReturnRouter.Use(middleware.Auth)
+	orderReturnRouter.HandleFunc("", returnHandler.RequestReturn).Methods(http.MethodPost)
+	orderReturnRouter.HandleFunc("", returnHandler.GetOrderReturns).Methods(http.MethodGet)
+
+	adminReturnRouter := r.PathPrefix("/api/admin/returns").Subrouter()
+	adminReturnRouter.Use(middleware.Auth, middleware.Admin)
+	adminReturnRouter.HandleFunc("", returnHandler.GetReturnRequests).Methods(http.MethodGet)
+	adminReturnRouter.HandleFunc("/{id}", returnHandler.GetReturnRequest).Methods(http.MethodGet)
+	adminReturnRouter.HandleFunc("/{id}/approve", returnHandler.ApproveReturn).Methods(http.MethodPost)
+	adminReturnRouter.HandleFunc("/{id}/reject", returnHandler.RejectReturn).Methods(http.MethodPost)
+	adminReturnRouter.HandleFunc("/{id}/receive", returnHandler.ReceiveReturn).Methods(http.MethodPost)
+}
+
+func getPaymentRouter(r *mux.Router, paymentHandler *handlers.PaymentHandler, idempotency mux.MiddlewareFunc) {
+	orderPaymentRouter := r.PathPrefix("/api/orders/{id}/pay").Subrouter()
+	orderPaymentRouter.Use(middleware.Auth, idempotency)
+	orderPaymentRouter.HandleFunc("", paymentHandler.PayOrder).Methods(http.MethodPost)
+
+	webhookRouter := r.PathPrefix("/api/webhooks/payments").Subrouter()
+	webhookRouter.HandleFunc("/{provider}", paymentHandler.HandlePaymentWebhook).Methods(http.MethodPost)
+}
+
+func getRefundRouter(r *mux.Router, refundHandler *handlers.RefundHandler, idempotency mux.MiddlewareFunc) {
+	orderRefundRouter := r.PathPrefix("/api/admin/orders/{id}/refunds").Subrouter()
+	orderRefundRouter.Use(middleware.Auth, middleware.Admin, idempotency)
+	orderRefundRouter.HandleFunc("", refundHandler.IssueRefund).Methods(http.MethodPost)
+	orderRefundRouter.HandleFunc("", refundHandler.GetOrderRefunds).Methods(http.MethodGet)
+
+	adminRefundRouter := r.PathPrefix("/api/admin/refunds").Subrouter()
+	adminRefundRouter.Use(middleware.Auth, middleware.Admin)
+	adminRefundRouter.HandleFunc("/{id}/process", refundHandler.ProcessRefund).Methods(http.MethodPost)
+}
+
+func getTaxRouter(r *mux.Router, taxHandler *handlers.TaxHandler) {
+	taxRateRouter := r.PathPrefix("/api/admin/tax-rates").Subrouter()
+	taxRateRouter.Use(middleware.Auth, middleware.Admin)
+	taxRateRouter.HandleFunc("", taxHandler.GetTaxRates).Methods(http.MethodGet)
+	taxRateRouter.HandleFunc("", taxHandler.SaveTaxRate).Methods(http.MethodPut)
+	taxRateRouter.HandleFunc("/{id}", taxHandler.DeleteTaxRate).Methods(http.MethodDelete)
+
+	adminRouter := r.PathPrefix("/api/admin").Subrouter()
+	adminRouter.Use(middleware.Auth, middleware.Admin)
+	adminRouter.HandleFunc("/users/{id}/tax-exempt", taxHandler.SetUserTaxExempt).Methods(http.MethodPut)
+	adminRouter.HandleFunc("/sub-categories/{id}/tax-class", taxHandler.SetSubCategoryTaxClass).Methods(http.MethodPut)
+}
+
+func getShippingRouter(r *mux.Router, shippingHandler *handlers.ShippingHandler) {
+	shippingZoneRouter := r.PathPrefix("/api/admin/shipping-zones").Subrouter()
+	shippingZoneRouter.Use(middleware.Auth, middleware.Admin)
+	shippingZoneRouter.HandleFunc("", shippingHandler.GetShippingZones).Methods(http.MethodGet)
+	shippingZoneRouter.HandleFunc("", shippingHandler.CreateShippingZone).Methods(http.MethodPost)
+	shippingZoneRouter.HandleFunc("/{id}", shippingHandler.DeleteShippingZone).Methods(http.MethodDelete)
+	shippingZoneRouter.HandleFunc("/{id}/rates", shippingHandler.CreateShippingRate).Methods(http.MethodPost)
+
+	shippingRateRouter := r.PathPrefix("/api/admin/shipping-rates").Subrouter()
+	shippingRateRouter.Use(middleware.Auth, middleware.Admin)
+	shippingRateRouter.HandleFunc("/{id}", shippingHandler.DeleteShippingRate).Methods(http.MethodDelete)
+
+	productShippingRouter := r.PathPrefix("/api/admin/products").Subrouter()
+	productShippingRouter.Use(middleware.Auth, middleware.Admin)
+	productShippingRouter.HandleFunc("/{id}/shipping", shippingHandler.UpdateProductShipping).Methods(http.MethodPut)
+}
+
+func getPromotionRouter(r *mux.Router, promotionHandler *handlers.PromotionHandler) {
+	promotionRouter := r.PathPrefix("/api/admin/promotions").Subrouter()
+	promotionRouter.Use(middleware.Auth, middleware.Admin)
+	promotionRouter.HandleFunc("", promotionHandler.GetPromotions).Methods(http.MethodGet)
+	promotionRouter.HandleFunc("", promotionHandler.CreatePromotion).Methods(http.MethodPost)
+	promotionRouter.HandleFunc("/{id}", promotionHandler.GetPromotion).Methods(http.MethodGet)
+	promotionRouter.HandleFunc("/{id}/status", promotionHandler.SetPromotionActive).Methods(http.MethodPut)
+	promotionRouter.HandleFunc("/{id}/report", promotionHandler.GetPromotionReport).Methods(http.MethodGet)
+}
+
+func getCurrencyRouter(r *mux.Router, currencyHandler *handlers.CurrencyHandler) {
+	currencyRouter := r.PathPrefix("/api/currencies").Subrouter()
+	currencyRouter.HandleFunc("", currencyHandler.GetCurrencies).Methods(http.MethodGet)
+
+	adminCurrencyRouter := r.PathPrefix("/api/admin/currencies").Subrouter()
+	adminCurrencyRouter.Use(middleware.Auth, middleware.Admin)
+	adminCurrencyRouter.HandleFunc("", currencyHandler.GetAllCurrencies).Methods(http.MethodGet)
+	adminCurrencyRouter.HandleFunc("/{code}", currencyHandler.SaveCurrency).Methods(http.MethodPut)
+	adminCurrencyRouter.HandleFunc("/{code}/rate", currencyHandler.SetExchangeRate).Methods(http.MethodPut)
+
+	exchangeRateRouter := r.PathPrefix("/api/admin/exchange-rates").Subrouter()
+	exchangeRateRouter.Use(middleware.Auth, middleware.Admin)
+	exchangeRateRouter.HandleFunc("/import", currencyHandler.ImportExchangeRates).Methods(http.MethodPost)
+}
+
+func getGiftCardRouter(r *mux.Router, giftCardHandler *handlers.GiftCardHandler) {
+	giftCardRouter := r.PathPrefix("/api/gift-cards").Subrouter()
+	giftCardRouter.Use(middleware.Auth)
+	giftCardRouter.HandleFunc("/{code}", giftCardHandler.CheckGiftCardBalance).Methods(http.MethodGet)
+
+	adminGiftCardRouter := r.PathPrefix("/api/admin/gift-cards").Subrouter()
+	adminGiftCardRouter.Use(middleware.Auth, middleware.Admin)
+	adminGiftCardRouter.HandleFunc("", giftCardHandler.GetGiftCards).Methods(http.MethodGet)
+	adminGiftCardRouter.HandleFunc("", giftCardHandler.IssueGiftCard).Methods(http.MethodPost)
+	adminGiftCardRouter.HandleFunc("/{id}", giftCardHandler.GetGiftCard).Methods(http.MethodGet)
+	adminGiftCardRouter.HandleFunc("/{id}/status", giftCardHandler.SetGiftCardActive).Methods(http.MethodPut)
+}
+
+func getStoreCreditRouter(r *mux.Router, storeCreditHandler *handlers.StoreCreditHandler) {
+	storeCreditRouter := r.PathPrefix("/api/users/store-credit").Subrouter()
+	storeCreditRouter.Use(middleware.Auth)
+	storeCreditRouter.HandleFunc("", storeCreditHandler.GetMyStoreCredit).Methods(http.MethodGet)
+
+	adminStoreCreditRouter := r.PathPrefix("/api/admin/users/{id}/store-credit").Subrouter()
+	adminStoreCreditRouter.Use(middleware.Auth, middleware.Admin)
+	adminStoreCreditRouter.HandleFunc("", storeCreditHandler.GetStoreCredit).Methods(http.MethodGet)
+	adminStoreCreditRouter.HandleFunc("", storeCreditHandler.AdjustStoreCredit).Methods(http.MethodPost)
+}
+
+func getAddressRouter(r *mux.Router, addressHandler *handlers.AddressHandler) {
+	addressRouter := r.PathPrefix("/api/users/addresses").Subrouter()
+	addressRouter.Use(middleware.Auth)
+	addressRouter.HandleFunc("", addressHandler.GetAddresses).Methods(http.MethodGet)
+	addressRouter.HandleFunc("/{type}", addressHandler.CreateAddress).Methods(http.MethodPost)
+	addressRouter.HandleFunc("/{type}/{id}", addressHandler.UpdateAddress).Methods(http.MethodPut)
+	addressRouter.HandleFunc("/{type}/{id}", addressHandler.DeleteAddress).Methods(http.MethodDelete)
+	addressRouter.HandleFunc("/{type}/{id}/default", addressHandler.SetDefaultAddress).Methods(http.MethodPut)
+}
+
+func getReviewRouter(r *mux.Router, reviewHandler *handlers.ReviewHandler) {
+	productReviewRouter := r.PathPrefix("/api/products/{id}/reviews").Subrouter()
+	productReviewRouter.HandleFunc("", reviewHandler.GetProductReviews).Methods(http.MethodGet)
+
+	protectedProductReviewRouter := r.PathPrefix("/api/products/{id}/reviews").Subrouter()
+	protectedProductReviewRouter.Use(middleware.Auth)
+	protectedProductReviewRouter.HandleFunc("", reviewHandler.CreateReview).Methods(http.MethodPost)
+
+	reviewRouter := r.PathPrefix("/api/reviews").Subrouter()
+	reviewRouter.Use(middleware.Auth)
+	reviewRouter.HandleFunc("/{id}", reviewHandler.UpdateReview).Methods(http.MethodPut)
+	reviewRouter.HandleFunc("/{id}", reviewHandler.DeleteReview).Methods(http.MethodDelete)
+	reviewRouter.HandleFunc("/{id}/vote", reviewHandler.VoteReview).Methods(http.MethodPut)
+	reviewRouter.HandleFunc("/{id}/vote", reviewHandler.DeleteReviewVote).Methods(http.MethodDelete)
+	reviewRouter.HandleFunc("/{id}/report", reviewHandler.ReportReview).Methods(http.MethodPost)
+
+	adminReviewRouter := r.PathPrefix("/api/admin/reviews").Subrouter()
+	adminReviewRouter.Use(middleware.Auth, middleware.Admin)
+	adminReviewRouter.HandleFunc("", reviewHandler.GetModerationQueue).Methods(http.MethodGet)
+	adminReviewRouter.HandleFunc("/{id}/status", reviewHandler.ModerateReview).Methods(http.MethodPut)
+}
+
+func getRecommendationRouter(r *mux.Router, recommendationHandler *handlers.RecommendationHandler, currency mux.MiddlewareFunc) {
+	productRecommendationRouter := r.PathPrefix("/api/products/{id}/recommendations").Subrouter()
+	productRecommendationRouter.Use(currency)
+	productRecommendationRouter.HandleFunc("", recommendationHandler.GetProductRecommendations).Methods(http.MethodGet)
+	productRecommendationRouter.HandleFunc("/saved", recommendationHandler.GetAlsoSavedProducts).Methods(http.MethodGet)
+
+	userRecommendationRouter := r.PathPrefix("/api/users/recommendations").Subrouter()
+	userRecommendationRouter.Use(middleware.Auth, currency)
+	userRecommendationRouter.HandleFunc("", recommendationHandler.GetUserRecommendations).Methods(http.MethodGet)
+
+	adminRecommendationRouter := r.PathPrefix("/api/admin/recommendations").Subrouter()
+	adminRecommendationRouter.Use(middleware.Auth, middleware.Admin)
+	adminRecommendationRouter.HandleFunc("/refresh", recommendationHandler.RefreshRecommendations).Methods(http.MethodPost)
+}
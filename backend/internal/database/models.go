@@ -12,13 +12,16 @@ import (
 )
 
 type BillingAddress struct {
-	ID         uuid.UUID
-	UserID     uuid.UUID
-	Street     string
-	City       string
-	State      string
-	Country    string
-	PostalCode string
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Street      string
+	City        string
+	State       string
+	Country     string
+	PostalCode  string
+	IsDefault   bool
+	CreatedAt   time.Time
+	LastUpdated sql.NullTime
 }
 
 type CartItem struct {
@@ -30,6 +33,12 @@ type CartItem struct {
 	LastUpdated    sql.NullTime
 }
 
+type CartPromotion struct {
+	ShoppingCartID uuid.UUID
+	PromotionID    uuid.UUID
+	CreatedAt      time.Time
+}
+
 type Category struct {
 	ID          uuid.UUID
 	Name        string
@@ -48,6 +57,59 @@ type Colour struct {
 	LastUpdated sql.NullTime
 }
 
+type Currency struct {
+	Code              string
+	Name              string
+	Symbol            string
+	RoundingIncrement string
+	RoundingMode      string
+	IsActive          bool
+	CreatedAt         time.Time
+	LastUpdated       sql.NullTime
+}
+
+type ExchangeRate struct {
+	CurrencyCode string
+	Rate         string
+	Source       string
+	UpdatedAt    time.Time
+}
+
+type GiftCard struct {
+	ID             uuid.UUID
+	Code           string
+	InitialBalance string
+	Balance        string
+	ExpiresAt      sql.NullTime
+	IsActive       bool
+	CreatedBy      uuid.NullUUID
+	CreatedAt      time.Time
+	LastUpdated    sql.NullTime
+}
+
+type GiftCardTransaction struct {
+	ID           uuid.UUID
+	GiftCardID   uuid.UUID
+	Amount       string
+	BalanceAfter string
+	Reason       string
+	OrderID      uuid.NullUUID
+	RefundID     uuid.NullUUID
+	CreatedAt    time.Time
+}
+
+type IdempotencyKey struct {
+	UserID         uuid.UUID
+	IdempotencyKey string
+	RequestMethod  string
+	RequestPath    string
+	RequestHash    string
+	ResponseStatus sql.NullInt32
+	ResponseBody   sql.NullString
+	CreatedAt      time.Time
+	CompletedAt    sql.NullTime
+}
+
 type Material struct {
 	ID          uuid.UUID
 	Name        string
@@ -56,26 +118,73 @@ type Material struct {
 }
 
 type Order struct {
-	ID              uuid.UUID
-	UserID          uuid.UUID
-	OrderStatus     string
-	PaymentStatus   string
-	PaymentMethod   string
-	ShippingAddress string
-	BillingAddress  string
-	TotalPrice      string
-	CreatedAt       time.Time
-	LastUpdated     sql.NullTime
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	OrderStatus       string
+	PaymentStatus     string
+	PaymentMethod     string
+	ShippingAddress   string
+	BillingAddress    string
+	TotalPrice        string
+	CreatedAt         time.Time
+	LastUpdated       sql.NullTime
+	ShippingMethod    sql.NullString
+	ShippingCost      string
+	Currency          string
+	ExchangeRate      string
+	SettlementTotal   sql.NullString
+	GiftCardAmount    string
+	StoreCreditAmount string
 }
 
 type OrderItem struct {
-	ID          uuid.UUID
-	OrderID     uuid.UUID
-	ProductID   uuid.UUID
-	Quantity    int32
-	TaxedPrice  string
-	CreatedAt   time.Time
-	LastUpdated sql.NullTime
+	ID             uuid.UUID
+	OrderID        uuid.UUID
+	ProductID      uuid.UUID
+	Quantity       int32
+	TaxedPrice     string
+	CreatedAt      time.Time
+	LastUpdated    sql.NullTime
+	UnitPrice      string
+	TaxRate        string
+	TaxAmount      string
+	DiscountAmount string
+}
+
+type OrderStatusHistory struct {
+	ID         uuid.UUID
+	OrderID    uuid.UUID
+	FromStatus sql.NullString
+	ToStatus   string
+	ActorID    uuid.NullUUID
+	Reason     sql.NullString
+	CreatedAt  time.Time
+}
+
+type Payment struct {
+	ID                    uuid.UUID
+	OrderID               uuid.UUID
+	Provider              string
+	PaymentMethod         string
+	Amount                string
+	Status                string
+	ProviderTransactionID sql.NullString
+	FailureMessage        sql.NullString
+	CreatedAt             time.Time
+	LastUpdated           sql.NullTime
+	Currency              string
+}
+
+type PaymentEvent struct {
+	ID                    uuid.UUID
+	Provider              string
+	EventID               string
+	EventType             string
+	ProviderTransactionID string
+	Payload               string
+	Outcome               sql.NullString
+	ReceivedAt            time.Time
+	ProcessedAt           sql.NullTime
 }
 
 type Product struct {
@@ -94,6 +203,20 @@ type Product struct {
 	CreatedAt     time.Time
 	LastUpdated   sql.NullTime
 	SubCategoryID uuid.NullUUID
+	WeightGrams   int32
+	LengthCm      sql.NullInt32
+	WidthCm       sql.NullInt32
+	HeightCm      sql.NullInt32
+	SaleStartsAt  sql.NullTime
+	SaleEndsAt    sql.NullTime
+}
+
+type ProductCoPurchase struct {
+	ProductID        uuid.UUID
+	RelatedProductID uuid.UUID
+	OrderCount       int32
+	Score            float64
+	ComputedAt       time.Time
 }
 
 type ProductColour struct {
@@ -112,6 +235,47 @@ type ProductMaterial struct {
 	LastUpdated sql.NullTime
 }
 
+type ProductWishlistAffinity struct {
+	ProductID        uuid.UUID
+	RelatedProductID uuid.UUID
+	UserCount        int32
+	Score            float64
+	ComputedAt       time.Time
+}
+
+type Promotion struct {
+	ID             uuid.UUID
+	Code           string
+	Description    sql.NullString
+	DiscountType   string
+	DiscountValue  string
+	MinCartValue   sql.NullString
+	StartsAt       sql.NullTime
+	EndsAt         sql.NullTime
+	MaxUses        sql.NullInt32
+	MaxUsesPerUser sql.NullInt32
+	TimesUsed      int32
+	IsActive       bool
+	CreatedAt      time.Time
+	LastUpdated    sql.NullTime
+}
+
+type PromotionRedemption struct {
+	ID             uuid.UUID
+	PromotionID    uuid.UUID
+	OrderID        uuid.UUID
+	UserID         uuid.UUID
+	Code           string
+	DiscountAmount string
+	CreatedAt      time.Time
+}
+
+type PromotionTarget struct {
+	PromotionID uuid.UUID
+	TargetType  string
+	TargetID    uuid.UUID
+}
+
 type RecentlyViewedProduct struct {
 	UserID       uuid.UUID
 	ProductID    uuid.UUID
@@ -127,24 +291,115 @@ type RefreshToken struct {
 	RevokedAt sql.NullTime
 }
 
-type Review struct {
+type Refund struct {
+	ID               uuid.UUID
+	OrderID          uuid.UUID
+	ReturnRequestID  uuid.NullUUID
+	Amount           string
+	Status           string
+	Reason           sql.NullString
+	CreatedAt        time.Time
+	LastUpdated      sql.NullTime
+	PaymentID        uuid.NullUUID
+	ProviderRefundID sql.NullString
+	FailureMessage   sql.NullString
+	CreatedBy        uuid.NullUUID
+	SettlementAmount sql.NullString
+	Method           string
+}
+
+type RefundItem struct {
 	ID          uuid.UUID
+	RefundID    uuid.UUID
+	OrderItemID uuid.UUID
+	Quantity    int32
+	Amount      string
+	CreatedAt   time.Time
+}
+
+type ReturnRequest struct {
+	ID          uuid.UUID
+	OrderID     uuid.UUID
 	UserID      uuid.UUID
-	ProductID   uuid.UUID
-	Rating      string
-	Comment     sql.NullString
+	Status      string
+	Reason      string
+	AdminNote   sql.NullString
+	CreatedAt   time.Time
+	LastUpdated sql.NullTime
+}
+
+type ReturnRequestItem struct {
+	ID              uuid.UUID
+	ReturnRequestID uuid.UUID
+	OrderItemID     uuid.UUID
+	Quantity        int32
+	CreatedAt       time.Time
+}
+
+type Review struct {
+	ID               uuid.UUID
+	UserID           uuid.UUID
+	ProductID        uuid.UUID
+	Rating           string
+	Comment          sql.NullString
+	CreatedAt        time.Time
+	LastUpdated      sql.NullTime
+	Status           string
+	VerifiedPurchase bool
+	FlaggedWords     sql.NullString
+	ModeratedBy      uuid.NullUUID
+	ModeratedAt      sql.NullTime
+}
+
+type ReviewReport struct {
+	ID        uuid.UUID
+	ReviewID  uuid.UUID
+	UserID    uuid.UUID
+	Reason    sql.NullString
+	CreatedAt time.Time
+}
+
+type ReviewVote struct {
+	ReviewID    uuid.UUID
+	UserID      uuid.UUID
+	Helpful     bool
 	CreatedAt   time.Time
 	LastUpdated sql.NullTime
 }
 
 type ShippingAddress struct {
-	ID         uuid.UUID
-	UserID     uuid.UUID
-	Street     string
-	City       string
-	State      string
-	Country    string
-	PostalCode string
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Street      string
+	City        string
+	State       string
+	Country     string
+	PostalCode  string
+	IsDefault   bool
+	CreatedAt   time.Time
+	LastUpdated sql.NullTime
+}
+
+type ShippingRate struct {
+	ID             uuid.UUID
+	ZoneID         uuid.UUID
+	Method         string
+	Name           string
+	MinWeightGrams int32
+	MaxWeightGrams sql.NullInt32
+	Price          string
+	EstimatedDays  sql.NullInt32
+	CreatedAt      time.Time
+}
+
+type ShippingZone struct {
+	ID                    uuid.UUID
+	Name                  string
+	Country               string
+	PostalCodePrefix      string
+	FreeShippingThreshold sql.NullString
+	CreatedAt             time.Time
+	LastUpdated           sql.NullTime
 }
 
 type ShoppingCart struct {
@@ -156,6 +411,26 @@ type ShoppingCart struct {
 	TotalPrice  string
 }
 
+type StoreCreditAccount struct {
+	UserID      uuid.UUID
+	Balance     string
+	CreatedAt   time.Time
+	LastUpdated sql.NullTime
+}
+
+type StoreCreditTransaction struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	Amount       string
+	BalanceAfter string
+	Reason       string
+	OrderID      uuid.NullUUID
+	RefundID     uuid.NullUUID
+	Note         sql.NullString
+	CreatedBy    uuid.NullUUID
+	CreatedAt    time.Time
+}
+
 type SubCategory struct {
 	ID          uuid.UUID
 	CategoryID  uuid.UUID
@@ -166,6 +441,19 @@ type SubCategory struct {
 	IsActive    bool
 	CreatedAt   time.Time
 	LastUpdated sql.NullTime
+	TaxClass    string
+}
+
+type TaxRate struct {
+	ID               uuid.UUID
+	Country          string
+	Region           string
+	TaxClass         string
+	Name             string
+	Rate             string
+	PricesIncludeTax bool
+	CreatedAt        time.Time
+	LastUpdated      sql.NullTime
 }
 
 type User struct {
@@ -184,6 +472,7 @@ type User struct {
 	UserRole       string
 	ProfilePicture sql.NullString
 	TwoFactorAuth  bool
+	TaxExempt      bool
 }
 
 type Wishlist struct {
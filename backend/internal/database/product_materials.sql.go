@@ -22,7 +22,7 @@ func (q *Queries) DeleteProductMaterial(ctx context.Context, id uuid.UUID) error
 }
 
 const getProductsByMaterial = `-- name: GetProductsByMaterial :many
-SELECT p.id, p.name, p.description, p.image_url, p.price, p.stock, p.brand, p.rating, p.review_count, p.discount_rate, p.keywords, p.is_active, p.created_at, p.last_updated, p.sub_category_id FROM products p
+SELECT p.id, p.name, p.description, p.image_url, p.price, p.stock, p.brand, p.rating, p.review_count, p.discount_rate, p.keywords, p.is_active, p.created_at, p.last_updated, p.sub_category_id, p.weight_grams, p.length_cm, p.width_cm, p.height_cm, p.sale_starts_at, p.sale_ends_at FROM products p
     INNER JOIN product_materials pm ON p.id = pm.product_id
 WHERE pm.material_id = $1
 LIMIT $2 OFFSET $3
@@ -59,6 +59,12 @@ func (q *Queries) GetProductsByMaterial(ctx context.Context, arg GetProductsByMa
 			&i.CreatedAt,
 			&i.LastUpdated,
 			&i.SubCategoryID,
+			&i.WeightGrams,
+			&i.LengthCm,
+			&i.WidthCm,
+			&i.HeightCm,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
 		); err != nil {
 			return nil, err
 		}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const checkProductStock = `-- name: CheckProductStock :many
@@ -46,7 +47,7 @@ const createProduct = `-- name: CreateProduct :one
 
 INSERT INTO products (id, name, description, image_url, price, stock, sub_category_id, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 0.0, 0, 0.0, $9, TRUE, NOW(), NULL)
-RETURNING id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id
+RETURNING id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id, weight_grams, length_cm, width_cm, height_cm, sale_starts_at, sale_ends_at
 `
 
 type CreateProductParams struct {
@@ -112,10 +113,32 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.CreatedAt,
 		&i.LastUpdated,
 		&i.SubCategoryID,
+		&i.WeightGrams,
+		&i.LengthCm,
+		&i.WidthCm,
+		&i.HeightCm,
+		&i.SaleStartsAt,
+		&i.SaleEndsAt,
 	)
 	return i, err
 }
 
+const decrementProductStock = `-- name: DecrementProductStock :exec
+UPDATE products SET
+    stock = stock - $2
+WHERE id = $1
+`
+
+type DecrementProductStockParams struct {
+	ID    uuid.UUID
+	Stock int32
+}
+
+func (q *Queries) DecrementProductStock(ctx context.Context, arg DecrementProductStockParams) error {
+	_, err := q.db.ExecContext(ctx, decrementProductStock, arg.ID, arg.Stock)
+	return err
+}
+
 const deleteProduct = `-- name: DeleteProduct :exec
 UPDATE products SET
     is_active = FALSE
@@ -128,7 +151,7 @@ func (q *Queries) DeleteProduct(ctx context.Context, id uuid.UUID) error {
 }
 
 const getAvailableProducts = `-- name: GetAvailableProducts :many
-SELECT id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id FROM products
+SELECT id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id, weight_grams, length_cm, width_cm, height_cm, sale_starts_at, sale_ends_at FROM products
 WHERE stock > 0 AND is_active = TRUE
 `
 
@@ -157,6 +180,12 @@ func (q *Queries) GetAvailableProducts(ctx context.Context) ([]Product, error) {
 			&i.CreatedAt,
 			&i.LastUpdated,
 			&i.SubCategoryID,
+			&i.WeightGrams,
+			&i.LengthCm,
+			&i.WidthCm,
+			&i.HeightCm,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
 		); err != nil {
 			return nil, err
 		}
@@ -172,7 +201,7 @@ func (q *Queries) GetAvailableProducts(ctx context.Context) ([]Product, error) {
 }
 
 const getProductById = `-- name: GetProductById :one
-SELECT id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id FROM products
+SELECT id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id, weight_grams, length_cm, width_cm, height_cm, sale_starts_at, sale_ends_at FROM products
 WHERE id = $1
 `
 
@@ -195,6 +224,12 @@ func (q *Queries) GetProductById(ctx context.Context, id uuid.UUID) (Product, er
 		&i.CreatedAt,
 		&i.LastUpdated,
 		&i.SubCategoryID,
+		&i.WeightGrams,
+		&i.LengthCm,
+		&i.WidthCm,
+		&i.HeightCm,
+		&i.SaleStartsAt,
+		&i.SaleEndsAt,
 	)
 	return i, err
 }
@@ -226,7 +261,7 @@ func (q *Queries) GetProductCountByCategory(ctx context.Context, id uuid.UUID) (
 }
 
 const getProducts = `-- name: GetProducts :many
-SELECT id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id FROM products
+SELECT id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id, weight_grams, length_cm, width_cm, height_cm, sale_starts_at, sale_ends_at FROM products
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
 `
@@ -261,6 +296,12 @@ func (q *Queries) GetProducts(ctx context.Context, arg GetProductsParams) ([]Pro
 			&i.CreatedAt,
 			&i.LastUpdated,
 			&i.SubCategoryID,
+			&i.WeightGrams,
+			&i.LengthCm,
+			&i.WidthCm,
+			&i.HeightCm,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
 		); err != nil {
 			return nil, err
 		}
@@ -276,7 +317,7 @@ func (q *Queries) GetProducts(ctx context.Context, arg GetProductsParams) ([]Pro
 }
 
 const getProductsByCategory = `-- name: GetProductsByCategory :many
-SELECT p.id, p.name, p.description, p.image_url, p.price, p.stock, p.brand, p.rating, p.review_count, p.discount_rate, p.keywords, p.is_active, p.created_at, p.last_updated, p.sub_category_id, sc.name AS sub_category_name, c.name AS category_name
+SELECT p.id, p.name, p.description, p.image_url, p.price, p.stock, p.brand, p.rating, p.review_count, p.discount_rate, p.keywords, p.is_active, p.created_at, p.last_updated, p.sub_category_id, p.weight_grams, p.length_cm, p.width_cm, p.height_cm, p.sale_starts_at, p.sale_ends_at, sc.name AS sub_category_name, c.name AS category_name
 FROM products p
     INNER JOIN sub_categories sc ON p.sub_category_id = sc.id
     INNER JOIN categories c ON sc.category_id = c.id
@@ -307,6 +348,12 @@ type GetProductsByCategoryRow struct {
 	CreatedAt       time.Time
 	LastUpdated     sql.NullTime
 	SubCategoryID   uuid.NullUUID
+	WeightGrams     int32
+	LengthCm        sql.NullInt32
+	WidthCm         sql.NullInt32
+	HeightCm        sql.NullInt32
+	SaleStartsAt    sql.NullTime
+	SaleEndsAt      sql.NullTime
 	SubCategoryName string
 	CategoryName    string
 }
@@ -336,6 +383,12 @@ func (q *Queries) GetProductsByCategory(ctx context.Context, arg GetProductsByCa
 			&i.CreatedAt,
 			&i.LastUpdated,
 			&i.SubCategoryID,
+			&i.WeightGrams,
+			&i.LengthCm,
+			&i.WidthCm,
+			&i.HeightCm,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
 			&i.SubCategoryName,
 			&i.CategoryName,
 		); err != nil {
@@ -353,15 +406,16 @@ func (q *Queries) GetProductsByCategory(ctx context.Context, arg GetProductsByCa
 }
 
 const getSalesTrends = `-- name: GetSalesTrends :many
-SELECT DATE_TRUNC('month', created_at) AS month, SUM(price) AS total_sales
+SELECT DATE_TRUNC('month', created_at)::TIMESTAMP AS month, SUM(total_price)::DECIMAL AS total_sales
 FROM orders
+WHERE order_status <> 'cancelled'
 GROUP BY month
 ORDER BY month
 `
 
 type GetSalesTrendsRow struct {
-	Month      int64
-	TotalSales int64
+	Month      time.Time
+	TotalSales string
 }
 
 func (q *Queries) GetSalesTrends(ctx context.Context) ([]GetSalesTrendsRow, error) {
@@ -411,7 +465,10 @@ SELECT
     sc.name AS sub_category_name,
     c.id AS category_id,
     c.name AS category_name,
-    tp.sales_volume
+    tp.sales_volume,
+    p.discount_rate,
+    p.sale_starts_at,
+    p.sale_ends_at
 FROM
     TrendingProducts tp
         JOIN products p ON tp.product_id = p.id
@@ -430,6 +487,9 @@ type GetTrendingProductsRow struct {
 	CategoryID      uuid.UUID
 	CategoryName    string
 	SalesVolume     int64
+	DiscountRate    string
+	SaleStartsAt    sql.NullTime
+	SaleEndsAt      sql.NullTime
 }
 
 func (q *Queries) GetTrendingProducts(ctx context.Context) ([]GetTrendingProductsRow, error) {
@@ -450,6 +510,9 @@ func (q *Queries) GetTrendingProducts(ctx context.Context) ([]GetTrendingProduct
 			&i.CategoryID,
 			&i.CategoryName,
 			&i.SalesVolume,
+			&i.DiscountRate,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
 		); err != nil {
 			return nil, err
 		}
@@ -489,7 +552,10 @@ SELECT
     sc.name AS sub_category_name,
     c.id AS category_id,
     c.name AS category_name,
-    tp.sales_volume
+    tp.sales_volume,
+    p.discount_rate,
+    p.sale_starts_at,
+    p.sale_ends_at
 FROM
     TrendingProducts tp
         JOIN products p ON tp.product_id = p.id
@@ -508,6 +574,9 @@ type GetTrendingProductsByCategoryRow struct {
 	CategoryID      uuid.UUID
 	CategoryName    string
 	SalesVolume     int64
+	DiscountRate    string
+	SaleStartsAt    sql.NullTime
+	SaleEndsAt      sql.NullTime
 }
 
 func (q *Queries) GetTrendingProductsByCategory(ctx context.Context, categoryID uuid.UUID) ([]GetTrendingProductsByCategoryRow, error) {
@@ -528,6 +597,67 @@ func (q *Queries) GetTrendingProductsByCategory(ctx context.Context, categoryID
 			&i.CategoryID,
 			&i.CategoryName,
 			&i.SalesVolume,
+			&i.DiscountRate,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockProductsForCheckout = `-- name: LockProductsForCheckout :many
+SELECT id, name, stock, is_active, price, discount_rate, sale_starts_at, sale_ends_at,
+       COALESCE((
+           SELECT sc.tax_class
+           FROM sub_categories sc
+           WHERE sc.id = products.sub_category_id
+       ), 'standard')::VARCHAR AS tax_class
+FROM products
+WHERE id = ANY($1::UUID[])
+ORDER BY id
+FOR UPDATE
+`
+
+type LockProductsForCheckoutRow struct {
+	ID           uuid.UUID
+	Name         string
+	Stock        int32
+	IsActive     bool
+	Price        string
+	DiscountRate string
+	SaleStartsAt sql.NullTime
+	SaleEndsAt   sql.NullTime
+	TaxClass     string
+}
+
+func (q *Queries) LockProductsForCheckout(ctx context.Context, productIds []uuid.UUID) ([]LockProductsForCheckoutRow, error) {
+	rows, err := q.db.QueryContext(ctx, lockProductsForCheckout, pq.Array(productIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LockProductsForCheckoutRow
+	for rows.Next() {
+		var i LockProductsForCheckoutRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Stock,
+			&i.IsActive,
+			&i.Price,
+			&i.DiscountRate,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
+			&i.TaxClass,
 		); err != nil {
 			return nil, err
 		}
@@ -543,7 +673,7 @@ func (q *Queries) GetTrendingProductsByCategory(ctx context.Context, categoryID
 }
 
 const searchProducts = `-- name: SearchProducts :many
-SELECT id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id FROM products
+SELECT id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id, weight_grams, length_cm, width_cm, height_cm, sale_starts_at, sale_ends_at FROM products
 WHERE name ILIKE '%' || $1 || '%' OR keywords ILIKE '%' || $1 || '%'
 `
 
@@ -572,6 +702,12 @@ func (q *Queries) SearchProducts(ctx context.Context, dollar_1 sql.NullString) (
 			&i.CreatedAt,
 			&i.LastUpdated,
 			&i.SubCategoryID,
+			&i.WeightGrams,
+			&i.LengthCm,
+			&i.WidthCm,
+			&i.HeightCm,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
 		); err != nil {
 			return nil, err
 		}
@@ -595,14 +731,12 @@ UPDATE products SET
     stock = $6,
     sub_category_id = $7,
     brand = $8,
-    rating = $9,
-    review_count = $10,
-    discount_rate = $11,
-    keywords = $12,
-    is_active = $13,
+    discount_rate = $9,
+    keywords = $10,
+    is_active = $11,
     last_updated = NOW()
 WHERE id = $1
-RETURNING id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id
+RETURNING id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id, weight_grams, length_cm, width_cm, height_cm, sale_starts_at, sale_ends_at
 `
 
 type UpdateProductParams struct {
@@ -614,8 +748,6 @@ type UpdateProductParams struct {
 	Stock         int32
 	SubCategoryID uuid.NullUUID
 	Brand         sql.NullString
-	Rating        string
-	ReviewCount   int32
 	DiscountRate  string
 	Keywords      sql.NullString
 	IsActive      bool
@@ -631,8 +763,6 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		arg.Stock,
 		arg.SubCategoryID,
 		arg.Brand,
-		arg.Rating,
-		arg.ReviewCount,
 		arg.DiscountRate,
 		arg.Keywords,
 		arg.IsActive,
@@ -654,6 +784,73 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.CreatedAt,
 		&i.LastUpdated,
 		&i.SubCategoryID,
+		&i.WeightGrams,
+		&i.LengthCm,
+		&i.WidthCm,
+		&i.HeightCm,
+		&i.SaleStartsAt,
+		&i.SaleEndsAt,
 	)
 	return i, err
 }
+
+const updateProductSale = `-- name: UpdateProductSale :execrows
+UPDATE products SET
+    discount_rate = $2,
+    sale_starts_at = $3,
+    sale_ends_at = $4,
+    last_updated = NOW()
+WHERE id = $1
+`
+
+type UpdateProductSaleParams struct {
+	ID           uuid.UUID
+	DiscountRate string
+	SaleStartsAt sql.NullTime
+	SaleEndsAt   sql.NullTime
+}
+
+func (q *Queries) UpdateProductSale(ctx context.Context, arg UpdateProductSaleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateProductSale,
+		arg.ID,
+		arg.DiscountRate,
+		arg.SaleStartsAt,
+		arg.SaleEndsAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateProductShipping = `-- name: UpdateProductShipping :execrows
+UPDATE products SET
+    weight_grams = $2,
+    length_cm = $3,
+    width_cm = $4,
+    height_cm = $5,
+    last_updated = NOW()
+WHERE id = $1
+`
+
+type UpdateProductShippingParams struct {
+	ID          uuid.UUID
+	WeightGrams int32
+	LengthCm    sql.NullInt32
+	WidthCm     sql.NullInt32
+	HeightCm    sql.NullInt32
+}
+
+func (q *Queries) UpdateProductShipping(ctx context.Context, arg UpdateProductShippingParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateProductShipping,
+		arg.ID,
+		arg.WeightGrams,
+		arg.LengthCm,
+		arg.WidthCm,
+		arg.HeightCm,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
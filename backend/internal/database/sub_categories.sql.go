@@ -13,9 +13,9 @@ import (
 )
 
 const createSubCategory = `-- name: CreateSubCategory :one
-INSERT INTO sub_categories (id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated)
-VALUES ($1, $2, $3, $4, $5, $6, true, NOW(), NOW())
-RETURNING id, category_id, name, description, image_url, seo_keywords, is_active, created_at, last_updated
+INSERT INTO sub_categories (id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated, tax_class)
+VALUES ($1, $2, $3, $4, $5, $6, true, NOW(), NOW(), $7)
+RETURNING id, category_id, name, description, image_url, seo_keywords, is_active, created_at, last_updated, tax_class
 `
 
 type CreateSubCategoryParams struct {
@@ -25,6 +25,7 @@ type CreateSubCategoryParams struct {
 	Description sql.NullString
 	ImageUrl    sql.NullString
 	SeoKeywords sql.NullString
+	TaxClass    string
 }
 
 func (q *Queries) CreateSubCategory(ctx context.Context, arg CreateSubCategoryParams) (SubCategory, error) {
@@ -35,6 +36,7 @@ func (q *Queries) CreateSubCategory(ctx context.Context, arg CreateSubCategoryPa
 		arg.Description,
 		arg.ImageUrl,
 		arg.SeoKeywords,
+		arg.TaxClass,
 	)
 	var i SubCategory
 	err := row.Scan(
@@ -47,6 +49,7 @@ func (q *Queries) CreateSubCategory(ctx context.Context, arg CreateSubCategoryPa
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.LastUpdated,
+		&i.TaxClass,
 	)
 	return i, err
 }
@@ -62,7 +65,7 @@ func (q *Queries) DeleteSubCategory(ctx context.Context, id uuid.UUID) error {
 }
 
 const getProductBySubCategory = `-- name: GetProductBySubCategory :many
-SELECT id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id
+SELECT id, name, description, image_url, price, stock, brand, rating, review_count, discount_rate, keywords, is_active, created_at, last_updated, sub_category_id, weight_grams, length_cm, width_cm, height_cm, sale_starts_at, sale_ends_at
 FROM products
 WHERE sub_category_id = $1
 ORDER BY created_at DESC
@@ -100,6 +103,12 @@ func (q *Queries) GetProductBySubCategory(ctx context.Context, arg GetProductByS
 			&i.CreatedAt,
 			&i.LastUpdated,
 			&i.SubCategoryID,
+			&i.WeightGrams,
+			&i.LengthCm,
+			&i.WidthCm,
+			&i.HeightCm,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
 		); err != nil {
 			return nil, err
 		}
@@ -128,7 +137,7 @@ func (q *Queries) GetProductCountBySubCategory(ctx context.Context, subCategoryI
 }
 
 const getSubCategory = `-- name: GetSubCategory :one
-SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated
+SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated, tax_class
 FROM sub_categories
 WHERE id = $1
 `
@@ -146,12 +155,13 @@ func (q *Queries) GetSubCategory(ctx context.Context, id uuid.UUID) (SubCategory
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.LastUpdated,
+		&i.TaxClass,
 	)
 	return i, err
 }
 
 const getSubCategoryByCategory = `-- name: GetSubCategoryByCategory :many
-SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated
+SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated, tax_class
 FROM sub_categories
 WHERE category_id = $1
 ORDER BY created_at DESC
@@ -183,6 +193,7 @@ func (q *Queries) GetSubCategoryByCategory(ctx context.Context, arg GetSubCatego
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.LastUpdated,
+			&i.TaxClass,
 		); err != nil {
 			return nil, err
 		}
@@ -211,7 +222,7 @@ func (q *Queries) GetSubCategoryCountByCategory(ctx context.Context, categoryID
 }
 
 const listAllSubCategories = `-- name: ListAllSubCategories :many
-SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated
+SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated, tax_class
 FROM sub_categories
 `
 
@@ -234,6 +245,7 @@ func (q *Queries) ListAllSubCategories(ctx context.Context) ([]SubCategory, erro
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.LastUpdated,
+			&i.TaxClass,
 		); err != nil {
 			return nil, err
 		}
@@ -248,6 +260,26 @@ func (q *Queries) ListAllSubCategories(ctx context.Context) ([]SubCategory, erro
 	return items, nil
 }
 
+const setSubCategoryTaxClass = `-- name: SetSubCategoryTaxClass :execrows
+UPDATE sub_categories SET
+    tax_class = $2,
+    last_updated = NOW()
+WHERE id = $1
+`
+
+type SetSubCategoryTaxClassParams struct {
+	ID       uuid.UUID
+	TaxClass string
+}
+
+func (q *Queries) SetSubCategoryTaxClass(ctx context.Context, arg SetSubCategoryTaxClassParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setSubCategoryTaxClass, arg.ID, arg.TaxClass)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateSubCategory = `-- name: UpdateSubCategory :one
 UPDATE sub_categories SET
     category_id = $2,
@@ -255,9 +287,10 @@ UPDATE sub_categories SET
     description = $4,
     image_url = $5,
     SEO_keywords = $6,
+    tax_class = $7,
     last_updated = NOW()
 WHERE id = $1
-RETURNING id, category_id, name, description, image_url, seo_keywords, is_active, created_at, last_updated
+RETURNING id, category_id, name, description, image_url, seo_keywords, is_active, created_at, last_updated, tax_class
 `
 
 type UpdateSubCategoryParams struct {
@@ -267,6 +300,7 @@ type UpdateSubCategoryParams struct {
 	Description sql.NullString
 	ImageUrl    sql.NullString
 	SeoKeywords sql.NullString
+	TaxClass    string
 }
 
 func (q *Queries) UpdateSubCategory(ctx context.Context, arg UpdateSubCategoryParams) (SubCategory, error) {
@@ -277,6 +311,7 @@ func (q *Queries) UpdateSubCategory(ctx context.Context, arg UpdateSubCategoryPa
 		arg.Description,
 		arg.ImageUrl,
 		arg.SeoKeywords,
+		arg.TaxClass,
 	)
 	var i SubCategory
 	err := row.Scan(
@@ -289,6 +324,7 @@ func (q *Queries) UpdateSubCategory(ctx context.Context, arg UpdateSubCategoryPa
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.LastUpdated,
+		&i.TaxClass,
 	)
 	return i, err
 }
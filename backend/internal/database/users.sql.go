@@ -17,7 +17,7 @@ const activateUser = `-- name: ActivateUser :one
 UPDATE users SET
     account_status = 'active'
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 func (q *Queries) ActivateUser(ctx context.Context, id uuid.UUID) (User, error) {
@@ -39,6 +39,7 @@ func (q *Queries) ActivateUser(ctx context.Context, id uuid.UUID) (User, error)
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
@@ -153,7 +154,7 @@ func (q *Queries) CountSuspendedUsers(ctx context.Context) (int64, error) {
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (id, email, hashed_password, username, first_name, last_name, user_role)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 type CreateUserParams struct {
@@ -193,6 +194,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
@@ -201,7 +203,7 @@ const deactivateUser = `-- name: DeactivateUser :one
 UPDATE users SET
     account_status = 'inactive'
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 func (q *Queries) DeactivateUser(ctx context.Context, id uuid.UUID) (User, error) {
@@ -223,6 +225,7 @@ func (q *Queries) DeactivateUser(ctx context.Context, id uuid.UUID) (User, error
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
@@ -242,7 +245,7 @@ const demoteUserToCustomer = `-- name: DemoteUserToCustomer :one
 UPDATE users SET
     user_role = 'customer'
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 func (q *Queries) DemoteUserToCustomer(ctx context.Context, id uuid.UUID) (User, error) {
@@ -264,6 +267,7 @@ func (q *Queries) DemoteUserToCustomer(ctx context.Context, id uuid.UUID) (User,
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
@@ -272,7 +276,7 @@ const disableTwoFactorAuth = `-- name: DisableTwoFactorAuth :one
 UPDATE users SET
     two_factor_auth = FALSE
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 func (q *Queries) DisableTwoFactorAuth(ctx context.Context, id uuid.UUID) (User, error) {
@@ -294,6 +298,7 @@ func (q *Queries) DisableTwoFactorAuth(ctx context.Context, id uuid.UUID) (User,
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
@@ -302,7 +307,7 @@ const enableTwoFactorAuth = `-- name: EnableTwoFactorAuth :one
 UPDATE users SET
     two_factor_auth = TRUE
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 func (q *Queries) EnableTwoFactorAuth(ctx context.Context, id uuid.UUID) (User, error) {
@@ -324,12 +329,13 @@ func (q *Queries) EnableTwoFactorAuth(ctx context.Context, id uuid.UUID) (User,
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
 
 const findUserByEmail = `-- name: FindUserByEmail :one
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE email = $1
 `
 
@@ -352,12 +358,13 @@ func (q *Queries) FindUserByEmail(ctx context.Context, email string) (User, erro
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
 
 const findUserByID = `-- name: FindUserByID :one
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE id = $1
 `
 
@@ -380,12 +387,13 @@ func (q *Queries) FindUserByID(ctx context.Context, id uuid.UUID) (User, error)
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
 
 const findUserByPassword = `-- name: FindUserByPassword :one
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE hashed_password = $1
 `
 
@@ -408,12 +416,13 @@ func (q *Queries) FindUserByPassword(ctx context.Context, hashedPassword string)
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
 
 const findUserByUsername = `-- name: FindUserByUsername :one
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE username = $1
 `
 
@@ -436,12 +445,13 @@ func (q *Queries) FindUserByUsername(ctx context.Context, username string) (User
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
 
 const getActiveUsers = `-- name: GetActiveUsers :many
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE account_status = 'active'
 LIMIT $1 OFFSET $2
 `
@@ -476,6 +486,7 @@ func (q *Queries) GetActiveUsers(ctx context.Context, arg GetActiveUsersParams)
 			&i.UserRole,
 			&i.ProfilePicture,
 			&i.TwoFactorAuth,
+			&i.TaxExempt,
 		); err != nil {
 			return nil, err
 		}
@@ -491,7 +502,7 @@ func (q *Queries) GetActiveUsers(ctx context.Context, arg GetActiveUsersParams)
 }
 
 const getAdminUsers = `-- name: GetAdminUsers :many
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE user_role = 'admin'
 LIMIT $1 OFFSET $2
 `
@@ -526,6 +537,7 @@ func (q *Queries) GetAdminUsers(ctx context.Context, arg GetAdminUsersParams) ([
 			&i.UserRole,
 			&i.ProfilePicture,
 			&i.TwoFactorAuth,
+			&i.TaxExempt,
 		); err != nil {
 			return nil, err
 		}
@@ -541,7 +553,7 @@ func (q *Queries) GetAdminUsers(ctx context.Context, arg GetAdminUsersParams) ([
 }
 
 const getAllUsers = `-- name: GetAllUsers :many
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 LIMIT $1 OFFSET $2
 `
 
@@ -575,6 +587,7 @@ func (q *Queries) GetAllUsers(ctx context.Context, arg GetAllUsersParams) ([]Use
 			&i.UserRole,
 			&i.ProfilePicture,
 			&i.TwoFactorAuth,
+			&i.TaxExempt,
 		); err != nil {
 			return nil, err
 		}
@@ -590,7 +603,7 @@ func (q *Queries) GetAllUsers(ctx context.Context, arg GetAllUsersParams) ([]Use
 }
 
 const getCustomers = `-- name: GetCustomers :many
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE user_role = 'customer'
 LIMIT $1 OFFSET $2
 `
@@ -625,6 +638,7 @@ func (q *Queries) GetCustomers(ctx context.Context, arg GetCustomersParams) ([]U
 			&i.UserRole,
 			&i.ProfilePicture,
 			&i.TwoFactorAuth,
+			&i.TaxExempt,
 		); err != nil {
 			return nil, err
 		}
@@ -640,7 +654,7 @@ func (q *Queries) GetCustomers(ctx context.Context, arg GetCustomersParams) ([]U
 }
 
 const getDeletedUsers = `-- name: GetDeletedUsers :many
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE account_status = 'deleted'
 LIMIT $1 OFFSET $2
 `
@@ -675,6 +689,7 @@ func (q *Queries) GetDeletedUsers(ctx context.Context, arg GetDeletedUsersParams
 			&i.UserRole,
 			&i.ProfilePicture,
 			&i.TwoFactorAuth,
+			&i.TaxExempt,
 		); err != nil {
 			return nil, err
 		}
@@ -690,7 +705,7 @@ func (q *Queries) GetDeletedUsers(ctx context.Context, arg GetDeletedUsersParams
 }
 
 const getInactiveUsers = `-- name: GetInactiveUsers :many
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE account_status = 'inactive'
 LIMIT $1 OFFSET $2
 `
@@ -725,6 +740,7 @@ func (q *Queries) GetInactiveUsers(ctx context.Context, arg GetInactiveUsersPara
 			&i.UserRole,
 			&i.ProfilePicture,
 			&i.TwoFactorAuth,
+			&i.TaxExempt,
 		); err != nil {
 			return nil, err
 		}
@@ -759,7 +775,7 @@ func (q *Queries) GetRefreshTokenByUserID(ctx context.Context, userID uuid.UUID)
 }
 
 const getSuperAdminUsers = `-- name: GetSuperAdminUsers :many
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE user_role = 'superadmin'
 LIMIT $1 OFFSET $2
 `
@@ -794,6 +810,7 @@ func (q *Queries) GetSuperAdminUsers(ctx context.Context, arg GetSuperAdminUsers
 			&i.UserRole,
 			&i.ProfilePicture,
 			&i.TwoFactorAuth,
+			&i.TaxExempt,
 		); err != nil {
 			return nil, err
 		}
@@ -809,7 +826,7 @@ func (q *Queries) GetSuperAdminUsers(ctx context.Context, arg GetSuperAdminUsers
 }
 
 const getSuspendedUsers = `-- name: GetSuspendedUsers :many
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE account_status = 'suspended'
 LIMIT $1 OFFSET $2
 `
@@ -844,6 +861,7 @@ func (q *Queries) GetSuspendedUsers(ctx context.Context, arg GetSuspendedUsersPa
 			&i.UserRole,
 			&i.ProfilePicture,
 			&i.TwoFactorAuth,
+			&i.TaxExempt,
 		); err != nil {
 			return nil, err
 		}
@@ -859,7 +877,7 @@ func (q *Queries) GetSuspendedUsers(ctx context.Context, arg GetSuspendedUsersPa
 }
 
 const getUserByRefreshToken = `-- name: GetUserByRefreshToken :one
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE id = (
     SELECT user_id FROM refresh_tokens
     WHERE token = $1
@@ -885,12 +903,13 @@ func (q *Queries) GetUserByRefreshToken(ctx context.Context, token string) (User
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
 
 const partialFindUsersByUsername = `-- name: PartialFindUsersByUsername :many
-SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth FROM users
+SELECT id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt FROM users
 WHERE username LIKE $1
 LIMIT $2 OFFSET $3
 `
@@ -926,6 +945,7 @@ func (q *Queries) PartialFindUsersByUsername(ctx context.Context, arg PartialFin
 			&i.UserRole,
 			&i.ProfilePicture,
 			&i.TwoFactorAuth,
+			&i.TaxExempt,
 		); err != nil {
 			return nil, err
 		}
@@ -944,7 +964,7 @@ const promoteUserToAdmin = `-- name: PromoteUserToAdmin :one
 UPDATE users SET
     user_role = 'admin'
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 func (q *Queries) PromoteUserToAdmin(ctx context.Context, id uuid.UUID) (User, error) {
@@ -966,6 +986,7 @@ func (q *Queries) PromoteUserToAdmin(ctx context.Context, id uuid.UUID) (User, e
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
@@ -974,7 +995,7 @@ const promoteUserToSuperAdmin = `-- name: PromoteUserToSuperAdmin :one
 UPDATE users SET
     user_role = 'superadmin'
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 func (q *Queries) PromoteUserToSuperAdmin(ctx context.Context, id uuid.UUID) (User, error) {
@@ -996,6 +1017,7 @@ func (q *Queries) PromoteUserToSuperAdmin(ctx context.Context, id uuid.UUID) (Us
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
@@ -1004,7 +1026,7 @@ const recoverUser = `-- name: RecoverUser :one
 UPDATE users SET
     account_status = 'active'
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 func (q *Queries) RecoverUser(ctx context.Context, id uuid.UUID) (User, error) {
@@ -1026,10 +1048,30 @@ func (q *Queries) RecoverUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
 
+const setUserTaxExempt = `-- name: SetUserTaxExempt :execrows
+UPDATE users SET
+    tax_exempt = $2
+WHERE id = $1
+`
+
+type SetUserTaxExemptParams struct {
+	ID        uuid.UUID
+	TaxExempt bool
+}
+
+func (q *Queries) SetUserTaxExempt(ctx context.Context, arg SetUserTaxExemptParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setUserTaxExempt, arg.ID, arg.TaxExempt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const storeRefreshToken = `-- name: StoreRefreshToken :one
 INSERT INTO refresh_tokens (id, user_id, token, created_at, expires_at)
 VALUES ($1, $2, $3, $4, $5)
@@ -1068,7 +1110,7 @@ const suspendUser = `-- name: SuspendUser :one
 UPDATE users SET
     account_status = 'suspended'
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 func (q *Queries) SuspendUser(ctx context.Context, id uuid.UUID) (User, error) {
@@ -1090,6 +1132,7 @@ func (q *Queries) SuspendUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
@@ -1104,7 +1147,7 @@ UPDATE users SET
     date_of_birth = $7,
     gender = $8
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 type UpdateUserParams struct {
@@ -1146,6 +1189,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
@@ -1170,7 +1214,7 @@ const updateUserPassword = `-- name: UpdateUserPassword :one
 UPDATE users SET
     hashed_password = $2
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 type UpdateUserPasswordParams struct {
@@ -1197,6 +1241,7 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
@@ -1205,7 +1250,7 @@ const updateUserProfilePicture = `-- name: UpdateUserProfilePicture :one
 UPDATE users SET
     profile_picture = $2
 WHERE id = $1
-RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth
+RETURNING id, username, email, hashed_password, first_name, last_name, phone_number, date_of_birth, gender, created_at, last_login, account_status, user_role, profile_picture, two_factor_auth, tax_exempt
 `
 
 type UpdateUserProfilePictureParams struct {
@@ -1232,6 +1277,7 @@ func (q *Queries) UpdateUserProfilePicture(ctx context.Context, arg UpdateUserPr
 		&i.UserRole,
 		&i.ProfilePicture,
 		&i.TwoFactorAuth,
+		&i.TaxExempt,
 	)
 	return i, err
 }
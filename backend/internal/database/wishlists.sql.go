@@ -67,11 +67,11 @@ func (q *Queries) DeleteWishlist(ctx context.Context, id uuid.UUID) error {
 }
 
 const findCommonWishlistLists = `-- name: FindCommonWishlistLists :many
-SELECT product_id, COUNT(DISTINCT user_id) AS user_count
+SELECT wi.product_id, COUNT(DISTINCT w.user_id) AS user_count
 FROM wishlist_items wi
 JOIN wishlists w ON wi.wishlist_id = w.id
-GROUP BY product_id
-HAVING user_count > 1
+GROUP BY wi.product_id
+HAVING COUNT(DISTINCT w.user_id) > 1
 `
 
 type FindCommonWishlistListsRow struct {
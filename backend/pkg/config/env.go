@@ -3,15 +3,23 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Port            string
-	DbUrl           string
-	DefaultPageSize int32
-	DefaultPage     int32
+	Port                         string
+	DbUrl                        string
+	DefaultPageSize              int32
+	DefaultPage                  int32
+	ReturnWindowDays             int
+	PaymentWebhookSecrets        map[string]string
+	ReviewBannedWords            []string
+	ReviewReportThreshold        int
+	RecentlyViewedRetention      int
+	RecommendationRefreshMinutes int
 }
 
 func LoadConfig() Config {
@@ -22,16 +30,32 @@ func LoadConfig() Config {
 		// To be removed
 		log.Println("Error loadding .env file:", err)
 		return Config{
-			Port: "8000",
-			DbUrl: "postgresql://postgres:staphone@16@localhost:5432/ecommerce?sslmode=disable",
+			Port:             "8000",
+			DbUrl:            "postgresql://postgres:staphone@16@localhost:5432/ecommerce?sslmode=disable",
+			ReturnWindowDays: getEnvAsInt("RETURN_WINDOW_DAYS", 30),
+			PaymentWebhookSecrets: map[string]string{
+				"fake": getEnv("FAKE_PAYMENT_WEBHOOK_SECRET", ""),
+			},
+			ReviewBannedWords:            getEnvAsList("REVIEW_BANNED_WORDS"),
+			ReviewReportThreshold:        getEnvAsInt("REVIEW_REPORT_THRESHOLD", 3),
+			RecentlyViewedRetention:      getEnvAsInt("RECENTLY_VIEWED_RETENTION", 50),
+			RecommendationRefreshMinutes: getEnvAsInt("RECOMMENDATION_REFRESH_MINUTES", 60),
 		}
 	}
 
 	return Config{
-		Port:            getEnv("PORT", "8080"),
-		DbUrl:           getEnv("DB_URL", ""),
-		DefaultPageSize: 100,
-		DefaultPage:     1,
+		Port:             getEnv("PORT", "8080"),
+		DbUrl:            getEnv("DB_URL", ""),
+		DefaultPageSize:  100,
+		DefaultPage:      1,
+		ReturnWindowDays: getEnvAsInt("RETURN_WINDOW_DAYS", 30),
+		PaymentWebhookSecrets: map[string]string{
+			"fake": getEnv("FAKE_PAYMENT_WEBHOOK_SECRET", ""),
+		},
+		ReviewBannedWords:            getEnvAsList("REVIEW_BANNED_WORDS"),
+		ReviewReportThreshold:        getEnvAsInt("REVIEW_REPORT_THRESHOLD", 3),
+		RecentlyViewedRetention:      getEnvAsInt("RECENTLY_VIEWED_RETENTION", 50),
+		RecommendationRefreshMinutes: getEnvAsInt("RECOMMENDATION_REFRESH_MINUTES", 60),
 	}
 }
 
@@ -42,3 +66,30 @@ func getEnv(key string, fallback string) string {
 
 	return fallback
 }
+
+func getEnvAsInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid value for %s, using %d: %v", key, fallback, err)
+		return fallback
+	}
+
+	return intValue
+}
+
+// getEnvAsList splits a comma-separated value, dropping empty entries
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
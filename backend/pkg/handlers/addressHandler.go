@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/postal"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type AddressHandler struct {
+	addressService *usecases.AddressService
+}
+
+func NewAddressHandler(addressService *usecases.AddressService) *AddressHandler {
+	return &AddressHandler{
+		addressService: addressService,
+	}
+}
+
+// GetAddresses gets the logged-in user's shipping and billing addresses
+func (h *AddressHandler) GetAddresses(w http.ResponseWriter, r *http.Request) {
+	// get address book
+	book, err := h.addressService.GetAddresses(r.Context())
+	if err != nil {
+		respondWithAddressError(w, err, "Failed to get addresses")
+		return
+	}
+
+	// respond with address book
+	RespondWithJSON(w, http.StatusOK, book)
+}
+
+// CreateAddress adds a shipping or billing address to the logged-in user's address book
+func (h *AddressHandler) CreateAddress(w http.ResponseWriter, r *http.Request) {
+	// get address type
+	vars := mux.Vars(r)
+
+	// params
+	var params struct {
+		Street     string `json:"street"`
+		City       string `json:"city"`
+		State      string `json:"state"`
+		Country    string `json:"country"`
+		PostalCode string `json:"postal_code"`
+		IsDefault  bool   `json:"is_default"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// create address
+	address, err := h.addressService.CreateAddress(r.Context(), model.SaveAddressParams{
+		Type:       vars["type"],
+		Street:     params.Street,
+		City:       params.City,
+		State:      params.State,
+		Country:    params.Country,
+		PostalCode: params.PostalCode,
+		IsDefault:  params.IsDefault,
+	})
+	if err != nil {
+		respondWithAddressError(w, err, "Failed to create address")
+		return
+	}
+
+	// respond with address
+	RespondWithJSON(w, http.StatusCreated, address)
+}
+
+// UpdateAddress changes an address in the logged-in user's address book
+func (h *AddressHandler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	// get address type and id
+	vars := mux.Vars(r)
+	addressId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid address id")
+		return
+	}
+
+	// params
+	var params struct {
+		Street     string `json:"street"`
+		City       string `json:"city"`
+		State      string `json:"state"`
+		Country    string `json:"country"`
+		PostalCode string `json:"postal_code"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// update address
+	address, err := h.addressService.UpdateAddress(r.Context(), addressId, model.SaveAddressParams{
+		Type:       vars["type"],
+		Street:     params.Street,
+		City:       params.City,
+		State:      params.State,
+		Country:    params.Country,
+		PostalCode: params.PostalCode,
+	})
+	if err != nil {
+		respondWithAddressError(w, err, "Failed to update address")
+		return
+	}
+
+	// respond with address
+	RespondWithJSON(w, http.StatusOK, address)
+}
+
+// SetDefaultAddress makes an address the logged-in user's default of its type
+func (h *AddressHandler) SetDefaultAddress(w http.ResponseWriter, r *http.Request) {
+	// get address type and id
+	vars := mux.Vars(r)
+	addressId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid address id")
+		return
+	}
+
+	// set default address
+	address, err := h.addressService.SetDefaultAddress(r.Context(), vars["type"], addressId)
+	if err != nil {
+		respondWithAddressError(w, err, "Failed to set default address")
+		return
+	}
+
+	// respond with address
+	RespondWithJSON(w, http.StatusOK, address)
+}
+
+// DeleteAddress removes an address from the logged-in user's address book
+func (h *AddressHandler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	// get address type and id
+	vars := mux.Vars(r)
+	addressId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid address id")
+		return
+	}
+
+	// delete address
+	if err := h.addressService.DeleteAddress(r.Context(), vars["type"], addressId); err != nil {
+		respondWithAddressError(w, err, "Failed to delete address")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Address deleted")
+}
+
+func respondWithAddressError(w http.ResponseWriter, err error, message string) {
+	var validationErr *postal.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		RespondWithValidationErrors(w, http.StatusBadRequest, "Invalid address", validationErr.Fields)
+	case errors.Is(err, usecases.ErrInvalidAddressType):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrAddressNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/gorilla/mux"
+)
+
+type CartHandler struct {
+	cartService *usecases.CartService
+}
+
+func NewCartHandler(cartService *usecases.CartService) *CartHandler {
+	return &CartHandler{
+		cartService: cartService,
+	}
+}
+
+// GetCart returns the logged-in user's cart
+func (h *CartHandler) GetCart(w http.ResponseWriter, r *http.Request) {
+	// get cart
+	cart, err := h.cartService.GetCart(r.Context())
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get cart: %v", err))
+		return
+	}
+
+	// respond with cart
+	RespondWithJSON(w, http.StatusOK, cart)
+}
+
+// GetShippingOptions quotes the ways the logged-in user's cart can be shipped
+func (h *CartHandler) GetShippingOptions(w http.ResponseWriter, r *http.Request) {
+	// get shipping options
+	quote, err := h.cartService.GetShippingOptions(r.Context())
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get shipping options: %v", err))
+		return
+	}
+
+	// respond with shipping options
+	RespondWithJSON(w, http.StatusOK, quote)
+}
+
+// AddItem adds a product to the logged-in user's cart
+func (h *CartHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	// params
+	var params struct {
+		ProductID string `json:"product_id"`
+		Quantity  int32  `json:"quantity"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// add item to cart
+	cart, err := h.cartService.AddItem(r.Context(), params.ProductID, params.Quantity)
+	if err != nil {
+		respondWithCartError(w, err)
+		return
+	}
+
+	// respond with cart
+	RespondWithJSON(w, http.StatusOK, cart)
+}
+
+// UpdateItemQuantity changes the quantity of a product in the logged-in user's cart
+func (h *CartHandler) UpdateItemQuantity(w http.ResponseWriter, r *http.Request) {
+	// get product id
+	productId := mux.Vars(r)["productId"]
+
+	// params
+	var params struct {
+		Quantity int32 `json:"quantity"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// update item quantity
+	cart, err := h.cartService.UpdateItemQuantity(r.Context(), productId, params.Quantity)
+	if err != nil {
+		respondWithCartError(w, err)
+		return
+	}
+
+	// respond with cart
+	RespondWithJSON(w, http.StatusOK, cart)
+}
+
+// RemoveItem removes a product from the logged-in user's cart
+func (h *CartHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	// get product id
+	productId := mux.Vars(r)["productId"]
+
+	// remove item from cart
+	cart, err := h.cartService.RemoveItem(r.Context(), productId)
+	if err != nil {
+		respondWithCartError(w, err)
+		return
+	}
+
+	// respond with cart
+	RespondWithJSON(w, http.StatusOK, cart)
+}
+
+// ApplyPromotion applies a promotion code to the logged-in user's cart
+func (h *CartHandler) ApplyPromotion(w http.ResponseWriter, r *http.Request) {
+	// params
+	var params struct {
+		Code string `json:"code"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// apply promotion code
+	cart, err := h.cartService.ApplyPromotion(r.Context(), params.Code)
+	if err != nil {
+		respondWithCartError(w, err)
+		return
+	}
+
+	// respond with cart
+	RespondWithJSON(w, http.StatusOK, cart)
+}
+
+// RemovePromotion takes the promotion code off the logged-in user's cart
+func (h *CartHandler) RemovePromotion(w http.ResponseWriter, r *http.Request) {
+	// remove promotion code
+	cart, err := h.cartService.RemovePromotion(r.Context())
+	if err != nil {
+		respondWithCartError(w, err)
+		return
+	}
+
+	// respond with cart
+	RespondWithJSON(w, http.StatusOK, cart)
+}
+
+// respondWithCartError maps cart service errors to response codes
+func respondWithCartError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidQuantity), usecases.IsPromotionRejection(err):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrCartItemNotFound), errors.Is(err, usecases.ErrProductUnavailable),
+		errors.Is(err, usecases.ErrPromotionNotFound), errors.Is(err, usecases.ErrNoCartPromotion):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecases.ErrQuantityExceedsStock):
+		RespondWithError(w, http.StatusConflict, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to update cart: %v", err))
+	}
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/postal"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+)
+
+type CheckoutHandler struct {
+	checkoutService *usecases.CheckoutService
+}
+
+func NewCheckoutHandler(checkoutService *usecases.CheckoutService) *CheckoutHandler {
+	return &CheckoutHandler{
+		checkoutService: checkoutService,
+	}
+}
+
+// Checkout places an order for everything in the logged-in user's cart
+func (h *CheckoutHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	// params
+	var params struct {
+		PaymentMethod     string                   `json:"payment_method"`
+		ShippingAddressID *uuid.UUID               `json:"shipping_address_id"`
+		ShippingAddress   *model.SaveAddressParams `json:"shipping_address"`
+		BillingAddressID  *uuid.UUID               `json:"billing_address_id"`
+		BillingAddress    string                   `json:"billing_address"`
+		CardNumber        string                   `json:"card_number"`
+		ShippingMethod    string                   `json:"shipping_method"`
+		GiftCardCodes     []string                 `json:"gift_card_codes"`
+		UseStoreCredit    bool                     `json:"use_store_credit"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// place order
+	result, err := h.checkoutService.Checkout(
+		r.Context(),
+		params.PaymentMethod,
+		toNullUUID(params.ShippingAddressID),
+		params.ShippingAddress,
+		toNullUUID(params.BillingAddressID),
+		params.BillingAddress,
+		params.CardNumber,
+		params.ShippingMethod,
+		params.GiftCardCodes,
+		params.UseStoreCredit,
+	)
+	if err != nil {
+		var stockErr *repository.InsufficientStockError
+		var giftCardErr *repository.GiftCardUnavailableError
+		var addressErr *postal.ValidationError
+		switch {
+		case errors.As(err, &addressErr):
+			RespondWithValidationErrors(w, http.StatusBadRequest, "Invalid address", addressErr.Fields)
+		case errors.Is(err, usecases.ErrInvalidPaymentMethod), errors.Is(err, usecases.ErrInvalidAddress),
+			errors.Is(err, repository.ErrEmptyCart), errors.Is(err, usecases.ErrCardNumberRequired),
+			errors.Is(err, usecases.ErrInvalidCardNumber), errors.Is(err, usecases.ErrShippingMethodNotOffered),
+			errors.Is(err, usecases.ErrTooManyGiftCards), errors.As(err, &giftCardErr),
+			errors.Is(err, usecases.ErrAddressNotFound):
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, usecases.ErrNoShippingToDestination):
+			RespondWithError(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, usecases.ErrPaymentFailed):
+			// the order was placed, so hand it back for the payment to be retried
+			RespondWithJSON(w, http.StatusPaymentRequired, struct {
+				Error  string               `json:"error"`
+				Result model.CheckoutResult `json:"result"`
+			}{
+				Error:  err.Error(),
+				Result: result,
+			})
+		case errors.Is(err, repository.ErrCartChanged), errors.Is(err, repository.ErrPromotionUnavailable),
+			usecases.IsPromotionRejection(err):
+			RespondWithError(w, http.StatusConflict, err.Error())
+		case errors.As(err, &stockErr):
+			RespondWithJSON(w, http.StatusConflict, struct {
+				Error     string `json:"error"`
+				ProductID string `json:"product_id"`
+				Requested int32  `json:"requested"`
+				Available int32  `json:"available"`
+			}{
+				Error:     stockErr.Error(),
+				ProductID: stockErr.ProductID.String(),
+				Requested: stockErr.Requested,
+				Available: stockErr.Available,
+			})
+		default:
+			RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to place order: %v", err))
+		}
+		return
+	}
+
+	// respond with order
+	RespondWithJSON(w, http.StatusCreated, result)
+}
+
+func toNullUUID(value *uuid.UUID) uuid.NullUUID {
+	if value == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *value, Valid: true}
+}
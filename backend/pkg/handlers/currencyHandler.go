@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/gorilla/mux"
+)
+
+const maxExchangeRatesFileBytes = 1 << 20
+
+type CurrencyHandler struct {
+	currencyService *usecases.CurrencyService
+}
+
+func NewCurrencyHandler(currencyService *usecases.CurrencyService) *CurrencyHandler {
+	return &CurrencyHandler{
+		currencyService: currencyService,
+	}
+}
+
+// GetCurrencies gets the currencies customers can see prices in
+func (h *CurrencyHandler) GetCurrencies(w http.ResponseWriter, r *http.Request) {
+	// get active currencies
+	currencies, err := h.currencyService.GetCurrencies(r.Context(), true)
+	if err != nil {
+		respondWithCurrencyError(w, err, "Failed to get currencies")
+		return
+	}
+
+	// respond with currencies
+	RespondWithJSON(w, http.StatusOK, currencies)
+}
+
+// GetAllCurrencies gets every currency, active or not
+func (h *CurrencyHandler) GetAllCurrencies(w http.ResponseWriter, r *http.Request) {
+	// get currencies
+	currencies, err := h.currencyService.GetCurrencies(r.Context(), false)
+	if err != nil {
+		respondWithCurrencyError(w, err, "Failed to get currencies")
+		return
+	}
+
+	// respond with currencies
+	RespondWithJSON(w, http.StatusOK, currencies)
+}
+
+// SaveCurrency adds or updates a currency and how its converted prices are rounded
+func (h *CurrencyHandler) SaveCurrency(w http.ResponseWriter, r *http.Request) {
+	// get currency code
+	vars := mux.Vars(r)
+
+	// params
+	var params struct {
+		Name              string `json:"name"`
+		Symbol            string `json:"symbol"`
+		RoundingIncrement string `json:"rounding_increment"`
+		RoundingMode      string `json:"rounding_mode"`
+		IsActive          *bool  `json:"is_active"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// save currency, active unless told otherwise
+	currency, err := h.currencyService.SaveCurrency(r.Context(), model.SaveCurrencyParams{
+		Code:              vars["code"],
+		Name:              params.Name,
+		Symbol:            params.Symbol,
+		RoundingIncrement: params.RoundingIncrement,
+		RoundingMode:      params.RoundingMode,
+		IsActive:          params.IsActive == nil || *params.IsActive,
+	})
+	if err != nil {
+		respondWithCurrencyError(w, err, "Failed to save currency")
+		return
+	}
+
+	// respond with currency
+	RespondWithJSON(w, http.StatusOK, currency)
+}
+
+// SetExchangeRate sets a currency's exchange rate by hand
+func (h *CurrencyHandler) SetExchangeRate(w http.ResponseWriter, r *http.Request) {
+	// get currency code
+	vars := mux.Vars(r)
+
+	// params
+	var params struct {
+		Rate string `json:"rate"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// set exchange rate
+	rate, err := h.currencyService.SetExchangeRate(r.Context(), vars["code"], params.Rate)
+	if err != nil {
+		respondWithCurrencyError(w, err, "Failed to set exchange rate")
+		return
+	}
+
+	// respond with exchange rate
+	RespondWithJSON(w, http.StatusOK, rate)
+}
+
+// ImportExchangeRates loads exchange rates from a CSV file sent as the request body
+func (h *CurrencyHandler) ImportExchangeRates(w http.ResponseWriter, r *http.Request) {
+	// import exchange rates
+	rates, err := h.currencyService.ImportExchangeRates(r.Context(), http.MaxBytesReader(w, r.Body, maxExchangeRatesFileBytes))
+	if errors.Is(err, usecases.ErrCurrencyNotFound) {
+		// a file naming an unknown currency is a bad file rather than a missing resource
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithCurrencyError(w, err, "Failed to import exchange rates")
+		return
+	}
+
+	// respond with exchange rates
+	RespondWithJSON(w, http.StatusOK, rates)
+}
+
+func respondWithCurrencyError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, money.ErrInvalidCurrency), errors.Is(err, money.ErrInvalidRate),
+		errors.Is(err, money.ErrInvalidIncrement), errors.Is(err, money.ErrInvalidRoundingMode),
+		errors.Is(err, usecases.ErrInvalidCurrencyName), errors.Is(err, usecases.ErrBaseCurrency),
+		errors.Is(err, usecases.ErrInvalidRatesFile):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrCurrencyNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
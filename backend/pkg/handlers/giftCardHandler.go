@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type GiftCardHandler struct {
+	giftCardService *usecases.GiftCardService
+}
+
+func NewGiftCardHandler(giftCardService *usecases.GiftCardService) *GiftCardHandler {
+	return &GiftCardHandler{
+		giftCardService: giftCardService,
+	}
+}
+
+// IssueGiftCard creates a gift card with a generated code
+func (h *GiftCardHandler) IssueGiftCard(w http.ResponseWriter, r *http.Request) {
+	// params
+	var params struct {
+		Amount    string     `json:"amount"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// issue gift card
+	giftCard, err := h.giftCardService.IssueGiftCard(r.Context(), params.Amount, toNullTime(params.ExpiresAt))
+	if err != nil {
+		respondWithGiftCardError(w, err, "Failed to issue gift card")
+		return
+	}
+
+	// respond with gift card
+	RespondWithJSON(w, http.StatusCreated, giftCard)
+}
+
+// GetGiftCards gets every gift card, newest first
+func (h *GiftCardHandler) GetGiftCards(w http.ResponseWriter, r *http.Request) {
+	// get gift cards
+	giftCards, err := h.giftCardService.GetGiftCards(r.Context())
+	if err != nil {
+		respondWithGiftCardError(w, err, "Failed to get gift cards")
+		return
+	}
+
+	// respond with gift cards
+	RespondWithJSON(w, http.StatusOK, giftCards)
+}
+
+// GetGiftCard gets a gift card with its ledger
+func (h *GiftCardHandler) GetGiftCard(w http.ResponseWriter, r *http.Request) {
+	// get gift card id
+	vars := mux.Vars(r)
+	giftCardId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid gift card id")
+		return
+	}
+
+	// get gift card
+	giftCard, err := h.giftCardService.GetGiftCard(r.Context(), giftCardId)
+	if err != nil {
+		respondWithGiftCardError(w, err, "Failed to get gift card")
+		return
+	}
+
+	// respond with gift card
+	RespondWithJSON(w, http.StatusOK, giftCard)
+}
+
+// SetGiftCardActive switches a gift card on or off
+func (h *GiftCardHandler) SetGiftCardActive(w http.ResponseWriter, r *http.Request) {
+	// get gift card id
+	vars := mux.Vars(r)
+	giftCardId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid gift card id")
+		return
+	}
+
+	// params
+	var params struct {
+		IsActive bool `json:"is_active"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// set gift card status
+	giftCard, err := h.giftCardService.SetGiftCardActive(r.Context(), giftCardId, params.IsActive)
+	if err != nil {
+		respondWithGiftCardError(w, err, "Failed to update gift card")
+		return
+	}
+
+	// respond with gift card
+	RespondWithJSON(w, http.StatusOK, giftCard)
+}
+
+// CheckGiftCardBalance shows what is left on a gift card to anyone holding its code
+func (h *GiftCardHandler) CheckGiftCardBalance(w http.ResponseWriter, r *http.Request) {
+	// get gift card code
+	vars := mux.Vars(r)
+
+	// get balance
+	balance, err := h.giftCardService.CheckGiftCardBalance(r.Context(), vars["code"])
+	if err != nil {
+		respondWithGiftCardError(w, err, "Failed to get gift card balance")
+		return
+	}
+
+	// respond with balance
+	RespondWithJSON(w, http.StatusOK, balance)
+}
+
+func respondWithGiftCardError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidGiftCardAmount), errors.Is(err, usecases.ErrInvalidGiftCardExpiry):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrGiftCardNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
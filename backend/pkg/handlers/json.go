@@ -18,6 +18,17 @@ func RespondWithError(w http.ResponseWriter, code int, message string) {
 	RespondWithJSON(w, code, errorResponse{Error: message})
 }
 
+// RespondWithValidationErrors responds with what is wrong with each field of a request
+// alongside the usual error message
+func RespondWithValidationErrors(w http.ResponseWriter, code int, message string, fields map[string]string) {
+	type validationErrorResponse struct {
+		Error  string            `json:"error"`
+		Fields map[string]string `json:"fields"`
+	}
+
+	RespondWithJSON(w, code, validationErrorResponse{Error: message, Fields: fields})
+}
+
 func RespondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	response, err := json.Marshal(payload)
 	if err != nil {
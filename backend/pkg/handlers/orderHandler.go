@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type OrderHandler struct {
+	orderService *usecases.OrderService
+}
+
+func NewOrderHandler(orderService *usecases.OrderService) *OrderHandler {
+	return &OrderHandler{
+		orderService: orderService,
+	}
+}
+
+// TransitionOrder moves an order to a new status
+func (h *OrderHandler) TransitionOrder(w http.ResponseWriter, r *http.Request) {
+	// get order id
+	vars := mux.Vars(r)
+	orderId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	// params
+	var params struct {
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// move order
+	order, err := h.orderService.TransitionOrder(r.Context(), orderId, params.Status, params.Reason)
+	if err != nil {
+		respondWithOrderError(w, err, "Failed to update order status")
+		return
+	}
+
+	// respond with order
+	RespondWithJSON(w, http.StatusOK, order)
+}
+
+// CancelOrder cancels one of the logged-in user's orders
+func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	h.cancelOrder(w, r, h.orderService.CancelOrder)
+}
+
+// AdminCancelOrder cancels any order that has not been delivered yet
+func (h *OrderHandler) AdminCancelOrder(w http.ResponseWriter, r *http.Request) {
+	h.cancelOrder(w, r, h.orderService.AdminCancelOrder)
+}
+
+func (h *OrderHandler) cancelOrder(
+	w http.ResponseWriter,
+	r *http.Request,
+	cancel func(ctx context.Context, orderId uuid.UUID, reason string) (model.Order, error),
+) {
+	// get order id
+	vars := mux.Vars(r)
+	orderId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	// params, the reason is optional so an empty body is fine
+	var params struct {
+		Reason string `json:"reason"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil && !errors.Is(err, io.EOF) {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// cancel order
+	order, err := cancel(r.Context(), orderId, params.Reason)
+	if err != nil {
+		respondWithOrderError(w, err, "Failed to cancel order")
+		return
+	}
+
+	// respond with order
+	RespondWithJSON(w, http.StatusOK, order)
+}
+
+// GetOrderTimeline gets the status history of one of the logged-in user's orders
+func (h *OrderHandler) GetOrderTimeline(w http.ResponseWriter, r *http.Request) {
+	// get order id
+	vars := mux.Vars(r)
+	orderId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	// get timeline
+	timeline, err := h.orderService.GetOrderTimeline(r.Context(), orderId)
+	if err != nil {
+		respondWithOrderError(w, err, "Failed to get order timeline")
+		return
+	}
+
+	// respond with timeline
+	RespondWithJSON(w, http.StatusOK, timeline)
+}
+
+// GetOrders gets the logged-in user's orders, optionally filtered by status and date range
+func (h *OrderHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
+	// get page and page size
+	pageStr := r.URL.Query().Get("page")
+	pageSizeStr := r.URL.Query().Get("page_size")
+	status := r.URL.Query().Get("status")
+
+	var page int32 = 0
+	var pageSize int32 = 0
+
+	if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+		page = int32(p)
+	}
+
+	if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 {
+		pageSize = int32(ps)
+	}
+
+	// get date range
+	createdFrom, err := parseDateParam(r.URL.Query().Get("from"), false)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid from date, expected YYYY-MM-DD or RFC 3339")
+		return
+	}
+	createdTo, err := parseDateParam(r.URL.Query().Get("to"), true)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid to date, expected YYYY-MM-DD or RFC 3339")
+		return
+	}
+
+	// get orders
+	orders, err := h.orderService.GetOrders(r.Context(), status, createdFrom, createdTo, pageSize, page)
+	if err != nil {
+		respondWithOrderError(w, err, "Failed to get orders")
+		return
+	}
+
+	// respond with orders
+	RespondWithJSON(w, http.StatusOK, orders)
+}
+
+// GetOrder gets one of the logged-in user's orders with its items and status history
+func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	// get order id
+	vars := mux.Vars(r)
+	orderId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	// get order
+	order, err := h.orderService.GetOrder(r.Context(), orderId)
+	if err != nil {
+		respondWithOrderError(w, err, "Failed to get order")
+		return
+	}
+
+	// respond with order
+	RespondWithJSON(w, http.StatusOK, order)
+}
+
+// parseDateParam parses a date or timestamp query parameter. A bare "to" date covers the whole day.
+func parseDateParam(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// respondWithOrderError maps order service errors to status codes
+func respondWithOrderError(w http.ResponseWriter, err error, message string) {
+	var transitionErr *repository.InvalidTransitionError
+	switch {
+	case errors.Is(err, usecases.ErrUnknownOrderStatus), errors.Is(err, usecases.ErrReasonTooLong),
+		errors.Is(err, usecases.ErrInvalidDateRange):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrOrderNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	case errors.As(err, &transitionErr):
+		RespondWithError(w, http.StatusConflict, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
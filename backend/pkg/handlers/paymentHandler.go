@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/payment"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type PaymentHandler struct {
+	paymentService *usecases.PaymentService
+}
+
+func NewPaymentHandler(paymentService *usecases.PaymentService) *PaymentHandler {
+	return &PaymentHandler{
+		paymentService: paymentService,
+	}
+}
+
+// PayOrder takes payment for one of the logged-in user's orders, typically after a failed attempt
+func (h *PaymentHandler) PayOrder(w http.ResponseWriter, r *http.Request) {
+	// get order id
+	vars := mux.Vars(r)
+	orderId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	// params
+	var params struct {
+		CardNumber string `json:"card_number"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// pay order
+	paymentRecord, err := h.paymentService.PayOrder(r.Context(), orderId, params.CardNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrPaymentFailed):
+			RespondWithJSON(w, http.StatusPaymentRequired, struct {
+				Error   string        `json:"error"`
+				Payment model.Payment `json:"payment"`
+			}{
+				Error:   err.Error(),
+				Payment: paymentRecord,
+			})
+		case errors.Is(err, usecases.ErrOrderNotFound):
+			RespondWithError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, repository.ErrOrderNotPayable), errors.Is(err, repository.ErrOrderAlreadyPaid),
+			errors.Is(err, repository.ErrPaymentInProgress), errors.Is(err, repository.ErrPaymentExpired):
+			RespondWithError(w, http.StatusConflict, err.Error())
+		default:
+			RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to pay order: %v", err))
+		}
+		return
+	}
+
+	// respond with payment
+	RespondWithJSON(w, http.StatusOK, paymentRecord)
+}
+
+// HandlePaymentWebhook receives signed payment events from a provider
+func (h *PaymentHandler) HandlePaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	// get provider
+	vars := mux.Vars(r)
+	provider := vars["provider"]
+
+	// the signature covers the raw body, so read it as is
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to read request body: %v", err))
+		return
+	}
+
+	// apply event
+	outcome, err := h.paymentService.HandleWebhook(r.Context(), provider, body, r.Header.Get(payment.SignatureHeader))
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		switch {
+		case errors.Is(err, usecases.ErrInvalidSignature):
+			RespondWithError(w, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, payment.ErrInvalidWebhookEvent), errors.As(err, &syntaxErr):
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, usecases.ErrPaymentNotFound):
+			RespondWithError(w, http.StatusNotFound, err.Error())
+		default:
+			RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to handle payment event: %v", err))
+		}
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Payment event "+outcome)
+}
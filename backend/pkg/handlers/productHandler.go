@@ -3,10 +3,16 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
 	"github.com/geraldbahati/ecommerce/pkg/usecases"
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type ProductHandler struct {
@@ -40,21 +46,26 @@ func (h *ProductHandler) GetProducts(w http.ResponseWriter, r *http.Request) {
 func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	// Parameters
 	var params struct {
-		Name          string   `json:"name"`
-		Description   string   `json:"description"`
-		ImageUrl      string   `json:"image_url"`
-		Price         string   `json:"price"`
-		Stock         int32    `json:"stock"`
-		SubCategoryID string   `json:"sub_category_id"`
-		Brand         string   `json:"brand"`
-		Keywords      string   `json:"keywords"`
-		Colours       []string `json:"colours"`
-		Materials     []string `json:"materials"`
+		Name          string       `json:"name"`
+		Description   string       `json:"description"`
+		ImageUrl      string       `json:"image_url"`
+		Price         *money.Money `json:"price"`
+		Stock         int32        `json:"stock"`
+		SubCategoryID string       `json:"sub_category_id"`
+		Brand         string       `json:"brand"`
+		Keywords      string       `json:"keywords"`
+		Colours       []string     `json:"colours"`
+		Materials     []string     `json:"materials"`
 	}
 
 	// Decoding request body
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+	if params.Price == nil {
+		RespondWithError(w, http.StatusBadRequest, "Price is required")
+		return
 	}
 
 	// Add product
@@ -63,7 +74,7 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		params.Name,
 		params.Description,
 		params.ImageUrl,
-		params.Price,
+		*params.Price,
 		params.Stock,
 		params.SubCategoryID,
 		params.Brand,
@@ -71,6 +82,10 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		params.Colours,
 		params.Materials,
 	)
+	if errors.Is(err, usecases.ErrInvalidPriceCurrency) {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to add new product: %v", err))
 		return
@@ -83,21 +98,19 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	// Parameters
 	var params struct {
-		ID            string   `json:"id"`
-		Name          string   `json:"name"`
-		Description   string   `json:"description"`
-		ImageUrl      string   `json:"image_url"`
-		Price         string   `json:"price"`
-		Stock         int32    `json:"stock"`
-		SubCategoryId string   `json:"category_id"`
-		Brand         string   `json:"brand"`
-		Rating        string   `json:"rating"`
-		ReviewCount   int32    `json:"review_count"`
-		DiscountRate  string   `json:"discount_rate"`
-		Keywords      string   `json:"keywords"`
-		IsActive      bool     `json:"is_active"`
-		Colours       []string `json:"colours"`
-		Materials     []string `json:"materials"`
+		ID            string       `json:"id"`
+		Name          string       `json:"name"`
+		Description   string       `json:"description"`
+		ImageUrl      string       `json:"image_url"`
+		Price         *money.Money `json:"price"`
+		Stock         int32        `json:"stock"`
+		SubCategoryId string       `json:"category_id"`
+		Brand         string       `json:"brand"`
+		DiscountRate  string       `json:"discount_rate"`
+		Keywords      string       `json:"keywords"`
+		IsActive      bool         `json:"is_active"`
+		Colours       []string     `json:"colours"`
+		Materials     []string     `json:"materials"`
 	}
 
 	// Decoding request body
@@ -117,14 +130,16 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		params.Stock,
 		params.SubCategoryId,
 		params.Brand,
-		params.Rating,
-		params.ReviewCount,
 		params.DiscountRate,
 		params.Keywords,
 		params.IsActive,
 		params.Colours,
 		params.Materials,
 	)
+	if errors.Is(err, usecases.ErrInvalidDiscountRate) || errors.Is(err, usecases.ErrInvalidPriceCurrency) {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to update user: %v", err))
 		return
@@ -174,7 +189,7 @@ func (h *ProductHandler) GetProductById(w http.ResponseWriter, r *http.Request)
 
 	// Decoding request body
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body", err))
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
 		return
 	}
 
@@ -185,10 +200,32 @@ func (h *ProductHandler) GetProductById(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Record the view for the logged-in user
+	h.productService.RecordProductView(r.Context(), product.ID)
+
 	// Respond with particular product
 	RespondWithJSON(w, http.StatusOK, product)
 }
 
+// GetRecentlyViewedProducts gets the products the logged-in user viewed most recently
+func (h *ProductHandler) GetRecentlyViewedProducts(w http.ResponseWriter, r *http.Request) {
+	// get limit
+	var limit int32 = 0
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = int32(l)
+	}
+
+	// get recently viewed products
+	products, err := h.productService.GetRecentlyViewedProducts(r.Context(), limit)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get recently viewed products: %v", err))
+		return
+	}
+
+	// respond with products
+	RespondWithJSON(w, http.StatusOK, products)
+}
+
 func (h *ProductHandler) GetProductsByCategory(w http.ResponseWriter, r *http.Request) {
 	// Parameters
 	categoryIdStr := r.URL.Query().Get("category_id")
@@ -296,3 +333,53 @@ func (h *ProductHandler) GetAllMaterials(w http.ResponseWriter, r *http.Request)
 	// Respond with all materials
 	RespondWithJSON(w, http.StatusOK, materials)
 }
+
+// UpdateProductSale sets a product's discount rate and when the sale runs
+func (h *ProductHandler) UpdateProductSale(w http.ResponseWriter, r *http.Request) {
+	// get product id
+	vars := mux.Vars(r)
+	productId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid product id")
+		return
+	}
+
+	// params
+	var params struct {
+		DiscountRate string     `json:"discount_rate"`
+		SaleStartsAt *time.Time `json:"sale_starts_at"`
+		SaleEndsAt   *time.Time `json:"sale_ends_at"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// update product sale
+	err = h.productService.UpdateProductSale(r.Context(), productId, model.UpdateProductSaleParams{
+		DiscountRate: params.DiscountRate,
+		SaleStartsAt: toNullTime(params.SaleStartsAt),
+		SaleEndsAt:   toNullTime(params.SaleEndsAt),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrInvalidDiscountRate), errors.Is(err, usecases.ErrInvalidSaleWindow):
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, usecases.ErrProductNotFound):
+			RespondWithError(w, http.StatusNotFound, err.Error())
+		default:
+			RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to update product sale: %v", err))
+		}
+		return
+	}
+
+	// respond with updated product
+	product, err := h.productService.GetProductById(r.Context(), productId)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Error fetching product with id %v: %v", productId.String(), err))
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, product)
+}
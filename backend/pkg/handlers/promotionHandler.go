@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type PromotionHandler struct {
+	promotionService *usecases.PromotionService
+}
+
+func NewPromotionHandler(promotionService *usecases.PromotionService) *PromotionHandler {
+	return &PromotionHandler{
+		promotionService: promotionService,
+	}
+}
+
+// GetPromotions gets every promotion, newest first
+func (h *PromotionHandler) GetPromotions(w http.ResponseWriter, r *http.Request) {
+	// get promotions
+	promotions, err := h.promotionService.GetPromotions(r.Context())
+	if err != nil {
+		respondWithPromotionError(w, err, "Failed to get promotions")
+		return
+	}
+
+	// respond with promotions
+	RespondWithJSON(w, http.StatusOK, promotions)
+}
+
+// GetPromotion gets a promotion with the products and categories it is limited to
+func (h *PromotionHandler) GetPromotion(w http.ResponseWriter, r *http.Request) {
+	// get promotion id
+	vars := mux.Vars(r)
+	promotionId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid promotion id")
+		return
+	}
+
+	// get promotion
+	promotion, err := h.promotionService.GetPromotion(r.Context(), promotionId)
+	if err != nil {
+		respondWithPromotionError(w, err, "Failed to get promotion")
+		return
+	}
+
+	// respond with promotion
+	RespondWithJSON(w, http.StatusOK, promotion)
+}
+
+// CreatePromotion creates a promotion code
+func (h *PromotionHandler) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	// params
+	var params struct {
+		Code           string                  `json:"code"`
+		Description    *string                 `json:"description"`
+		DiscountType   string                  `json:"discount_type"`
+		DiscountValue  string                  `json:"discount_value"`
+		MinCartValue   *string                 `json:"min_cart_value"`
+		StartsAt       *time.Time              `json:"starts_at"`
+		EndsAt         *time.Time              `json:"ends_at"`
+		MaxUses        *int32                  `json:"max_uses"`
+		MaxUsesPerUser *int32                  `json:"max_uses_per_user"`
+		Targets        []model.PromotionTarget `json:"targets"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// create promotion
+	promotion, err := h.promotionService.CreatePromotion(r.Context(), model.CreatePromotionParams{
+		Code:           params.Code,
+		Description:    toNullString(params.Description),
+		DiscountType:   params.DiscountType,
+		DiscountValue:  params.DiscountValue,
+		MinCartValue:   toNullString(params.MinCartValue),
+		StartsAt:       toNullTime(params.StartsAt),
+		EndsAt:         toNullTime(params.EndsAt),
+		MaxUses:        toNullInt32(params.MaxUses),
+		MaxUsesPerUser: toNullInt32(params.MaxUsesPerUser),
+		Targets:        params.Targets,
+	})
+	if err != nil {
+		respondWithPromotionError(w, err, "Failed to create promotion")
+		return
+	}
+
+	// respond with promotion
+	RespondWithJSON(w, http.StatusCreated, promotion)
+}
+
+// SetPromotionActive switches a promotion on or off
+func (h *PromotionHandler) SetPromotionActive(w http.ResponseWriter, r *http.Request) {
+	// get promotion id
+	vars := mux.Vars(r)
+	promotionId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid promotion id")
+		return
+	}
+
+	// params
+	var params struct {
+		IsActive bool `json:"is_active"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// set promotion status
+	if err := h.promotionService.SetPromotionActive(r.Context(), promotionId, params.IsActive); err != nil {
+		respondWithPromotionError(w, err, "Failed to update promotion")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Promotion updated")
+}
+
+// GetPromotionReport sums up the orders placed with a promotion
+func (h *PromotionHandler) GetPromotionReport(w http.ResponseWriter, r *http.Request) {
+	// get promotion id
+	vars := mux.Vars(r)
+	promotionId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid promotion id")
+		return
+	}
+
+	// get report
+	report, err := h.promotionService.GetPromotionReport(r.Context(), promotionId)
+	if err != nil {
+		respondWithPromotionError(w, err, "Failed to get promotion report")
+		return
+	}
+
+	// respond with report
+	RespondWithJSON(w, http.StatusOK, report)
+}
+
+func respondWithPromotionError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidPromotionCode), errors.Is(err, usecases.ErrInvalidDiscountType),
+		errors.Is(err, usecases.ErrInvalidDiscountValue), errors.Is(err, usecases.ErrInvalidMinCartValue),
+		errors.Is(err, usecases.ErrInvalidPromotionWindow), errors.Is(err, usecases.ErrInvalidUsageLimit),
+		errors.Is(err, usecases.ErrInvalidPromotionTarget):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrPromotionNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecases.ErrPromotionCodeExists):
+		RespondWithError(w, http.StatusConflict, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
+
+func toNullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *value, Valid: true}
+}
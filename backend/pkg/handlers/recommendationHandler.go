@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type RecommendationHandler struct {
+	recommendationService *usecases.RecommendationService
+}
+
+func NewRecommendationHandler(recommendationService *usecases.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendationService: recommendationService,
+	}
+}
+
+// GetProductRecommendations gets the products customers also bought with a product
+func (h *RecommendationHandler) GetProductRecommendations(w http.ResponseWriter, r *http.Request) {
+	// get product id
+	productId, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid product id")
+		return
+	}
+
+	// get recommendations
+	recommendations, err := h.recommendationService.GetProductRecommendations(r.Context(), productId, recommendationLimit(r))
+	if err != nil {
+		respondWithRecommendationError(w, err, "Failed to get recommendations")
+		return
+	}
+
+	// respond with recommendations
+	RespondWithJSON(w, http.StatusOK, recommendations)
+}
+
+// GetAlsoSavedProducts gets the products people who saved a product to their wishlist also saved
+func (h *RecommendationHandler) GetAlsoSavedProducts(w http.ResponseWriter, r *http.Request) {
+	// get product id
+	productId, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid product id")
+		return
+	}
+
+	// get products
+	recommendations, err := h.recommendationService.GetAlsoSavedProducts(r.Context(), productId, recommendationLimit(r))
+	if err != nil {
+		respondWithRecommendationError(w, err, "Failed to get recommendations")
+		return
+	}
+
+	// respond with products
+	RespondWithJSON(w, http.StatusOK, recommendations)
+}
+
+// GetUserRecommendations gets recommendations for the logged-in user from their wishlists and
+// recently viewed products
+func (h *RecommendationHandler) GetUserRecommendations(w http.ResponseWriter, r *http.Request) {
+	// get recommendations
+	recommendations, err := h.recommendationService.GetUserRecommendations(r.Context(), recommendationLimit(r))
+	if err != nil {
+		respondWithRecommendationError(w, err, "Failed to get recommendations")
+		return
+	}
+
+	// respond with recommendations
+	RespondWithJSON(w, http.StatusOK, recommendations)
+}
+
+// RefreshRecommendations recomputes recommendations straight away rather than waiting for the next
+// scheduled refresh
+func (h *RecommendationHandler) RefreshRecommendations(w http.ResponseWriter, r *http.Request) {
+	// refresh recommendations
+	if err := h.recommendationService.RefreshRecommendations(r.Context()); err != nil {
+		respondWithRecommendationError(w, err, "Failed to refresh recommendations")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Recommendations refreshed")
+}
+
+func respondWithRecommendationError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecases.ErrProductNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
+
+// recommendationLimit gets the number of recommendations asked for, or 0 for the default
+func recommendationLimit(r *http.Request) int32 {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return int32(limit)
+}
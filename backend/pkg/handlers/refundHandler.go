@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type RefundHandler struct {
+	refundService *usecases.RefundService
+}
+
+func NewRefundHandler(refundService *usecases.RefundService) *RefundHandler {
+	return &RefundHandler{
+		refundService: refundService,
+	}
+}
+
+// IssueRefund refunds some or all of an order's payment, to the card or to store credit. An empty
+// body refunds whatever is left to the card.
+func (h *RefundHandler) IssueRefund(w http.ResponseWriter, r *http.Request) {
+	// get order id
+	vars := mux.Vars(r)
+	orderId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	// params
+	var params struct {
+		Amount string                   `json:"amount"`
+		Items  []model.RefundItemParams `json:"items"`
+		Reason string                   `json:"reason"`
+		Method string                   `json:"method"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil && !errors.Is(err, io.EOF) {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// issue refund
+	refund, err := h.refundService.IssueRefund(r.Context(), orderId, params.Amount, params.Items, params.Reason, params.Method)
+	if err != nil {
+		respondWithRefundError(w, err, refund, "Failed to issue refund")
+		return
+	}
+
+	// respond with refund
+	RespondWithJSON(w, http.StatusCreated, refund)
+}
+
+// ProcessRefund sends a pending refund, such as one recorded for a received return, to the payment provider
+func (h *RefundHandler) ProcessRefund(w http.ResponseWriter, r *http.Request) {
+	// get refund id
+	vars := mux.Vars(r)
+	refundId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid refund id")
+		return
+	}
+
+	// process refund
+	refund, err := h.refundService.ProcessRefund(r.Context(), refundId)
+	if err != nil {
+		respondWithRefundError(w, err, refund, "Failed to process refund")
+		return
+	}
+
+	// respond with refund
+	RespondWithJSON(w, http.StatusOK, refund)
+}
+
+// GetOrderRefunds gets the refund ledger of an order
+func (h *RefundHandler) GetOrderRefunds(w http.ResponseWriter, r *http.Request) {
+	// get order id
+	vars := mux.Vars(r)
+	orderId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	// get refunds
+	refunds, err := h.refundService.GetOrderRefunds(r.Context(), orderId)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get refunds: %v", err))
+		return
+	}
+
+	// respond with refunds
+	RespondWithJSON(w, http.StatusOK, refunds)
+}
+
+// respondWithRefundError maps refund errors to responses. A declined refund is returned along
+// with the error so the failed ledger entry can be shown.
+func respondWithRefundError(w http.ResponseWriter, err error, refund interface{}, message string) {
+	var transitionErr *repository.InvalidTransitionError
+	var exceedsErr *repository.RefundExceedsError
+	var itemErr *repository.InvalidRefundItemError
+	switch {
+	case errors.Is(err, usecases.ErrRefundFailed):
+		RespondWithJSON(w, http.StatusBadGateway, struct {
+			Error  string      `json:"error"`
+			Refund interface{} `json:"refund"`
+		}{
+			Error:  err.Error(),
+			Refund: refund,
+		})
+	case errors.Is(err, usecases.ErrInvalidAmount), errors.Is(err, usecases.ErrInvalidRefundItems),
+		errors.Is(err, usecases.ErrReasonTooLong), errors.Is(err, usecases.ErrInvalidRefundMethod):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrOrderNotFound), errors.Is(err, usecases.ErrRefundNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, repository.ErrOrderNotRefundable), errors.Is(err, repository.ErrNoCapturedPayment),
+		errors.As(err, &transitionErr):
+		RespondWithError(w, http.StatusConflict, err.Error())
+	case errors.As(err, &exceedsErr):
+		RespondWithJSON(w, http.StatusConflict, struct {
+			Error     string `json:"error"`
+			Requested string `json:"requested"`
+			Available string `json:"available"`
+		}{
+			Error:     exceedsErr.Error(),
+			Requested: exceedsErr.Requested,
+			Available: exceedsErr.Available,
+		})
+	case errors.As(err, &itemErr):
+		RespondWithJSON(w, http.StatusConflict, struct {
+			Error       string `json:"error"`
+			OrderItemID string `json:"order_item_id"`
+			Requested   int32  `json:"requested"`
+			Refundable  int32  `json:"refundable"`
+		}{
+			Error:       itemErr.Error(),
+			OrderItemID: itemErr.OrderItemID.String(),
+			Requested:   itemErr.Requested,
+			Refundable:  itemErr.Refundable,
+		})
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ReturnHandler struct {
+	returnService *usecases.ReturnService
+}
+
+func NewReturnHandler(returnService *usecases.ReturnService) *ReturnHandler {
+	return &ReturnHandler{
+		returnService: returnService,
+	}
+}
+
+// RequestReturn opens a return for items of one of the logged-in user's delivered orders
+func (h *ReturnHandler) RequestReturn(w http.ResponseWriter, r *http.Request) {
+	// get order id
+	vars := mux.Vars(r)
+	orderId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	// params
+	var params struct {
+		Reason string                   `json:"reason"`
+		Items  []model.ReturnItemParams `json:"items"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// create return request
+	returnRequest, err := h.returnService.RequestReturn(r.Context(), orderId, params.Reason, params.Items)
+	if err != nil {
+		respondWithReturnError(w, err, "Failed to request return")
+		return
+	}
+
+	// respond with return request
+	RespondWithJSON(w, http.StatusCreated, returnRequest)
+}
+
+// GetOrderReturns gets the return requests of one of the logged-in user's orders
+func (h *ReturnHandler) GetOrderReturns(w http.ResponseWriter, r *http.Request) {
+	// get order id
+	vars := mux.Vars(r)
+	orderId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	// get return requests
+	returnRequests, err := h.returnService.GetOrderReturns(r.Context(), orderId)
+	if err != nil {
+		respondWithReturnError(w, err, "Failed to get returns")
+		return
+	}
+
+	// respond with return requests
+	RespondWithJSON(w, http.StatusOK, returnRequests)
+}
+
+// GetReturnRequests gets return requests by status, defaulting to the ones waiting for review
+func (h *ReturnHandler) GetReturnRequests(w http.ResponseWriter, r *http.Request) {
+	// get page and page size
+	pageStr := r.URL.Query().Get("page")
+	pageSizeStr := r.URL.Query().Get("page_size")
+	status := r.URL.Query().Get("status")
+
+	var page int32 = 0
+	var pageSize int32 = 0
+
+	if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+		page = int32(p)
+	}
+
+	if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 {
+		pageSize = int32(ps)
+	}
+
+	// get return requests
+	returnRequests, err := h.returnService.GetReturnRequests(r.Context(), status, pageSize, page)
+	if err != nil {
+		respondWithReturnError(w, err, "Failed to get returns")
+		return
+	}
+
+	// respond with return requests
+	RespondWithJSON(w, http.StatusOK, returnRequests)
+}
+
+// GetReturnRequest gets a return request with its items and refund
+func (h *ReturnHandler) GetReturnRequest(w http.ResponseWriter, r *http.Request) {
+	// get return id
+	vars := mux.Vars(r)
+	returnId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid return id")
+		return
+	}
+
+	// get return request
+	returnRequest, err := h.returnService.GetReturnRequest(r.Context(), returnId)
+	if err != nil {
+		respondWithReturnError(w, err, "Failed to get return")
+		return
+	}
+
+	// respond with return request
+	RespondWithJSON(w, http.StatusOK, returnRequest)
+}
+
+// ApproveReturn accepts a requested return
+func (h *ReturnHandler) ApproveReturn(w http.ResponseWriter, r *http.Request) {
+	returnId, note, ok := decodeReturnDecision(w, r)
+	if !ok {
+		return
+	}
+
+	// approve return
+	returnRequest, err := h.returnService.ApproveReturn(r.Context(), returnId, note)
+	if err != nil {
+		respondWithReturnError(w, err, "Failed to approve return")
+		return
+	}
+
+	// respond with return request
+	RespondWithJSON(w, http.StatusOK, returnRequest)
+}
+
+// RejectReturn turns down a requested return
+func (h *ReturnHandler) RejectReturn(w http.ResponseWriter, r *http.Request) {
+	returnId, note, ok := decodeReturnDecision(w, r)
+	if !ok {
+		return
+	}
+
+	// reject return
+	returnRequest, err := h.returnService.RejectReturn(r.Context(), returnId, note)
+	if err != nil {
+		respondWithReturnError(w, err, "Failed to reject return")
+		return
+	}
+
+	// respond with return request
+	RespondWithJSON(w, http.StatusOK, returnRequest)
+}
+
+// ReceiveReturn records that the goods of an approved return arrived
+func (h *ReturnHandler) ReceiveReturn(w http.ResponseWriter, r *http.Request) {
+	returnId, note, ok := decodeReturnDecision(w, r)
+	if !ok {
+		return
+	}
+
+	// receive return
+	returnRequest, err := h.returnService.ReceiveReturn(r.Context(), returnId, note)
+	if err != nil {
+		respondWithReturnError(w, err, "Failed to receive return")
+		return
+	}
+
+	// respond with return request
+	RespondWithJSON(w, http.StatusOK, returnRequest)
+}
+
+// decodeReturnDecision reads the return id and the optional admin note, responding itself on failure
+func decodeReturnDecision(w http.ResponseWriter, r *http.Request) (uuid.UUID, string, bool) {
+	// get return id
+	vars := mux.Vars(r)
+	returnId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid return id")
+		return uuid.Nil, "", false
+	}
+
+	// params, the note is optional so an empty body is fine
+	var params struct {
+		Note string `json:"note"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil && !errors.Is(err, io.EOF) {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return uuid.Nil, "", false
+	}
+
+	return returnId, params.Note, true
+}
+
+// respondWithReturnError maps return service errors to status codes
+func respondWithReturnError(w http.ResponseWriter, err error, message string) {
+	var transitionErr *repository.InvalidTransitionError
+	var itemErr *repository.InvalidReturnItemError
+	switch {
+	case errors.Is(err, usecases.ErrInvalidReturnReason), errors.Is(err, usecases.ErrInvalidReturnItems),
+		errors.Is(err, usecases.ErrUnknownReturnStatus), errors.Is(err, usecases.ErrReasonTooLong):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrOrderNotFound), errors.Is(err, usecases.ErrReturnNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, repository.ErrOrderNotDelivered), errors.Is(err, repository.ErrReturnWindowClosed),
+		errors.As(err, &transitionErr):
+		RespondWithError(w, http.StatusConflict, err.Error())
+	case errors.As(err, &itemErr):
+		RespondWithJSON(w, http.StatusConflict, struct {
+			Error       string `json:"error"`
+			OrderItemID string `json:"order_item_id"`
+			Requested   int32  `json:"requested"`
+			Returnable  int32  `json:"returnable"`
+		}{
+			Error:       itemErr.Error(),
+			OrderItemID: itemErr.OrderItemID.String(),
+			Requested:   itemErr.Requested,
+			Returnable:  itemErr.Returnable,
+		})
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
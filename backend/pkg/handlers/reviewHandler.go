@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ReviewHandler struct {
+	reviewService *usecases.ReviewService
+}
+
+func NewReviewHandler(reviewService *usecases.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// GetProductReviews gets a product's reviews, sorted by newest, helpfulness, or highest or lowest
+// rating
+func (h *ReviewHandler) GetProductReviews(w http.ResponseWriter, r *http.Request) {
+	// get product id
+	productId, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid product id")
+		return
+	}
+
+	// get sort, page and page size
+	sort := r.URL.Query().Get("sort")
+	page, pageSize, _ := GetPageAndPageSize(r.URL.Query().Get("page"), r.URL.Query().Get("page_size"))
+
+	// get reviews
+	reviews, err := h.reviewService.GetProductReviews(r.Context(), productId, sort, pageSize, page)
+	if err != nil {
+		respondWithReviewError(w, err, "Failed to get reviews")
+		return
+	}
+
+	// respond with reviews
+	RespondWithJSON(w, http.StatusOK, reviews)
+}
+
+// CreateReview adds the logged-in user's review of a product
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	// get product id
+	productId, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid product id")
+		return
+	}
+
+	// params
+	var params struct {
+		Rating  int32  `json:"rating"`
+		Comment string `json:"comment"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// create review
+	review, err := h.reviewService.CreateReview(r.Context(), model.SaveReviewParams{
+		ProductID: productId,
+		Rating:    params.Rating,
+		Comment:   params.Comment,
+	})
+	if err != nil {
+		respondWithReviewError(w, err, "Failed to create review")
+		return
+	}
+
+	// respond with review
+	RespondWithJSON(w, http.StatusCreated, review)
+}
+
+// UpdateReview changes the rating and comment of one of the logged-in user's reviews
+func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	// get review id
+	reviewId, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid review id")
+		return
+	}
+
+	// params
+	var params struct {
+		Rating  int32  `json:"rating"`
+		Comment string `json:"comment"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// update review
+	review, err := h.reviewService.UpdateReview(r.Context(), reviewId, model.SaveReviewParams{
+		Rating:  params.Rating,
+		Comment: params.Comment,
+	})
+	if err != nil {
+		respondWithReviewError(w, err, "Failed to update review")
+		return
+	}
+
+	// respond with review
+	RespondWithJSON(w, http.StatusOK, review)
+}
+
+// DeleteReview deletes one of the logged-in user's reviews
+func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	// get review id
+	reviewId, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid review id")
+		return
+	}
+
+	// delete review
+	if err := h.reviewService.DeleteReview(r.Context(), reviewId); err != nil {
+		respondWithReviewError(w, err, "Failed to delete review")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Review deleted")
+}
+
+// VoteReview records whether the logged-in user found a review helpful
+func (h *ReviewHandler) VoteReview(w http.ResponseWriter, r *http.Request) {
+	// get review id
+	reviewId, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid review id")
+		return
+	}
+
+	// params
+	var params struct {
+		Helpful *bool `json:"helpful"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+	if params.Helpful == nil {
+		RespondWithError(w, http.StatusBadRequest, "helpful is required")
+		return
+	}
+
+	// vote on review
+	if err := h.reviewService.VoteReview(r.Context(), reviewId, *params.Helpful); err != nil {
+		respondWithReviewError(w, err, "Failed to vote on review")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Vote recorded")
+}
+
+// DeleteReviewVote withdraws the logged-in user's vote on a review
+func (h *ReviewHandler) DeleteReviewVote(w http.ResponseWriter, r *http.Request) {
+	// get review id
+	reviewId, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid review id")
+		return
+	}
+
+	// delete vote
+	if err := h.reviewService.DeleteReviewVote(r.Context(), reviewId); err != nil {
+		respondWithReviewError(w, err, "Failed to delete vote")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Vote deleted")
+}
+
+// ReportReview reports a review as abusive
+func (h *ReviewHandler) ReportReview(w http.ResponseWriter, r *http.Request) {
+	// get review id
+	reviewId, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid review id")
+		return
+	}
+
+	// params
+	var params struct {
+		Reason string `json:"reason"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// report review
+	if err := h.reviewService.ReportReview(r.Context(), reviewId, params.Reason); err != nil {
+		respondWithReviewError(w, err, "Failed to report review")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusCreated, "Review reported")
+}
+
+// GetModerationQueue gets reviews by moderation status, pending by default, optionally only those
+// flagged for using banned words
+func (h *ReviewHandler) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	// get filters, page and page size
+	status := r.URL.Query().Get("status")
+	flaggedOnly := r.URL.Query().Get("flagged") == "true"
+	page, pageSize, _ := GetPageAndPageSize(r.URL.Query().Get("page"), r.URL.Query().Get("page_size"))
+
+	// get reviews
+	reviews, err := h.reviewService.GetModerationQueue(r.Context(), status, flaggedOnly, pageSize, page)
+	if err != nil {
+		respondWithReviewError(w, err, "Failed to get reviews")
+		return
+	}
+
+	// respond with reviews
+	RespondWithJSON(w, http.StatusOK, reviews)
+}
+
+// ModerateReview approves or rejects a review
+func (h *ReviewHandler) ModerateReview(w http.ResponseWriter, r *http.Request) {
+	// get review id
+	reviewId, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid review id")
+		return
+	}
+
+	// params
+	var params struct {
+		Status string `json:"status"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// moderate review
+	review, err := h.reviewService.ModerateReview(r.Context(), reviewId, params.Status)
+	if err != nil {
+		respondWithReviewError(w, err, "Failed to moderate review")
+		return
+	}
+
+	// respond with review
+	RespondWithJSON(w, http.StatusOK, review)
+}
+
+func respondWithReviewError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidRating),
+		errors.Is(err, usecases.ErrCommentTooLong),
+		errors.Is(err, usecases.ErrInvalidReviewSort),
+		errors.Is(err, usecases.ErrInvalidReviewStatus),
+		errors.Is(err, usecases.ErrInvalidModeration),
+		errors.Is(err, usecases.ErrReportReasonTooLong):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrProductNotFound),
+		errors.Is(err, usecases.ErrReviewNotFound),
+		errors.Is(err, usecases.ErrVoteNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, repository.ErrOwnReview):
+		RespondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, repository.ErrAlreadyReviewed),
+		errors.Is(err, repository.ErrAlreadyReported):
+		RespondWithError(w, http.StatusConflict, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ShippingHandler struct {
+	shippingService *usecases.ShippingService
+}
+
+func NewShippingHandler(shippingService *usecases.ShippingService) *ShippingHandler {
+	return &ShippingHandler{
+		shippingService: shippingService,
+	}
+}
+
+// GetShippingZones gets every shipping zone with its rates
+func (h *ShippingHandler) GetShippingZones(w http.ResponseWriter, r *http.Request) {
+	// get shipping zones
+	zones, err := h.shippingService.GetShippingZones(r.Context())
+	if err != nil {
+		respondWithShippingError(w, err, "Failed to get shipping zones")
+		return
+	}
+
+	// respond with shipping zones
+	RespondWithJSON(w, http.StatusOK, zones)
+}
+
+// CreateShippingZone creates a shipping zone
+func (h *ShippingHandler) CreateShippingZone(w http.ResponseWriter, r *http.Request) {
+	// params
+	var params struct {
+		Name                  string  `json:"name"`
+		Country               string  `json:"country"`
+		PostalCodePrefix      string  `json:"postal_code_prefix"`
+		FreeShippingThreshold *string `json:"free_shipping_threshold"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// create shipping zone
+	zone, err := h.shippingService.CreateShippingZone(r.Context(), model.CreateShippingZoneParams{
+		Name:                  params.Name,
+		Country:               params.Country,
+		PostalCodePrefix:      params.PostalCodePrefix,
+		FreeShippingThreshold: toNullString(params.FreeShippingThreshold),
+	})
+	if err != nil {
+		respondWithShippingError(w, err, "Failed to create shipping zone")
+		return
+	}
+
+	// respond with shipping zone
+	RespondWithJSON(w, http.StatusCreated, zone)
+}
+
+// DeleteShippingZone deletes a shipping zone along with its rates
+func (h *ShippingHandler) DeleteShippingZone(w http.ResponseWriter, r *http.Request) {
+	// get shipping zone id
+	vars := mux.Vars(r)
+	zoneId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid shipping zone id")
+		return
+	}
+
+	// delete shipping zone
+	if err := h.shippingService.DeleteShippingZone(r.Context(), zoneId); err != nil {
+		respondWithShippingError(w, err, "Failed to delete shipping zone")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Shipping zone deleted")
+}
+
+// CreateShippingRate adds the price of a method for a weight band to a shipping zone
+func (h *ShippingHandler) CreateShippingRate(w http.ResponseWriter, r *http.Request) {
+	// get shipping zone id
+	vars := mux.Vars(r)
+	zoneId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid shipping zone id")
+		return
+	}
+
+	// params
+	var params struct {
+		Method         string       `json:"method"`
+		Name           string       `json:"name"`
+		MinWeightGrams int32        `json:"min_weight_grams"`
+		MaxWeightGrams *int32       `json:"max_weight_grams"`
+		Price          *money.Money `json:"price"`
+		EstimatedDays  *int32       `json:"estimated_days"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+	if params.Price == nil {
+		RespondWithError(w, http.StatusBadRequest, "Price is required")
+		return
+	}
+
+	// create shipping rate
+	rate, err := h.shippingService.CreateShippingRate(r.Context(), model.CreateShippingRateParams{
+		ZoneID:         zoneId,
+		Method:         params.Method,
+		Name:           params.Name,
+		MinWeightGrams: params.MinWeightGrams,
+		MaxWeightGrams: toNullInt32(params.MaxWeightGrams),
+		Price:          *params.Price,
+		EstimatedDays:  toNullInt32(params.EstimatedDays),
+	})
+	if err != nil {
+		respondWithShippingError(w, err, "Failed to create shipping rate")
+		return
+	}
+
+	// respond with shipping rate
+	RespondWithJSON(w, http.StatusCreated, rate)
+}
+
+// DeleteShippingRate deletes a shipping rate
+func (h *ShippingHandler) DeleteShippingRate(w http.ResponseWriter, r *http.Request) {
+	// get shipping rate id
+	vars := mux.Vars(r)
+	rateId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid shipping rate id")
+		return
+	}
+
+	// delete shipping rate
+	if err := h.shippingService.DeleteShippingRate(r.Context(), rateId); err != nil {
+		respondWithShippingError(w, err, "Failed to delete shipping rate")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Shipping rate deleted")
+}
+
+// UpdateProductShipping sets the weight and dimensions a product ships at
+func (h *ShippingHandler) UpdateProductShipping(w http.ResponseWriter, r *http.Request) {
+	// get product id
+	vars := mux.Vars(r)
+	productId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid product id")
+		return
+	}
+
+	// params
+	var params struct {
+		WeightGrams int32  `json:"weight_grams"`
+		LengthCm    *int32 `json:"length_cm"`
+		WidthCm     *int32 `json:"width_cm"`
+		HeightCm    *int32 `json:"height_cm"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// update product shipping
+	err = h.shippingService.UpdateProductShipping(r.Context(), productId, model.UpdateProductShippingParams{
+		WeightGrams: params.WeightGrams,
+		LengthCm:    toNullInt32(params.LengthCm),
+		WidthCm:     toNullInt32(params.WidthCm),
+		HeightCm:    toNullInt32(params.HeightCm),
+	})
+	if err != nil {
+		respondWithShippingError(w, err, "Failed to update product shipping")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Product shipping updated")
+}
+
+func respondWithShippingError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidShippingZone), errors.Is(err, usecases.ErrInvalidShippingRate),
+		errors.Is(err, usecases.ErrInvalidShippingMethod), errors.Is(err, usecases.ErrInvalidWeightBand),
+		errors.Is(err, usecases.ErrInvalidShippingPrice), errors.Is(err, usecases.ErrInvalidProductShipping):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrShippingZoneNotFound), errors.Is(err, usecases.ErrShippingRateNotFound),
+		errors.Is(err, usecases.ErrProductNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecases.ErrShippingZoneExists):
+		RespondWithError(w, http.StatusConflict, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
+
+func toNullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
+
+func toNullInt32(value *int32) sql.NullInt32 {
+	if value == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *value, Valid: true}
+}
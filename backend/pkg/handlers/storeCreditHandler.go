@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type StoreCreditHandler struct {
+	storeCreditService *usecases.StoreCreditService
+}
+
+func NewStoreCreditHandler(storeCreditService *usecases.StoreCreditService) *StoreCreditHandler {
+	return &StoreCreditHandler{
+		storeCreditService: storeCreditService,
+	}
+}
+
+// GetMyStoreCredit gets the logged-in user's store credit balance and ledger
+func (h *StoreCreditHandler) GetMyStoreCredit(w http.ResponseWriter, r *http.Request) {
+	// get store credit
+	storeCredit, err := h.storeCreditService.GetMyStoreCredit(r.Context())
+	if err != nil {
+		respondWithStoreCreditError(w, err, "Failed to get store credit")
+		return
+	}
+
+	// respond with store credit
+	RespondWithJSON(w, http.StatusOK, storeCredit)
+}
+
+// GetStoreCredit gets a user's store credit balance and ledger
+func (h *StoreCreditHandler) GetStoreCredit(w http.ResponseWriter, r *http.Request) {
+	// get user id
+	vars := mux.Vars(r)
+	userId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	// get store credit
+	storeCredit, err := h.storeCreditService.GetStoreCredit(r.Context(), userId)
+	if err != nil {
+		respondWithStoreCreditError(w, err, "Failed to get store credit")
+		return
+	}
+
+	// respond with store credit
+	RespondWithJSON(w, http.StatusOK, storeCredit)
+}
+
+// AdjustStoreCredit adds to or takes from a user's store credit by hand
+func (h *StoreCreditHandler) AdjustStoreCredit(w http.ResponseWriter, r *http.Request) {
+	// get user id
+	vars := mux.Vars(r)
+	userId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	// params
+	var params struct {
+		Amount string `json:"amount"`
+		Note   string `json:"note"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// adjust store credit
+	transaction, err := h.storeCreditService.AdjustStoreCredit(r.Context(), userId, params.Amount, params.Note)
+	if err != nil {
+		respondWithStoreCreditError(w, err, "Failed to adjust store credit")
+		return
+	}
+
+	// respond with ledger entry
+	RespondWithJSON(w, http.StatusCreated, transaction)
+}
+
+func respondWithStoreCreditError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidStoreCreditAmount), errors.Is(err, usecases.ErrReasonTooLong):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrUserNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, repository.ErrInsufficientStoreCredit):
+		RespondWithError(w, http.StatusConflict, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/geraldbahati/ecommerce/pkg/usecases"
 	"github.com/gorilla/mux"
 	"net/http"
@@ -26,6 +27,7 @@ func (h *SubCategoryHandler) CreateSubCategory(w http.ResponseWriter, r *http.Re
 		CategoryId  string `json:"category_id"`
 		ImageUrl    string `json:"image_url"`
 		SeoKeywords string `json:"seo_keywords"`
+		TaxClass    string `json:"tax_class"`
 	}
 
 	// decode request body
@@ -35,7 +37,11 @@ func (h *SubCategoryHandler) CreateSubCategory(w http.ResponseWriter, r *http.Re
 	}
 
 	// create sub category
-	subCategory, err := h.subCategoryService.CreateSubCategory(r.Context(), params.CategoryId, params.Name, params.Description, params.ImageUrl, params.SeoKeywords)
+	subCategory, err := h.subCategoryService.CreateSubCategory(r.Context(), params.CategoryId, params.Name, params.Description, params.ImageUrl, params.SeoKeywords, params.TaxClass)
+	if errors.Is(err, usecases.ErrInvalidTaxClass) {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		RespondWithError(w, http.StatusInternalServerError, "Failed to create sub category")
 		return
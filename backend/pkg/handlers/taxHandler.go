@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type TaxHandler struct {
+	taxService *usecases.TaxService
+}
+
+func NewTaxHandler(taxService *usecases.TaxService) *TaxHandler {
+	return &TaxHandler{
+		taxService: taxService,
+	}
+}
+
+// GetTaxRates gets every tax rate
+func (h *TaxHandler) GetTaxRates(w http.ResponseWriter, r *http.Request) {
+	// get tax rates
+	rates, err := h.taxService.GetTaxRates(r.Context())
+	if err != nil {
+		respondWithTaxError(w, err, "Failed to get tax rates")
+		return
+	}
+
+	// respond with tax rates
+	RespondWithJSON(w, http.StatusOK, rates)
+}
+
+// SaveTaxRate sets the rate for a country, or a region of it, and tax class
+func (h *TaxHandler) SaveTaxRate(w http.ResponseWriter, r *http.Request) {
+	// params
+	var params model.SaveTaxRateParams
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// save tax rate
+	rate, err := h.taxService.SaveTaxRate(r.Context(), params)
+	if err != nil {
+		respondWithTaxError(w, err, "Failed to save tax rate")
+		return
+	}
+
+	// respond with tax rate
+	RespondWithJSON(w, http.StatusOK, rate)
+}
+
+// DeleteTaxRate deletes a tax rate
+func (h *TaxHandler) DeleteTaxRate(w http.ResponseWriter, r *http.Request) {
+	// get tax rate id
+	vars := mux.Vars(r)
+	taxRateId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid tax rate id")
+		return
+	}
+
+	// delete tax rate
+	if err := h.taxService.DeleteTaxRate(r.Context(), taxRateId); err != nil {
+		respondWithTaxError(w, err, "Failed to delete tax rate")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Tax rate deleted")
+}
+
+// SetUserTaxExempt flags or unflags a customer as exempt from tax
+func (h *TaxHandler) SetUserTaxExempt(w http.ResponseWriter, r *http.Request) {
+	// get user id
+	vars := mux.Vars(r)
+	userId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	// params
+	var params struct {
+		TaxExempt bool `json:"tax_exempt"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// set tax exemption
+	if err := h.taxService.SetUserTaxExempt(r.Context(), userId, params.TaxExempt); err != nil {
+		respondWithTaxError(w, err, "Failed to set tax exemption")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Tax exemption updated")
+}
+
+// SetSubCategoryTaxClass sets the tax class of the products in a sub category
+func (h *TaxHandler) SetSubCategoryTaxClass(w http.ResponseWriter, r *http.Request) {
+	// get sub category id
+	vars := mux.Vars(r)
+	subCategoryId, err := uuid.Parse(vars["id"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid sub category id")
+		return
+	}
+
+	// params
+	var params struct {
+		TaxClass string `json:"tax_class"`
+	}
+
+	// decode request body
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+		return
+	}
+
+	// set tax class
+	if err := h.taxService.SetSubCategoryTaxClass(r.Context(), subCategoryId, params.TaxClass); err != nil {
+		respondWithTaxError(w, err, "Failed to set tax class")
+		return
+	}
+
+	// respond with success
+	RespondWithSuccess(w, http.StatusOK, "Tax class updated")
+}
+
+func respondWithTaxError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidTaxRate), errors.Is(err, usecases.ErrInvalidTaxClass),
+		errors.Is(err, usecases.ErrInvalidTaxLocation), errors.Is(err, usecases.ErrInvalidTaxName):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrTaxRateNotFound), errors.Is(err, usecases.ErrUserNotFound),
+		errors.Is(err, usecases.ErrSubCategoryNotFound):
+		RespondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+	}
+}
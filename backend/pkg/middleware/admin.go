@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// Admin only lets admins through. It must run after Auth, which puts the role in the context.
+func Admin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// get role from context
+		role, _ := r.Context().Value("userRole").(string)
+		if role != "admin" && role != "superadmin" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -7,33 +7,61 @@ import (
 
 func Auth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		const bearerSchema = "Bearer "
-
-		// get authorization header
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
+		// get claims from authorization header
+		claims, ok := parseAuthHeader(r)
+		if !ok {
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
 
-		// check if authorization header is valid
-		if len(authHeader) < len(bearerSchema) || authHeader[:len(bearerSchema)] != bearerSchema {
-			w.WriteHeader(http.StatusUnauthorized)
-			return
-		}
+		// set user id in context
+		ctx := utils.SetUserIdInContext(r.Context(), claims.UserId)
+		ctx = utils.SetUserRoleInContext(ctx, claims.Role)
 
-		// get token
-		token := authHeader[len(bearerSchema):]
-		claims, err := utils.ParseToken(token, true)
-		if err != nil {
-			w.WriteHeader(http.StatusUnauthorized)
+		next.ServeHTTP(w, r.WithContext(ctx))
+
+	})
+}
+
+// OptionalAuth puts the user in the context like Auth when the request carries a valid access
+// token, and otherwise lets the request through anonymously
+func OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := parseAuthHeader(r)
+		if !ok {
+			next.ServeHTTP(w, r)
 			return
 		}
 
 		// set user id in context
 		ctx := utils.SetUserIdInContext(r.Context(), claims.UserId)
-		
-		next.ServeHTTP(w, r.WithContext(ctx))
+		ctx = utils.SetUserRoleInContext(ctx, claims.Role)
 
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// parseAuthHeader gets the claims of the bearer access token in the authorization header
+func parseAuthHeader(r *http.Request) (*utils.UserClaims, bool) {
+	const bearerSchema = "Bearer "
+
+	// get authorization header
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return nil, false
+	}
+
+	// check if authorization header is valid
+	if len(authHeader) < len(bearerSchema) || authHeader[:len(bearerSchema)] != bearerSchema {
+		return nil, false
+	}
+
+	// get token
+	token := authHeader[len(bearerSchema):]
+	claims, err := utils.ParseToken(token, true)
+	if err != nil {
+		return nil, false
+	}
+
+	return claims, true
+}
@@ -21,13 +21,16 @@ func CORS(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, PUT, DELETE, OPTIONS")
 
 		// Set allowed headers
-		allowedHeaders := "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-CSRF-Token"
+		allowedHeaders := "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-CSRF-Token, Idempotency-Key, Accept-Currency"
 		if requestedHeaders := r.Header.Get("Access-Control-Request-Headers"); requestedHeaders != "" {
 			w.Header().Set("Access-Control-Allow-Headers", requestedHeaders)
 		} else {
 			w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
 		}
 
+		// let clients read the currency prices came back in
+		w.Header().Set("Access-Control-Expose-Headers", "Content-Currency")
+
 		// Handle Content-Type
 		if ct := r.Header.Get("Content-Type"); strings.Contains(ct, "multipart/form-data") {
 			w.Header().Set("Content-Type", "multipart/form-data")
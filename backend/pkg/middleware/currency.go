@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/geraldbahati/ecommerce/pkg/handlers"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+)
+
+const (
+	AcceptCurrencyHeader  = "Accept-Currency"
+	ContentCurrencyHeader = "Content-Currency"
+)
+
+// Currency lets customers see prices in another currency. The currency is taken from the
+// ?currency= parameter, then the Accept-Currency header, and must be active with an exchange rate;
+// a converter for it is put in the context under "currency". Requests that ask for no currency, or
+// for money.DefaultCurrency, see prices as they are stored. The currency used is sent back in the
+// Content-Currency header.
+func Currency(currencyRepo repository.CurrencyRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", AcceptCurrencyHeader)
+
+			// get requested currency
+			code := r.URL.Query().Get("currency")
+			if code == "" {
+				// only the first of a list of currencies is used
+				code, _, _ = strings.Cut(r.Header.Get(AcceptCurrencyHeader), ",")
+				code, _, _ = strings.Cut(code, ";")
+			}
+			if strings.TrimSpace(code) == "" {
+				w.Header().Set(ContentCurrencyHeader, money.DefaultCurrency)
+				next.ServeHTTP(w, r)
+				return
+			}
+			code, err := money.ParseCurrency(code)
+			if err != nil {
+				handlers.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if code == money.DefaultCurrency {
+				w.Header().Set(ContentCurrencyHeader, money.DefaultCurrency)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// get its exchange rate and rounding rule
+			currency, err := currencyRepo.GetCurrency(r.Context(), code)
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && (!currency.IsActive || !currency.Rate.Valid)) {
+				handlers.RespondWithError(w, http.StatusBadRequest, "Currency "+code+" is not supported")
+				return
+			}
+			if err != nil {
+				handlers.RespondWithError(w, http.StatusInternalServerError, "Failed to get currency")
+				return
+			}
+			converter, err := money.NewConverter(currency.Code, currency.Rate.String, currency.RoundingIncrement, currency.RoundingMode)
+			if err != nil {
+				log.Printf("Error reading exchange rate of %s: %s", code, err.Error())
+				handlers.RespondWithError(w, http.StatusInternalServerError, "Failed to get currency")
+				return
+			}
+
+			w.Header().Set(ContentCurrencyHeader, converter.Currency())
+			ctx := context.WithValue(r.Context(), "currency", converter)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/handlers"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	idempotencyKeyTTL         = 24 * time.Hour
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 1 << 20
+)
+
+// Idempotency makes retried requests safe. The first request with an Idempotency-Key runs and its
+// response is stored per user and key; a retry with the same body and Accept-Currency header gets
+// the stored response back, and the same key with a different one is rejected. It must run after Auth, which puts the user
+// id in the context. Requests without the header are passed straight through.
+func Idempotency(idempotencyRepo repository.IdempotencyRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// get key and user id
+			key := r.Header.Get(IdempotencyKeyHeader)
+			userId, ok := r.Context().Value("userId").(uuid.UUID)
+			if key == "" || !ok || r.Method == http.MethodGet || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				handlers.RespondWithError(w, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+				return
+			}
+
+			// read the body so it can be hashed, then hand it on untouched
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentRequestBytes))
+			if err != nil {
+				handlers.RespondWithError(w, http.StatusBadRequest, "Failed to read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			// the display currency changes what the request does, so a retry must ask for the same one
+			hash := sha256.New()
+			hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+			hash.Write([]byte(AcceptCurrencyHeader + ": " + r.Header.Get(AcceptCurrencyHeader) + "\n"))
+			hash.Write(body)
+			requestHash := hex.EncodeToString(hash.Sum(nil))
+
+			// claim key
+			stored, claimed, err := idempotencyRepo.ClaimIdempotencyKey(r.Context(), model.ClaimIdempotencyKeyParams{
+				UserID:        userId,
+				Key:           key,
+				RequestMethod: r.Method,
+				RequestPath:   r.URL.Path,
+				RequestHash:   requestHash,
+				ExpiredBefore: time.Now().Add(-idempotencyKeyTTL),
+			})
+			if err != nil {
+				handlers.RespondWithError(w, http.StatusInternalServerError, "Failed to check Idempotency-Key")
+				return
+			}
+
+			if !claimed {
+				switch {
+				case stored.RequestHash != requestHash:
+					handlers.RespondWithError(w, http.StatusConflict, "Idempotency-Key was already used for a different request")
+				case !stored.CompletedAt.Valid:
+					handlers.RespondWithError(w, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+				default:
+					// replay stored response
+					w.Header().Set(IdempotentReplayedHeader, "true")
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(int(stored.ResponseStatus.Int32))
+					w.Write([]byte(stored.ResponseBody.String))
+				}
+				return
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r)
+
+			// the client may have gone away, which is when the response matters most
+			ctx := context.WithoutCancel(r.Context())
+
+			// server errors are not stored, so the request can be retried
+			if recorder.status == 0 || recorder.status >= http.StatusInternalServerError {
+				if err := idempotencyRepo.DeleteIdempotencyKey(ctx, userId, key); err != nil {
+					log.Printf("Error releasing idempotency key %s: %s", key, err.Error())
+				}
+				return
+			}
+			if err := idempotencyRepo.CompleteIdempotencyKey(ctx, userId, key, recorder.status, recorder.body.String()); err != nil {
+				log.Printf("Error storing response for idempotency key %s: %s", key, err.Error())
+			}
+		})
+	}
+}
+
+// responseRecorder passes a response through while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(code int) {
+	if rec.status == 0 {
+		rec.status = code
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Address types, one address book of each per user
+const (
+	AddressTypeShipping = "shipping"
+	AddressTypeBilling  = "billing"
+)
+
+type Address struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	Type        string       `json:"type"`
+	Street      string       `json:"street"`
+	City        string       `json:"city"`
+	State       string       `json:"state"`
+	Country     string       `json:"country"`
+	PostalCode  string       `json:"postal_code"`
+	IsDefault   bool         `json:"is_default"`
+	CreatedAt   time.Time    `json:"created_at"`
+	LastUpdated sql.NullTime `json:"last_updated"`
+}
+
+// AddressBook is a user's saved addresses, each type with its default first
+type AddressBook struct {
+	Shipping []Address `json:"shipping"`
+	Billing  []Address `json:"billing"`
+}
+
+type SaveAddressParams struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Type       string    `json:"type"`
+	Street     string    `json:"street"`
+	City       string    `json:"city"`
+	State      string    `json:"state"`
+	Country    string    `json:"country"`
+	PostalCode string    `json:"postal_code"`
+	IsDefault  bool      `json:"is_default"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+type ShoppingCart struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	CreatedAt   time.Time    `json:"created_at"`
+	LastUpdated sql.NullTime `json:"last_updated"`
+	TotalItems  int32        `json:"total_items"`
+	TotalPrice  money.Money  `json:"total_price"`
+}
+
+type CartItem struct {
+	ID             uuid.UUID    `json:"id"`
+	ShoppingCartID uuid.UUID    `json:"shopping_cart_id"`
+	ProductID      uuid.UUID    `json:"product_id"`
+	Quantity       int32        `json:"quantity"`
+	CreatedAt      time.Time    `json:"created_at"`
+	LastUpdated    sql.NullTime `json:"last_updated"`
+}
+
+type CartItemDetail struct {
+	CartItem
+	ProductName   string         `json:"product_name"`
+	ImageUrl      sql.NullString `json:"image_url"`
+	Price         money.Money    `json:"price"`
+	DiscountRate  string         `json:"discount_rate"`
+	UnitPrice     money.Money    `json:"unit_price"`
+	Stock         int32          `json:"stock"`
+	IsActive      bool           `json:"is_active"`
+	TaxClass      string         `json:"tax_class"`
+	WeightGrams   int32          `json:"weight_grams"`
+	LengthCm      sql.NullInt32  `json:"length_cm"`
+	WidthCm       sql.NullInt32  `json:"width_cm"`
+	HeightCm      sql.NullInt32  `json:"height_cm"`
+	SubCategoryID uuid.NullUUID  `json:"sub_category_id"`
+	CategoryID    uuid.NullUUID  `json:"category_id"`
+	SaleStartsAt  sql.NullTime   `json:"sale_starts_at"`
+	SaleEndsAt    sql.NullTime   `json:"sale_ends_at"`
+	Pricing       Pricing        `json:"pricing"`
+}
+
+type Cart struct {
+	ShoppingCart
+	Items     []CartItemDetail  `json:"items"`
+	Promotion *AppliedPromotion `json:"promotion"`
+	Tax       TaxBreakdown      `json:"tax"`
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Currency struct {
+	Code              string         `json:"code"`
+	Name              string         `json:"name"`
+	Symbol            string         `json:"symbol"`
+	RoundingIncrement string         `json:"rounding_increment"`
+	RoundingMode      string         `json:"rounding_mode"`
+	IsActive          bool           `json:"is_active"`
+	CreatedAt         time.Time      `json:"created_at"`
+	LastUpdated       sql.NullTime   `json:"last_updated"`
+	Rate              sql.NullString `json:"rate"`
+	RateSource        sql.NullString `json:"rate_source"`
+	RateUpdatedAt     sql.NullTime   `json:"rate_updated_at"`
+}
+
+type ExchangeRate struct {
+	CurrencyCode string    `json:"currency_code"`
+	Rate         string    `json:"rate"`
+	Source       string    `json:"source"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type SaveCurrencyParams struct {
+	Code              string `json:"code"`
+	Name              string `json:"name"`
+	Symbol            string `json:"symbol"`
+	RoundingIncrement string `json:"rounding_increment"`
+	RoundingMode      string `json:"rounding_mode"`
+	IsActive          bool   `json:"is_active"`
+}
+
+type SetExchangeRateParams struct {
+	CurrencyCode string `json:"currency_code"`
+	Rate         string `json:"rate"`
+	Source       string `json:"source"`
+}
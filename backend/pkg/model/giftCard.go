@@ -0,0 +1,60 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+type GiftCard struct {
+	ID             uuid.UUID     `json:"id"`
+	Code           string        `json:"code"`
+	InitialBalance string        `json:"initial_balance"`
+	Balance        string        `json:"balance"`
+	ExpiresAt      sql.NullTime  `json:"expires_at"`
+	IsActive       bool          `json:"is_active"`
+	CreatedBy      uuid.NullUUID `json:"created_by"`
+	CreatedAt      time.Time     `json:"created_at"`
+	LastUpdated    sql.NullTime  `json:"last_updated"`
+}
+
+type GiftCardTransaction struct {
+	ID           uuid.UUID     `json:"id"`
+	GiftCardID   uuid.UUID     `json:"gift_card_id"`
+	Amount       string        `json:"amount"`
+	BalanceAfter string        `json:"balance_after"`
+	Reason       string        `json:"reason"`
+	OrderID      uuid.NullUUID `json:"order_id"`
+	RefundID     uuid.NullUUID `json:"refund_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type GiftCardDetail struct {
+	GiftCard
+	Transactions []GiftCardTransaction `json:"transactions"`
+}
+
+// GiftCardBalance is what a customer holding a code may see of the card
+type GiftCardBalance struct {
+	Code       string       `json:"code"`
+	Balance    string       `json:"balance"`
+	ExpiresAt  sql.NullTime `json:"expires_at"`
+	Redeemable bool         `json:"redeemable"`
+}
+
+type IssueGiftCardParams struct {
+	Code      string       `json:"code"`
+	Amount    money.Money  `json:"amount"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	CreatedBy uuid.UUID    `json:"created_by"`
+}
+
+// GiftCardRedemption is what a gift card paid towards an order
+type GiftCardRedemption struct {
+	GiftCardID   uuid.UUID `json:"gift_card_id"`
+	Code         string    `json:"code"`
+	Amount       string    `json:"amount"`
+	BalanceAfter string    `json:"balance_after"`
+}
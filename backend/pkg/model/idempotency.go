@@ -0,0 +1,29 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IdempotencyKey struct {
+	UserID         uuid.UUID      `json:"user_id"`
+	Key            string         `json:"key"`
+	RequestMethod  string         `json:"request_method"`
+	RequestPath    string         `json:"request_path"`
+	RequestHash    string         `json:"request_hash"`
+	ResponseStatus sql.NullInt32  `json:"response_status"`
+	ResponseBody   sql.NullString `json:"response_body"`
+	CreatedAt      time.Time      `json:"created_at"`
+	CompletedAt    sql.NullTime   `json:"completed_at"`
+}
+
+type ClaimIdempotencyKeyParams struct {
+	UserID        uuid.UUID `json:"user_id"`
+	Key           string    `json:"key"`
+	RequestMethod string    `json:"request_method"`
+	RequestPath   string    `json:"request_path"`
+	RequestHash   string    `json:"request_hash"`
+	ExpiredBefore time.Time `json:"expired_before"`
+}
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+type Order struct {
+	ID                uuid.UUID      `json:"id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	OrderStatus       string         `json:"order_status"`
+	PaymentStatus     string         `json:"payment_status"`
+	PaymentMethod     string         `json:"payment_method"`
+	ShippingAddress   string         `json:"shipping_address"`
+	BillingAddress    string         `json:"billing_address"`
+	TotalPrice        string         `json:"total_price"`
+	CreatedAt         time.Time      `json:"created_at"`
+	LastUpdated       sql.NullTime   `json:"last_updated"`
+	ShippingMethod    sql.NullString `json:"shipping_method"`
+	ShippingCost      string         `json:"shipping_cost"`
+	Currency          string         `json:"currency"`
+	ExchangeRate      string         `json:"exchange_rate"`
+	SettlementTotal   sql.NullString `json:"settlement_total"`
+	GiftCardAmount    string         `json:"gift_card_amount"`
+	StoreCreditAmount string         `json:"store_credit_amount"`
+}
+
+type OrderItem struct {
+	ID             uuid.UUID    `json:"id"`
+	OrderID        uuid.UUID    `json:"order_id"`
+	ProductID      uuid.UUID    `json:"product_id"`
+	Quantity       int32        `json:"quantity"`
+	UnitPrice      string       `json:"unit_price"`
+	TaxedPrice     string       `json:"taxed_price"`
+	TaxRate        string       `json:"tax_rate"`
+	TaxAmount      string       `json:"tax_amount"`
+	DiscountAmount string       `json:"discount_amount"`
+	CreatedAt      time.Time    `json:"created_at"`
+	LastUpdated    sql.NullTime `json:"last_updated"`
+}
+
+type PlaceOrderParams struct {
+	UserID          uuid.UUID              `json:"user_id"`
+	CartID          uuid.UUID              `json:"cart_id"`
+	PaymentMethod   string                 `json:"payment_method"`
+	ShippingAddress string                 `json:"shipping_address"`
+	BillingAddress  string                 `json:"billing_address"`
+	Taxes           []LineTax              `json:"taxes"`
+	ShippingMethod  sql.NullString         `json:"shipping_method"`
+	ShippingCost    money.Money            `json:"shipping_cost"`
+	Promotion       *RedeemPromotionParams `json:"promotion"`
+	Currency        money.Converter        `json:"-"`
+	GiftCardCodes   []string               `json:"gift_card_codes"`
+	UseStoreCredit  bool                   `json:"use_store_credit"`
+}
+
+type CheckoutResult struct {
+	Order       Order                   `json:"order"`
+	Items       []OrderItem             `json:"items"`
+	Tax         TaxBreakdown            `json:"tax"`
+	Promotion   *PromotionRedemption    `json:"promotion"`
+	GiftCards   []GiftCardRedemption    `json:"gift_cards"`
+	StoreCredit *StoreCreditTransaction `json:"store_credit"`
+	Payment     *Payment                `json:"payment,omitempty"`
+}
+
+type OrderStatusChange struct {
+	ID         uuid.UUID      `json:"id"`
+	OrderID    uuid.UUID      `json:"order_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	ActorID    uuid.NullUUID  `json:"actor_id"`
+	Reason     sql.NullString `json:"reason"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type OrderTransitionParams struct {
+	OrderID     uuid.UUID `json:"order_id"`
+	AllowedFrom []string  `json:"allowed_from"`
+	ToStatus    string    `json:"to_status"`
+	ActorID     uuid.UUID `json:"actor_id"`
+	Reason      string    `json:"reason"`
+}
+
+type OrderTimeline struct {
+	Order   Order               `json:"order"`
+	History []OrderStatusChange `json:"history"`
+}
+
+type OrderItemDetail struct {
+	OrderItem
+	ProductName string         `json:"product_name"`
+	ImageUrl    sql.NullString `json:"image_url"`
+}
+
+type OrderDetail struct {
+	Order
+	Items     []OrderItemDetail    `json:"items"`
+	History   []OrderStatusChange  `json:"history"`
+	Refunds   []RefundDetail       `json:"refunds"`
+	Promotion *PromotionRedemption `json:"promotion"`
+}
+
+type OrderFilter struct {
+	Status      sql.NullString `json:"status"`
+	CreatedFrom sql.NullTime   `json:"created_from"`
+	CreatedTo   sql.NullTime   `json:"created_to"`
+}
+
+type OrderCancelParams struct {
+	OrderID     uuid.UUID     `json:"order_id"`
+	OwnerID     uuid.NullUUID `json:"owner_id"`
+	AllowedFrom []string      `json:"allowed_from"`
+	ActorID     uuid.UUID     `json:"actor_id"`
+	Reason      string        `json:"reason"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Payment struct {
+	ID                    uuid.UUID      `json:"id"`
+	OrderID               uuid.UUID      `json:"order_id"`
+	Provider              string         `json:"provider"`
+	PaymentMethod         string         `json:"payment_method"`
+	Amount                string         `json:"amount"`
+	Status                string         `json:"status"`
+	ProviderTransactionID sql.NullString `json:"provider_transaction_id"`
+	FailureMessage        sql.NullString `json:"failure_message"`
+	CreatedAt             time.Time      `json:"created_at"`
+	LastUpdated           sql.NullTime   `json:"last_updated"`
+	Currency              string         `json:"currency"`
+}
+
+type BeginPaymentParams struct {
+	OrderID  uuid.UUID     `json:"order_id"`
+	OwnerID  uuid.NullUUID `json:"owner_id"`
+	Provider string        `json:"provider"`
+}
+
+type UpdatePaymentParams struct {
+	ID                    uuid.UUID `json:"id"`
+	Status                string    `json:"status"`
+	ProviderTransactionID string    `json:"provider_transaction_id"`
+	FailureMessage        string    `json:"failure_message"`
+	OrderPaymentStatus    string    `json:"order_payment_status"`
+}
+
+type PaymentEventParams struct {
+	Provider           string   `json:"provider"`
+	EventID            string   `json:"event_id"`
+	EventType          string   `json:"event_type"`
+	TransactionID      string   `json:"transaction_id"`
+	Payload            string   `json:"payload"`
+	Status             string   `json:"status"`
+	AllowedFrom        []string `json:"allowed_from"`
+	FailureMessage     string   `json:"failure_message"`
+	OrderPaymentStatus string   `json:"order_payment_status"`
+	OrderPaymentFrom   []string `json:"order_payment_from"`
+}
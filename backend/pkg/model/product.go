@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/geraldbahati/ecommerce/pkg/money"
 	"github.com/google/uuid"
 )
 
@@ -12,7 +13,7 @@ type Product struct {
 	Name          string         `json:"name"`
 	Description   sql.NullString `json:"description"`
 	ImageUrl      sql.NullString `json:"image_url"`
-	Price         string         `json:"price"`
+	Price         money.Money    `json:"price"`
 	Stock         int32          `json:"stock"`
 	SubCategoryID uuid.NullUUID  `json:"sub_category_id"`
 	Brand         sql.NullString `json:"brand"`
@@ -23,6 +24,24 @@ type Product struct {
 	IsActive      bool           `json:"is_active"`
 	CreatedAt     time.Time      `json:"created_at"`
 	LastUpdated   sql.NullTime   `json:"last_updated"`
+	WeightGrams   int32          `json:"weight_grams"`
+	LengthCm      sql.NullInt32  `json:"length_cm"`
+	WidthCm       sql.NullInt32  `json:"width_cm"`
+	HeightCm      sql.NullInt32  `json:"height_cm"`
+	SaleStartsAt  sql.NullTime   `json:"sale_starts_at"`
+	SaleEndsAt    sql.NullTime   `json:"sale_ends_at"`
+	Pricing       Pricing        `json:"pricing"`
+}
+
+// Pricing is what a product sells for right now. SalePrice equals ListPrice and Savings is zero
+// unless the product's sale is running.
+type Pricing struct {
+	ListPrice    money.Money  `json:"list_price"`
+	SalePrice    money.Money  `json:"sale_price"`
+	Savings      money.Money  `json:"savings"`
+	DiscountRate string       `json:"discount_rate"`
+	OnSale       bool         `json:"on_sale"`
+	SaleEndsAt   sql.NullTime `json:"sale_ends_at"`
 }
 
 type ProductListing struct {
@@ -39,29 +58,83 @@ type ProductDetails struct {
 	CategoryName string `json:"category_name"`
 }
 
+// Review moderation statuses. Only approved reviews are shown and count towards a product's rating.
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
 type ProductReview struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Rating    float32   `json:"rating"`
-	Comment   string    `json:"comment"`
-	CreatedAt time.Time `json:"created_at"`
+	ID               uuid.UUID    `json:"id"`
+	UserID           uuid.UUID    `json:"user_id"`
+	Username         string       `json:"username,omitempty"`
+	ProductID        uuid.UUID    `json:"product_id"`
+	Rating           float32      `json:"rating"`
+	Comment          string       `json:"comment"`
+	Status           string       `json:"status"`
+	VerifiedPurchase bool         `json:"verified_purchase"`
+	HelpfulCount     int64        `json:"helpful_count"`
+	UnhelpfulCount   int64        `json:"unhelpful_count"`
+	CreatedAt        time.Time    `json:"created_at"`
+	LastUpdated      sql.NullTime `json:"last_updated"`
 }
 
-type TrendingProduct struct {
+// ReviewModerationItem is a review as admins see it in the moderation queue. ReportCount only
+// counts reports made since the review was last moderated.
+type ReviewModerationItem struct {
+	ProductReview
+	ProductName  string        `json:"product_name"`
+	FlaggedWords []string      `json:"flagged_words"`
+	ReportCount  int64         `json:"report_count"`
+	ModeratedBy  uuid.NullUUID `json:"moderated_by"`
+	ModeratedAt  sql.NullTime  `json:"moderated_at"`
+}
+
+type SaveReviewParams struct {
+	UserID       uuid.UUID `json:"user_id"`
 	ProductID    uuid.UUID `json:"product_id"`
-	ProductName  string    `json:"product_name"`
-	Price        string    `json:"price"`
-	CategoryID   uuid.UUID `json:"category_id"`
-	CategoryName string    `json:"category_name"`
-	SalesVolume  int64     `json:"sales_volume"`
+	Rating       int32     `json:"rating"`
+	Comment      string    `json:"comment"`
+	FlaggedWords []string  `json:"flagged_words"`
+}
+
+type ReviewVoteParams struct {
+	ReviewID uuid.UUID `json:"review_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Helpful  bool      `json:"helpful"`
+}
+
+type ReportReviewParams struct {
+	ReviewID uuid.UUID `json:"review_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Reason   string    `json:"reason"`
+}
+
+type ModerateReviewParams struct {
+	ReviewID    uuid.UUID `json:"review_id"`
+	Status      string    `json:"status"`
+	ModeratedBy uuid.UUID `json:"moderated_by"`
+}
+
+type TrendingProduct struct {
+	ProductID    uuid.UUID    `json:"product_id"`
+	ProductName  string       `json:"product_name"`
+	Price        money.Money  `json:"price"`
+	CategoryID   uuid.UUID    `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	SalesVolume  int64        `json:"sales_volume"`
+	DiscountRate string       `json:"discount_rate"`
+	SaleStartsAt sql.NullTime `json:"sale_starts_at"`
+	SaleEndsAt   sql.NullTime `json:"sale_ends_at"`
+	Pricing      Pricing      `json:"pricing"`
 }
 
 type AddProductParams struct {
 	Name          string         `json:"name"`
 	Description   sql.NullString `json:"description"`
 	ImageUrl      sql.NullString `json:"image_url"`
-	Price         string         `json:"price"`
+	Price         money.Money    `json:"price"`
 	Stock         int32          `json:"stock"`
 	SubCategoryID uuid.NullUUID  `json:"sub_category_id"`
 	Brand         sql.NullString `json:"brand"`
@@ -73,7 +146,7 @@ type GetProductsByCategoryRow struct {
 	Name            string         `json:"name"`
 	Description     sql.NullString `json:"description"`
 	ImageUrl        sql.NullString `json:"image_url"`
-	Price           string         `json:"price"`
+	Price           money.Money    `json:"price"`
 	Stock           int32          `json:"stock"`
 	Brand           sql.NullString `json:"brand"`
 	Rating          string         `json:"rating"`
@@ -86,6 +159,9 @@ type GetProductsByCategoryRow struct {
 	SubCategoryID   uuid.NullUUID  `json:"sub_category_id"`
 	SubCategoryName string         `json:"sub_category_name"`
 	CategoryName    string         `json:"category_name"`
+	SaleStartsAt    sql.NullTime   `json:"sale_starts_at"`
+	SaleEndsAt      sql.NullTime   `json:"sale_ends_at"`
+	Pricing         Pricing        `json:"pricing"`
 }
 
 type ProductMaterial struct {
@@ -109,17 +185,21 @@ type UpdateProductParams struct {
 	Name          string         `json:"name"`
 	Description   sql.NullString `json:"description"`
 	ImageUrl      sql.NullString `json:"image_url"`
-	Price         string         `json:"price"`
+	Price         money.Money    `json:"price"`
 	Stock         int32          `json:"stock"`
 	SubCategoryID uuid.NullUUID  `json:"sub_category_id"`
 	Brand         sql.NullString `json:"brand"`
-	Rating        string         `json:"rating"`
-	ReviewCount   int32          `json:"review_count"`
 	DiscountRate  string         `json:"discount_rate"`
 	Keywords      sql.NullString `json:"keywords"`
 	IsActive      bool           `json:"is_active"`
 }
 
+type UpdateProductSaleParams struct {
+	DiscountRate string       `json:"discount_rate"`
+	SaleStartsAt sql.NullTime `json:"sale_starts_at"`
+	SaleEndsAt   sql.NullTime `json:"sale_ends_at"`
+}
+
 type UpdateProductColourParams struct {
 	ID        uuid.UUID `json:"id"`
 	ProductID uuid.UUID `json:"product_id"`
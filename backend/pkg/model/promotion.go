@@ -0,0 +1,91 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+type Promotion struct {
+	ID             uuid.UUID      `json:"id"`
+	Code           string         `json:"code"`
+	Description    sql.NullString `json:"description"`
+	DiscountType   string         `json:"discount_type"`
+	DiscountValue  string         `json:"discount_value"`
+	MinCartValue   sql.NullString `json:"min_cart_value"`
+	StartsAt       sql.NullTime   `json:"starts_at"`
+	EndsAt         sql.NullTime   `json:"ends_at"`
+	MaxUses        sql.NullInt32  `json:"max_uses"`
+	MaxUsesPerUser sql.NullInt32  `json:"max_uses_per_user"`
+	TimesUsed      int32          `json:"times_used"`
+	IsActive       bool           `json:"is_active"`
+	CreatedAt      time.Time      `json:"created_at"`
+	LastUpdated    sql.NullTime   `json:"last_updated"`
+}
+
+type PromotionTarget struct {
+	TargetType string    `json:"target_type"`
+	TargetID   uuid.UUID `json:"target_id"`
+}
+
+type PromotionDetail struct {
+	Promotion
+	Targets []PromotionTarget `json:"targets"`
+}
+
+type CreatePromotionParams struct {
+	Code           string            `json:"code"`
+	Description    sql.NullString    `json:"description"`
+	DiscountType   string            `json:"discount_type"`
+	DiscountValue  string            `json:"discount_value"`
+	MinCartValue   sql.NullString    `json:"min_cart_value"`
+	StartsAt       sql.NullTime      `json:"starts_at"`
+	EndsAt         sql.NullTime      `json:"ends_at"`
+	MaxUses        sql.NullInt32     `json:"max_uses"`
+	MaxUsesPerUser sql.NullInt32     `json:"max_uses_per_user"`
+	Targets        []PromotionTarget `json:"targets"`
+}
+
+type PromotionRedemption struct {
+	ID             uuid.UUID `json:"id"`
+	PromotionID    uuid.UUID `json:"promotion_id"`
+	OrderID        uuid.UUID `json:"order_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Code           string    `json:"code"`
+	DiscountAmount string    `json:"discount_amount"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type RedeemPromotionParams struct {
+	PromotionID    uuid.UUID   `json:"promotion_id"`
+	Code           string      `json:"code"`
+	DiscountAmount money.Money `json:"discount_amount"`
+}
+
+type PromotionReport struct {
+	PromotionID   uuid.UUID `json:"promotion_id"`
+	Code          string    `json:"code"`
+	TimesUsed     int32     `json:"times_used"`
+	Redemptions   int64     `json:"redemptions"`
+	Customers     int64     `json:"customers"`
+	DiscountTotal string    `json:"discount_total"`
+	Revenue       string    `json:"revenue"`
+}
+
+type LineDiscount struct {
+	ProductID uuid.UUID   `json:"product_id"`
+	Amount    money.Money `json:"amount"`
+}
+
+type AppliedPromotion struct {
+	PromotionID   uuid.UUID      `json:"promotion_id"`
+	Code          string         `json:"code"`
+	Description   sql.NullString `json:"description"`
+	DiscountType  string         `json:"discount_type"`
+	Lines         []LineDiscount `json:"lines"`
+	ItemsDiscount money.Money    `json:"items_discount"`
+	FreeShipping  bool           `json:"free_shipping"`
+	Error         string         `json:"error,omitempty"`
+}
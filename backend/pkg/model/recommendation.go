@@ -0,0 +1,14 @@
+package model
+
+// Why a product is recommended
+const (
+	RecommendationReasonBoughtTogether = "bought_together"
+	RecommendationReasonBestseller     = "bestseller"
+	RecommendationReasonSavedTogether  = "saved_together"
+	RecommendationReasonPopularSaved   = "popular_in_wishlists"
+)
+
+type RecommendedProduct struct {
+	Product
+	Reason string `json:"reason"`
+}
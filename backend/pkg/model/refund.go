@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+type RefundItem struct {
+	ID          uuid.UUID   `json:"id"`
+	RefundID    uuid.UUID   `json:"refund_id"`
+	OrderItemID uuid.UUID   `json:"order_item_id"`
+	Quantity    int32       `json:"quantity"`
+	Amount      money.Money `json:"amount"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+type RefundDetail struct {
+	Refund
+	Items []RefundItem `json:"items"`
+}
+
+type RefundItemParams struct {
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	Quantity    int32     `json:"quantity"`
+}
+
+type CreateRefundParams struct {
+	OrderID   uuid.UUID          `json:"order_id"`
+	Amount    money.Money        `json:"amount"`
+	Items     []RefundItemParams `json:"items"`
+	Reason    string             `json:"reason"`
+	CreatedBy uuid.UUID          `json:"created_by"`
+	Method    string             `json:"method"`
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+type ReturnRequest struct {
+	ID          uuid.UUID      `json:"id"`
+	OrderID     uuid.UUID      `json:"order_id"`
+	UserID      uuid.UUID      `json:"user_id"`
+	Status      string         `json:"status"`
+	Reason      string         `json:"reason"`
+	AdminNote   sql.NullString `json:"admin_note"`
+	CreatedAt   time.Time      `json:"created_at"`
+	LastUpdated sql.NullTime   `json:"last_updated"`
+}
+
+type ReturnRequestItem struct {
+	ID              uuid.UUID `json:"id"`
+	ReturnRequestID uuid.UUID `json:"return_request_id"`
+	OrderItemID     uuid.UUID `json:"order_item_id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	Quantity        int32     `json:"quantity"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ReturnRequestDetail struct {
+	ReturnRequest
+	Items  []ReturnRequestItem `json:"items"`
+	Refund *Refund             `json:"refund,omitempty"`
+}
+
+type Refund struct {
+	ID               uuid.UUID      `json:"id"`
+	OrderID          uuid.UUID      `json:"order_id"`
+	ReturnRequestID  uuid.NullUUID  `json:"return_request_id"`
+	Amount           money.Money    `json:"amount"`
+	Status           string         `json:"status"`
+	Reason           sql.NullString `json:"reason"`
+	CreatedAt        time.Time      `json:"created_at"`
+	LastUpdated      sql.NullTime   `json:"last_updated"`
+	PaymentID        uuid.NullUUID  `json:"payment_id"`
+	ProviderRefundID sql.NullString `json:"provider_refund_id"`
+	FailureMessage   sql.NullString `json:"failure_message"`
+	CreatedBy        uuid.NullUUID  `json:"created_by"`
+	SettlementAmount sql.NullString `json:"settlement_amount"`
+	Method           string         `json:"method"`
+}
+
+type ReturnItemParams struct {
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	Quantity    int32     `json:"quantity"`
+}
+
+type CreateReturnRequestParams struct {
+	OrderID        uuid.UUID          `json:"order_id"`
+	UserID         uuid.UUID          `json:"user_id"`
+	Reason         string             `json:"reason"`
+	Items          []ReturnItemParams `json:"items"`
+	DeliveredAfter time.Time          `json:"delivered_after"`
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+type ShippingZone struct {
+	ID                    uuid.UUID      `json:"id"`
+	Name                  string         `json:"name"`
+	Country               string         `json:"country"`
+	PostalCodePrefix      string         `json:"postal_code_prefix"`
+	FreeShippingThreshold sql.NullString `json:"free_shipping_threshold"`
+	CreatedAt             time.Time      `json:"created_at"`
+	LastUpdated           sql.NullTime   `json:"last_updated"`
+}
+
+type ShippingRate struct {
+	ID             uuid.UUID     `json:"id"`
+	ZoneID         uuid.UUID     `json:"zone_id"`
+	Method         string        `json:"method"`
+	Name           string        `json:"name"`
+	MinWeightGrams int32         `json:"min_weight_grams"`
+	MaxWeightGrams sql.NullInt32 `json:"max_weight_grams"`
+	Price          money.Money   `json:"price"`
+	EstimatedDays  sql.NullInt32 `json:"estimated_days"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type ShippingZoneDetail struct {
+	ShippingZone
+	Rates []ShippingRate `json:"rates"`
+}
+
+type CreateShippingZoneParams struct {
+	Name                  string         `json:"name"`
+	Country               string         `json:"country"`
+	PostalCodePrefix      string         `json:"postal_code_prefix"`
+	FreeShippingThreshold sql.NullString `json:"free_shipping_threshold"`
+}
+
+type CreateShippingRateParams struct {
+	ZoneID         uuid.UUID     `json:"zone_id"`
+	Method         string        `json:"method"`
+	Name           string        `json:"name"`
+	MinWeightGrams int32         `json:"min_weight_grams"`
+	MaxWeightGrams sql.NullInt32 `json:"max_weight_grams"`
+	Price          money.Money   `json:"price"`
+	EstimatedDays  sql.NullInt32 `json:"estimated_days"`
+}
+
+type UpdateProductShippingParams struct {
+	WeightGrams int32         `json:"weight_grams"`
+	LengthCm    sql.NullInt32 `json:"length_cm"`
+	WidthCm     sql.NullInt32 `json:"width_cm"`
+	HeightCm    sql.NullInt32 `json:"height_cm"`
+}
+
+type ShippingDestination struct {
+	Country    string `json:"country"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+}
+
+type ShippingOption struct {
+	Method        string        `json:"method"`
+	Name          string        `json:"name"`
+	Price         money.Money   `json:"price"`
+	Cost          money.Money   `json:"cost"`
+	EstimatedDays sql.NullInt32 `json:"estimated_days"`
+	FreeShipping  bool          `json:"free_shipping"`
+}
+
+type ShippingQuote struct {
+	Destination           *ShippingDestination `json:"destination"`
+	Zone                  *ShippingZone        `json:"zone"`
+	WeightGrams           int32                `json:"weight_grams"`
+	Subtotal              money.Money          `json:"subtotal"`
+	FreeShippingThreshold sql.NullString       `json:"free_shipping_threshold"`
+	Options               []ShippingOption     `json:"options"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+type StoreCreditTransaction struct {
+	ID           uuid.UUID      `json:"id"`
+	UserID       uuid.UUID      `json:"user_id"`
+	Amount       string         `json:"amount"`
+	BalanceAfter string         `json:"balance_after"`
+	Reason       string         `json:"reason"`
+	OrderID      uuid.NullUUID  `json:"order_id"`
+	RefundID     uuid.NullUUID  `json:"refund_id"`
+	Note         sql.NullString `json:"note"`
+	CreatedBy    uuid.NullUUID  `json:"created_by"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// StoreCredit is a user's store credit balance with the ledger that adds up to it, newest first
+type StoreCredit struct {
+	UserID       uuid.UUID                `json:"user_id"`
+	Balance      string                   `json:"balance"`
+	Transactions []StoreCreditTransaction `json:"transactions"`
+}
+
+type AdjustStoreCreditParams struct {
+	UserID    uuid.UUID   `json:"user_id"`
+	Amount    money.Money `json:"amount"`
+	Note      string      `json:"note"`
+	CreatedBy uuid.UUID   `json:"created_by"`
+}
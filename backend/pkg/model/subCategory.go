@@ -16,6 +16,7 @@ type SubCategory struct {
 	IsActive    bool           `json:"is_active"`
 	CreatedAt   time.Time      `json:"created_at"`
 	LastUpdated sql.NullTime   `json:"last_updated"`
+	TaxClass    string         `json:"tax_class"`
 }
 
 type AddSubCategoryParams struct {
@@ -25,4 +26,5 @@ type AddSubCategoryParams struct {
 	Description sql.NullString `json:"description"`
 	ImageUrl    sql.NullString `json:"image_url"`
 	SeoKeywords sql.NullString `json:"seo_keywords"`
+	TaxClass    string         `json:"tax_class"`
 }
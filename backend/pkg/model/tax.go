@@ -0,0 +1,67 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+type TaxRate struct {
+	ID               uuid.UUID    `json:"id"`
+	Country          string       `json:"country"`
+	Region           string       `json:"region"`
+	TaxClass         string       `json:"tax_class"`
+	Name             string       `json:"name"`
+	Rate             string       `json:"rate"`
+	PricesIncludeTax bool         `json:"prices_include_tax"`
+	CreatedAt        time.Time    `json:"created_at"`
+	LastUpdated      sql.NullTime `json:"last_updated"`
+}
+
+type SaveTaxRateParams struct {
+	Country          string `json:"country"`
+	Region           string `json:"region"`
+	TaxClass         string `json:"tax_class"`
+	Name             string `json:"name"`
+	Rate             string `json:"rate"`
+	PricesIncludeTax bool   `json:"prices_include_tax"`
+}
+
+type TaxLocation struct {
+	Country string `json:"country"`
+	Region  string `json:"region"`
+}
+
+type TaxProfile struct {
+	Exempt   bool         `json:"exempt"`
+	Location *TaxLocation `json:"location"`
+}
+
+type TaxLine struct {
+	ProductID uuid.UUID   `json:"product_id"`
+	TaxClass  string      `json:"tax_class"`
+	UnitPrice money.Money `json:"unit_price"`
+	Quantity  int32       `json:"quantity"`
+	Discount  money.Money `json:"discount"`
+}
+
+type LineTax struct {
+	TaxLine
+	TaxName          string      `json:"tax_name"`
+	Rate             string      `json:"rate"`
+	PricesIncludeTax bool        `json:"prices_include_tax"`
+	NetAmount        money.Money `json:"net_amount"`
+	TaxAmount        money.Money `json:"tax_amount"`
+	TaxedPrice       money.Money `json:"taxed_price"`
+}
+
+type TaxBreakdown struct {
+	Location *TaxLocation `json:"location"`
+	Exempt   bool         `json:"exempt"`
+	Lines    []LineTax    `json:"lines"`
+	NetTotal money.Money  `json:"net_total"`
+	TaxTotal money.Money  `json:"tax_total"`
+	Total    money.Money  `json:"total"`
+}
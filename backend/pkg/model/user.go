@@ -22,6 +22,7 @@ type User struct {
 	UserRole       string         `json:"user_role"`
 	ProfilePicture sql.NullString `json:"profile_picture"`
 	TwoFactorAuth  bool           `json:"two_factor_auth"`
+	TaxExempt      bool           `json:"tax_exempt"`
 }
 
 type UserRegister struct {
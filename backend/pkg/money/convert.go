@@ -0,0 +1,122 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Rounding modes for converted amounts
+const (
+	RoundHalfUp = "half_up"
+	RoundUp     = "up"
+	RoundDown   = "down"
+)
+
+var (
+	ErrInvalidRate         = errors.New("exchange rate must be a positive decimal with at most eight decimal places")
+	ErrInvalidRoundingMode = errors.New("rounding mode must be one of half_up, up or down")
+	ErrInvalidIncrement    = errors.New("rounding increment must be a positive amount")
+)
+
+var ratePattern = regexp.MustCompile(`^\d{1,10}(\.\d{1,8})?$`)
+
+// Converter turns amounts in DefaultCurrency into another currency at a stored exchange rate,
+// the number of units of the currency one unit of DefaultCurrency buys. Converted amounts are
+// rounded to the currency's increment, such as 0.05 or 1.00. The zero value leaves amounts in
+// DefaultCurrency untouched.
+type Converter struct {
+	currency  string
+	rate      *big.Rat
+	increment int64
+	mode      string
+}
+
+// NewConverter builds a converter from a currency's stored rate and rounding rule
+func NewConverter(currency string, rate string, increment string, mode string) (Converter, error) {
+	currency, err := ParseCurrency(currency)
+	if err != nil {
+		return Converter{}, err
+	}
+	value, err := ParseRate(rate)
+	if err != nil {
+		return Converter{}, err
+	}
+	step, err := Parse(increment, currency)
+	if err != nil || step.Sign() <= 0 {
+		return Converter{}, ErrInvalidIncrement
+	}
+	if !ValidRoundingMode(mode) {
+		return Converter{}, ErrInvalidRoundingMode
+	}
+
+	return Converter{
+		currency:  currency,
+		rate:      value,
+		increment: step.Cents(),
+		mode:      mode,
+	}, nil
+}
+
+// ParseRate reads an exchange rate, which must be more than zero with at most eight decimal
+// places to fit a DECIMAL(18, 8) column
+func ParseRate(rate string) (*big.Rat, error) {
+	rate = strings.TrimSpace(rate)
+	if !ratePattern.MatchString(rate) {
+		return nil, ErrInvalidRate
+	}
+	value, ok := new(big.Rat).SetString(rate)
+	if !ok || value.Sign() <= 0 {
+		return nil, ErrInvalidRate
+	}
+	return value, nil
+}
+
+// ValidRoundingMode reports whether mode is one of the rounding modes above
+func ValidRoundingMode(mode string) bool {
+	switch mode {
+	case RoundHalfUp, RoundUp, RoundDown:
+		return true
+	}
+	return false
+}
+
+// Currency returns the currency amounts are converted into
+func (c Converter) Currency() string {
+	if c.rate == nil {
+		return DefaultCurrency
+	}
+	return c.currency
+}
+
+// Rate returns the exchange rate as it is stored, with eight decimal places
+func (c Converter) Rate() string {
+	if c.rate == nil {
+		return "1.00000000"
+	}
+	return c.rate.FloatString(8)
+}
+
+// Convert turns an amount in DefaultCurrency into the converter's currency. Halves are rounded
+// away from zero in the half_up mode, up rounds away from zero and down rounds towards it.
+func (c Converter) Convert(amount Money) Money {
+	if c.rate == nil {
+		return amount
+	}
+	amount.mustMatch(Zero(DefaultCurrency))
+
+	// count the increments the converted amount is worth
+	steps := new(big.Rat).Mul(big.NewRat(amount.cents, c.increment), c.rate)
+	quotient, remainder := new(big.Int).QuoRem(steps.Num(), steps.Denom(), new(big.Int))
+	switch c.mode {
+	case RoundUp:
+		if remainder.Sign() != 0 {
+			quotient.Add(quotient, big.NewInt(int64(steps.Sign())))
+		}
+	case RoundHalfUp:
+		quotient, _ = new(big.Int).SetString(steps.FloatString(0), 10)
+	}
+
+	return Money{cents: quotient.Int64() * c.increment, currency: c.currency}
+}
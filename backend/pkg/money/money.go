@@ -0,0 +1,234 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency the catalog is priced in and amounts are stored in
+const DefaultCurrency = "USD"
+
+var (
+	ErrInvalidAmount   = errors.New("amount must be a decimal of zero or more with at most two decimal places and eight digits before the point")
+	ErrInvalidCurrency = errors.New("currency must be a three-letter ISO 4217 code")
+)
+
+var (
+	amountPattern   = regexp.MustCompile(`^\d{1,8}(\.\d{1,2})?$`)
+	decimalPattern  = regexp.MustCompile(`^-?\d{1,16}(\.\d{1,2})?$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// Money is an exact amount of a currency, held in hundredths of its unit as amounts are stored
+// in DECIMAL(10, 2) columns. The zero value is zero of DefaultCurrency. Arithmetic between
+// amounts of different currencies is a programming error and panics.
+type Money struct {
+	cents    int64
+	currency string
+}
+
+// New returns the given number of hundredths of a currency
+func New(cents int64, currency string) Money {
+	return Money{cents: cents, currency: normalizeCurrency(currency)}
+}
+
+// Zero returns no money in a currency
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// Parse reads an amount entered by a user, such as a price. It must be zero or more, have at
+// most two decimal places and fit a DECIMAL(10, 2) column.
+func Parse(amount string, currency string) (Money, error) {
+	amount = strings.TrimSpace(amount)
+	if !amountPattern.MatchString(amount) {
+		return Money{}, ErrInvalidAmount
+	}
+	return parse(amount, currency)
+}
+
+// FromDecimal reads an amount as Postgres formats a DECIMAL column. Unlike Parse it allows
+// negative amounts and totals larger than a single price.
+func FromDecimal(amount string, currency string) (Money, error) {
+	if !decimalPattern.MatchString(amount) {
+		return Money{}, fmt.Errorf("invalid decimal amount %q", amount)
+	}
+	return parse(amount, currency)
+}
+
+func parse(amount string, currency string) (Money, error) {
+	currency = normalizeCurrency(currency)
+	if !currencyPattern.MatchString(currency) {
+		return Money{}, ErrInvalidCurrency
+	}
+
+	negative := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(amount, "-")
+	units, fraction, _ := strings.Cut(amount, ".")
+	fraction = (fraction + "00")[:2]
+	cents, err := strconv.ParseInt(units+fraction, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidAmount
+	}
+	if negative {
+		cents = -cents
+	}
+	return Money{cents: cents, currency: currency}, nil
+}
+
+// ParseCurrency reads a currency code, which must be three letters, and upper-cases it
+func ParseCurrency(code string) (string, error) {
+	code = normalizeCurrency(code)
+	if !currencyPattern.MatchString(code) {
+		return "", ErrInvalidCurrency
+	}
+	return code, nil
+}
+
+// Cents returns the amount in hundredths of the currency's unit
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+func (m Money) Currency() string {
+	if m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+// Rat returns the amount as an exact fraction of the currency's unit
+func (m Money) Rat() *big.Rat {
+	return big.NewRat(m.cents, 100)
+}
+
+// String formats the amount with two decimal places, as it is stored, without the currency
+func (m Money) String() string {
+	return m.Rat().FloatString(2)
+}
+
+func (m Money) Sign() int {
+	switch {
+	case m.cents < 0:
+		return -1
+	case m.cents > 0:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+func (m Money) Add(other Money) Money {
+	m.mustMatch(other)
+	return Money{cents: m.cents + other.cents, currency: m.Currency()}
+}
+
+func (m Money) Sub(other Money) Money {
+	m.mustMatch(other)
+	return Money{cents: m.cents - other.cents, currency: m.Currency()}
+}
+
+// Mul multiplies the amount by a whole number, such as a quantity
+func (m Money) Mul(quantity int64) Money {
+	return Money{cents: m.cents * quantity, currency: m.Currency()}
+}
+
+// MulRat multiplies the amount by a fraction, such as a rate, rounding to the cent with halves
+// rounded away from zero as Postgres' ROUND does
+func (m Money) MulRat(factor *big.Rat) Money {
+	product := new(big.Rat).Mul(big.NewRat(m.cents, 1), factor)
+	cents, _ := strconv.ParseInt(product.FloatString(0), 10, 64)
+	return Money{cents: cents, currency: m.Currency()}
+}
+
+// Cmp compares two amounts of the same currency, returning -1, 0 or 1
+func (m Money) Cmp(other Money) int {
+	m.mustMatch(other)
+	switch {
+	case m.cents < other.cents:
+		return -1
+	case m.cents > other.cents:
+		return 1
+	}
+	return 0
+}
+
+// Min returns the smaller of two amounts
+func (m Money) Min(other Money) Money {
+	if m.Cmp(other) > 0 {
+		return other
+	}
+	return m
+}
+
+func (m Money) mustMatch(other Money) {
+	if m.Currency() != other.Currency() {
+		panic(fmt.Sprintf("money: mixing %s and %s", m.Currency(), other.Currency()))
+	}
+}
+
+type jsonMoney struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+// MarshalJSON writes the amount as {"amount": "12.50", "currency": "USD"}
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{
+		Amount:   m.String(),
+		Currency: m.Currency(),
+	})
+}
+
+// UnmarshalJSON reads an amount written as MarshalJSON does, or as a bare string or number in
+// DefaultCurrency. The amount must be one Parse accepts.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value jsonMoney
+	switch {
+	case len(data) > 0 && data[0] == '{':
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+	case len(data) > 0 && data[0] == '"':
+		var amount string
+		if err := json.Unmarshal(data, &amount); err != nil {
+			return err
+		}
+		value.Amount = json.Number(amount)
+	default:
+		value.Amount = json.Number(data)
+	}
+
+	if value.Currency == "" {
+		value.Currency = DefaultCurrency
+	}
+	parsed, err := Parse(value.Amount.String(), value.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func normalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
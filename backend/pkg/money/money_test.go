@@ -0,0 +1,99 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestMulRat(t *testing.T) {
+	tests := []struct {
+		name   string
+		cents  int64
+		factor *big.Rat
+		want   int64
+	}{
+		{"whole cents", 1000, big.NewRat(1, 4), 250},
+		{"half rounds up", 5, big.NewRat(1, 2), 3},
+		{"negative half rounds down", -5, big.NewRat(1, 2), -3},
+		{"below half rounds down", 1001, big.NewRat(1, 10), 100},
+		{"above half rounds up", 1006, big.NewRat(1, 10), 101},
+		{"inclusive tax share", 1200, big.NewRat(20, 120), 200},
+		{"zero factor", 999, new(big.Rat), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.cents, "USD").MulRat(tt.factor)
+			if got.Cents() != tt.want {
+				t.Errorf("MulRat() = %d cents, want %d", got.Cents(), tt.want)
+			}
+			if got.Currency() != "USD" {
+				t.Errorf("MulRat() currency = %s, want USD", got.Currency())
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		want     int64
+		wantErr  error
+	}{
+		{"whole units", "12", "USD", 1200, nil},
+		{"one decimal place", "12.5", "usd", 1250, nil},
+		{"two decimal places", "0.05", "USD", 5, nil},
+		{"surrounding spaces", " 3.10 ", "USD", 310, nil},
+		{"largest amount", "99999999.99", "USD", 9999999999, nil},
+		{"negative", "-1.00", "USD", 0, ErrInvalidAmount},
+		{"three decimal places", "1.005", "USD", 0, ErrInvalidAmount},
+		{"too many digits", "100000000", "USD", 0, ErrInvalidAmount},
+		{"not a number", "ten", "USD", 0, ErrInvalidAmount},
+		{"empty", "", "USD", 0, ErrInvalidAmount},
+		{"bad currency", "1.00", "US", 0, ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.amount, tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Cents() != tt.want {
+				t.Errorf("Parse() = %d cents, want %d", got.Cents(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromDecimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  string
+		want    int64
+		wantErr bool
+	}{
+		{"stored amount", "19.99", 1999, false},
+		{"negative", "-2.50", -250, false},
+		{"larger than a price", "1234567890.00", 123456789000, false},
+		{"no decimals", "7", 700, false},
+		{"three decimal places", "1.005", 0, true},
+		{"leading plus", "+1.00", 0, true},
+		{"empty", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromDecimal(tt.amount, "USD")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromDecimal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Cents() != tt.want {
+				t.Errorf("FromDecimal() = %d cents, want %d", got.Cents(), tt.want)
+			}
+		})
+	}
+}
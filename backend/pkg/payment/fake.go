@@ -0,0 +1,125 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Test card numbers understood by FakeGateway. Any other card number, and payment methods
+// without a card, are approved.
+const (
+	CardApproved          = "4242424242424242"
+	CardDeclined          = "4000000000000002"
+	CardInsufficientFunds = "4000000000009995"
+	CardProcessingError   = "4000000000000119"
+	CardCaptureDeclined   = "4000000000000341"
+	CardRefundDeclined    = "4000000000005126"
+)
+
+var (
+	ErrUnknownTransaction = errors.New("unknown transaction")
+	ErrProcessingError    = errors.New("the payment provider could not process the request")
+)
+
+type fakeTransaction struct {
+	cardNumber string
+	amount     string
+	status     string
+}
+
+// FakeGateway is an in-process PaymentGateway for development and offline testing. Its outcomes
+// are driven by the card numbers above and it keeps its transactions in memory only.
+type FakeGateway struct {
+	mu           sync.Mutex
+	transactions map[string]*fakeTransaction
+}
+
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{
+		transactions: make(map[string]*fakeTransaction),
+	}
+}
+
+func (g *FakeGateway) Name() string {
+	return "fake"
+}
+
+func (g *FakeGateway) Authorize(ctx context.Context, request AuthorizeRequest) (Result, error) {
+	switch request.CardNumber {
+	case CardProcessingError:
+		return Result{}, ErrProcessingError
+	case CardDeclined:
+		return Result{Approved: false, Message: "card declined"}, nil
+	case CardInsufficientFunds:
+		return Result{Approved: false, Message: "insufficient funds"}, nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	transactionId := "fake_" + uuid.NewString()
+	g.transactions[transactionId] = &fakeTransaction{
+		cardNumber: request.CardNumber,
+		amount:     request.Amount,
+		status:     "authorized",
+	}
+	return Result{TransactionID: transactionId, Approved: true, Message: "authorized"}, nil
+}
+
+func (g *FakeGateway) Capture(ctx context.Context, transactionId string, amount string) (Result, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	transaction, ok := g.transactions[transactionId]
+	if !ok {
+		return Result{}, ErrUnknownTransaction
+	}
+	if transaction.status != "authorized" {
+		return Result{TransactionID: transactionId, Approved: false, Message: fmt.Sprintf("cannot capture a %s transaction", transaction.status)}, nil
+	}
+	if transaction.cardNumber == CardCaptureDeclined {
+		return Result{TransactionID: transactionId, Approved: false, Message: "capture declined"}, nil
+	}
+
+	transaction.status = "captured"
+	transaction.amount = amount
+	return Result{TransactionID: transactionId, Approved: true, Message: "captured"}, nil
+}
+
+func (g *FakeGateway) Refund(ctx context.Context, transactionId string, amount string) (Result, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	transaction, ok := g.transactions[transactionId]
+	if !ok {
+		return Result{}, ErrUnknownTransaction
+	}
+	if transaction.status != "captured" {
+		return Result{TransactionID: transactionId, Approved: false, Message: fmt.Sprintf("cannot refund a %s transaction", transaction.status)}, nil
+	}
+	if transaction.cardNumber == CardRefundDeclined {
+		return Result{TransactionID: transactionId, Approved: false, Message: "refund declined"}, nil
+	}
+
+	return Result{TransactionID: transactionId, Approved: true, Message: "refunded " + amount}, nil
+}
+
+func (g *FakeGateway) Void(ctx context.Context, transactionId string) (Result, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	transaction, ok := g.transactions[transactionId]
+	if !ok {
+		return Result{}, ErrUnknownTransaction
+	}
+	if transaction.status != "authorized" {
+		return Result{TransactionID: transactionId, Approved: false, Message: fmt.Sprintf("cannot void a %s transaction", transaction.status)}, nil
+	}
+
+	transaction.status = "voided"
+	return Result{TransactionID: transactionId, Approved: true, Message: "voided"}, nil
+}
@@ -0,0 +1,43 @@
+package payment
+
+import (
+	"context"
+)
+
+// PaymentGateway is a payment provider that money can be taken through. Amounts are decimal
+// strings, as they are stored in the database, in the currency the payment was authorized in.
+//
+// A declined operation is not an error: it comes back with Approved set to false and the
+// provider's message. Errors are kept for when the provider could not be reached or gave an
+// answer that could not be understood.
+type PaymentGateway interface {
+	// Name identifies the provider in the payments table
+	Name() string
+
+	// Authorize reserves the amount without taking it
+	Authorize(ctx context.Context, request AuthorizeRequest) (Result, error)
+
+	// Capture takes an authorized amount
+	Capture(ctx context.Context, transactionId string, amount string) (Result, error)
+
+	// Refund gives back some or all of a captured amount
+	Refund(ctx context.Context, transactionId string, amount string) (Result, error)
+
+	// Void releases an authorization that was never captured
+	Void(ctx context.Context, transactionId string) (Result, error)
+}
+
+type AuthorizeRequest struct {
+	// Reference is our id for the attempt, passed to the provider for reconciliation
+	Reference     string
+	Amount        string
+	Currency      string
+	PaymentMethod string
+	CardNumber    string
+}
+
+type Result struct {
+	TransactionID string
+	Approved      bool
+	Message       string
+}
@@ -0,0 +1,76 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// SignatureHeader carries the hex encoded HMAC-SHA256 of the raw webhook body, optionally
+// prefixed with "sha256=".
+const SignatureHeader = "X-Payment-Signature"
+
+var ErrInvalidWebhookEvent = errors.New("webhook event must have an id, a known type and a transaction id")
+
+// eventStatuses maps webhook event types to the payment status they report
+var eventStatuses = map[string]string{
+	"payment.authorized": "authorized",
+	"payment.captured":   "captured",
+	"payment.declined":   "declined",
+	"payment.failed":     "failed",
+	"payment.voided":     "voided",
+}
+
+// WebhookEvent is the body providers post to the payment webhook
+type WebhookEvent struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	TransactionID string `json:"transaction_id"`
+	Message       string `json:"message"`
+}
+
+// PaymentStatus is the payment status the event reports
+func (e WebhookEvent) PaymentStatus() string {
+	return eventStatuses[e.Type]
+}
+
+// Sign computes the signature of a webhook body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks a webhook body against the signature header in constant time.
+// An empty secret never verifies, so a provider without one configured cannot post events.
+func VerifySignature(secret string, body []byte, signature string) bool {
+	if secret == "" {
+		return false
+	}
+
+	expected, err := hex.DecodeString(Sign(secret, body))
+	if err != nil {
+		return false
+	}
+	actual, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, actual)
+}
+
+// ParseWebhookEvent decodes and validates a webhook body
+func ParseWebhookEvent(body []byte) (WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return WebhookEvent{}, err
+	}
+	if event.ID == "" || len(event.ID) > 100 || event.TransactionID == "" || len(event.TransactionID) > 100 ||
+		event.PaymentStatus() == "" {
+		return WebhookEvent{}, ErrInvalidWebhookEvent
+	}
+	return event, nil
+}
@@ -0,0 +1,204 @@
+package postal
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// countries.json holds the address rules for each country, keyed by its ISO 3166-1 alpha-2
+// code: the fields it requires, the pattern its postal codes follow and, where addresses name
+// one, its states or provinces by code. Countries not listed only need a street, city and
+// country.
+//
+//go:embed countries.json
+var countriesJSON []byte
+
+// Address fields, as they are named in requests and validation errors
+const (
+	FieldStreet     = "street"
+	FieldCity       = "city"
+	FieldState      = "state"
+	FieldCountry    = "country"
+	FieldPostalCode = "postal_code"
+)
+
+var defaultRequired = []string{FieldStreet, FieldCity}
+
+// maxLengths are the sizes of the address columns
+var maxLengths = map[string]int{
+	FieldStreet:     255,
+	FieldCity:       100,
+	FieldState:      100,
+	FieldCountry:    100,
+	FieldPostalCode: 20,
+}
+
+type Address struct {
+	Street     string
+	City       string
+	State      string
+	Country    string
+	PostalCode string
+}
+
+type country struct {
+	Name              string            `json:"name"`
+	Aliases           []string          `json:"aliases"`
+	Required          []string          `json:"required"`
+	PostalCodePattern string            `json:"postal_code_pattern"`
+	PostalCodeExample string            `json:"postal_code_example"`
+	States            map[string]string `json:"states"`
+
+	code       string
+	postalCode *regexp.Regexp
+	// states by lower-cased code and name
+	states map[string]string
+}
+
+// countries by lower-cased code, name and alias
+var countries = loadCountries(countriesJSON)
+
+func loadCountries(data []byte) map[string]*country {
+	var byCode map[string]*country
+	if err := json.Unmarshal(data, &byCode); err != nil {
+		panic(fmt.Sprintf("postal: invalid countries.json: %v", err))
+	}
+
+	lookup := make(map[string]*country)
+	for code, c := range byCode {
+		c.code = code
+		if c.PostalCodePattern != "" {
+			c.postalCode = regexp.MustCompile(c.PostalCodePattern)
+		}
+		c.states = make(map[string]string, 2*len(c.States))
+		for stateCode, name := range c.States {
+			c.states[strings.ToLower(stateCode)] = stateCode
+			c.states[strings.ToLower(name)] = stateCode
+		}
+
+		lookup[strings.ToLower(code)] = c
+		lookup[strings.ToLower(c.Name)] = c
+		for _, alias := range c.Aliases {
+			lookup[strings.ToLower(alias)] = c
+		}
+	}
+	return lookup
+}
+
+// ValidationError says what is wrong with each field of an address
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	problems := make([]string, len(fields))
+	for i, field := range fields {
+		problems[i] = field + " " + e.Fields[field]
+	}
+	return "invalid address: " + strings.Join(problems, "; ")
+}
+
+// WithPrefix names the fields as part of a larger request, such as "shipping_address.city"
+func (e *ValidationError) WithPrefix(prefix string) *ValidationError {
+	fields := make(map[string]string, len(e.Fields))
+	for field, problem := range e.Fields {
+		fields[prefix+"."+field] = problem
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// Validate tidies an address up and checks it against the rules for its country. Runs of
+// whitespace become single spaces and postal codes are upper-cased. Known countries, and their
+// states or provinces, may be given by code or name and come back as their code. Anything wrong
+// is reported field by field in a *ValidationError, alongside the tidied address.
+func Validate(address Address) (Address, error) {
+	address = Address{
+		Street:     collapseSpaces(address.Street),
+		City:       collapseSpaces(address.City),
+		State:      collapseSpaces(address.State),
+		Country:    collapseSpaces(address.Country),
+		PostalCode: strings.ToUpper(collapseSpaces(address.PostalCode)),
+	}
+	values := map[string]*string{
+		FieldStreet:     &address.Street,
+		FieldCity:       &address.City,
+		FieldState:      &address.State,
+		FieldCountry:    &address.Country,
+		FieldPostalCode: &address.PostalCode,
+	}
+	problems := make(map[string]string)
+
+	// every address needs a country, and what else it needs depends on the country
+	required := defaultRequired
+	c := countries[strings.ToLower(address.Country)]
+	if address.Country == "" {
+		problems[FieldCountry] = "is required"
+	} else if c != nil {
+		address.Country = c.code
+		required = c.Required
+	}
+	for _, field := range required {
+		if *values[field] == "" {
+			problems[field] = "is required"
+		}
+	}
+	for field, max := range maxLengths {
+		if len(*values[field]) > max {
+			problems[field] = fmt.Sprintf("must be at most %d characters", max)
+		}
+	}
+
+	// check the state and postal code belong to the country
+	if c != nil && address.State != "" && len(c.states) > 0 && problems[FieldState] == "" {
+		if code, ok := c.states[strings.ToLower(address.State)]; ok {
+			address.State = code
+		} else {
+			problems[FieldState] = fmt.Sprintf("is not a state or province of %s", c.Name)
+		}
+	}
+	if c != nil && address.PostalCode != "" && c.postalCode != nil && problems[FieldPostalCode] == "" &&
+		!c.postalCode.MatchString(address.PostalCode) {
+		problems[FieldPostalCode] = fmt.Sprintf("is not a valid postal code for %s, such as %s", c.Name, c.PostalCodeExample)
+	}
+
+	if len(problems) > 0 {
+		return address, &ValidationError{Fields: problems}
+	}
+	return address, nil
+}
+
+// NormalizeCountry gives the code of a known country named by code or name, and otherwise the
+// name with its whitespace tidied
+func NormalizeCountry(name string) string {
+	name = collapseSpaces(name)
+	if c, ok := countries[strings.ToLower(name)]; ok {
+		return c.code
+	}
+	return name
+}
+
+// NormalizeState gives the code of a known state or province of a country named by code or
+// name, and otherwise the name with its whitespace tidied
+func NormalizeState(countryName string, name string) string {
+	name = collapseSpaces(name)
+	if c, ok := countries[strings.ToLower(collapseSpaces(countryName))]; ok {
+		if code, ok := c.states[strings.ToLower(name)]; ok {
+			return code
+		}
+	}
+	return name
+}
+
+func collapseSpaces(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,80 @@
+package pricing
+
+import (
+	"database/sql"
+	"math/big"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+)
+
+// SaleActive reports whether a sale window is open at the given time. A missing start or end
+// leaves that side of the window open, so a product with neither is always on sale when it has
+// a discount rate.
+func SaleActive(saleStartsAt sql.NullTime, saleEndsAt sql.NullTime, now time.Time) bool {
+	if saleStartsAt.Valid && now.Before(saleStartsAt.Time) {
+		return false
+	}
+	if saleEndsAt.Valid && !now.Before(saleEndsAt.Time) {
+		return false
+	}
+	return true
+}
+
+// Calculate works out what a product sells for at the given time. The sale price is the list
+// price less the discount rate, rounded to the cent, and only applies while the sale window is
+// open. It is the unit price carts and checkout charge as well as the price products are listed at.
+func Calculate(price money.Money, discountRate string, saleStartsAt sql.NullTime, saleEndsAt sql.NullTime, now time.Time) model.Pricing {
+	pricing := model.Pricing{
+		ListPrice:    price,
+		SalePrice:    price,
+		Savings:      money.Zero(price.Currency()),
+		DiscountRate: "0.0",
+	}
+
+	rate, ok := new(big.Rat).SetString(discountRate)
+	if !ok || rate.Sign() <= 0 || !SaleActive(saleStartsAt, saleEndsAt, now) {
+		return pricing
+	}
+	if rate.Cmp(big.NewRat(1, 1)) > 0 {
+		rate = big.NewRat(1, 1)
+	}
+
+	salePrice := price.MulRat(new(big.Rat).Sub(big.NewRat(1, 1), rate))
+	savings := price.Sub(salePrice)
+	if savings.Sign() <= 0 {
+		return pricing
+	}
+
+	pricing.SalePrice = salePrice
+	pricing.Savings = savings
+	pricing.DiscountRate = rate.FloatString(1)
+	pricing.OnSale = true
+	pricing.SaleEndsAt = saleEndsAt
+	return pricing
+}
+
+// ApplyToProducts sets the pricing of each product at the given time
+func ApplyToProducts(products []model.Product, now time.Time) {
+	for i := range products {
+		products[i].Pricing = Calculate(products[i].Price, products[i].DiscountRate, products[i].SaleStartsAt, products[i].SaleEndsAt, now)
+	}
+}
+
+// Convert shows pricing in another currency. The list and sale prices are converted on their own
+// and the savings are the difference between them, so the three always agree.
+func Convert(pricing model.Pricing, converter money.Converter) model.Pricing {
+	pricing.ListPrice = converter.Convert(pricing.ListPrice)
+	pricing.SalePrice = converter.Convert(pricing.SalePrice)
+	pricing.Savings = pricing.ListPrice.Sub(pricing.SalePrice)
+	return pricing
+}
+
+// ConvertProducts shows the price and pricing of each product in another currency
+func ConvertProducts(products []model.Product, converter money.Converter) {
+	for i := range products {
+		products[i].Price = converter.Convert(products[i].Price)
+		products[i].Pricing = Convert(products[i].Pricing, converter)
+	}
+}
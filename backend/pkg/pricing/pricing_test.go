@@ -0,0 +1,59 @@
+package pricing
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/money"
+)
+
+func TestCalculate(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	before := sql.NullTime{Time: now.Add(-time.Hour), Valid: true}
+	after := sql.NullTime{Time: now.Add(time.Hour), Valid: true}
+
+	tests := []struct {
+		name         string
+		price        int64
+		discountRate string
+		startsAt     sql.NullTime
+		endsAt       sql.NullTime
+		wantSale     int64
+		wantSavings  int64
+		wantRate     string
+		wantOnSale   bool
+	}{
+		{"no discount", 1000, "0.0", sql.NullTime{}, sql.NullTime{}, 1000, 0, "0.0", false},
+		{"open window", 1000, "0.25", sql.NullTime{}, sql.NullTime{}, 750, 250, "0.3", true},
+		{"sale price rounds to the cent", 999, "0.15", sql.NullTime{}, sql.NullTime{}, 849, 150, "0.2", true},
+		{"rate above one is clamped", 1000, "1.5", sql.NullTime{}, sql.NullTime{}, 0, 1000, "1.0", true},
+		{"negative rate is ignored", 1000, "-0.5", sql.NullTime{}, sql.NullTime{}, 1000, 0, "0.0", false},
+		{"unreadable rate is ignored", 1000, "half", sql.NullTime{}, sql.NullTime{}, 1000, 0, "0.0", false},
+		{"inside the window", 1000, "0.1", before, after, 900, 100, "0.1", true},
+		{"before the window", 1000, "0.1", after, sql.NullTime{}, 1000, 0, "0.0", false},
+		{"after the window", 1000, "0.1", sql.NullTime{}, before, 1000, 0, "0.0", false},
+		{"discount too small to save a cent", 1, "0.1", sql.NullTime{}, sql.NullTime{}, 1, 0, "0.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Calculate(money.New(tt.price, "USD"), tt.discountRate, tt.startsAt, tt.endsAt, now)
+			if got.ListPrice.Cents() != tt.price {
+				t.Errorf("ListPrice = %d cents, want %d", got.ListPrice.Cents(), tt.price)
+			}
+			if got.SalePrice.Cents() != tt.wantSale {
+				t.Errorf("SalePrice = %d cents, want %d", got.SalePrice.Cents(), tt.wantSale)
+			}
+			if got.Savings.Cents() != tt.wantSavings {
+				t.Errorf("Savings = %d cents, want %d", got.Savings.Cents(), tt.wantSavings)
+			}
+			if got.DiscountRate != tt.wantRate {
+				t.Errorf("DiscountRate = %s, want %s", got.DiscountRate, tt.wantRate)
+			}
+			if got.OnSale != tt.wantOnSale {
+				t.Errorf("OnSale = %v, want %v", got.OnSale, tt.wantOnSale)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type AddressRepository interface {
+	// create
+	CreateAddress(ctx context.Context, params model.SaveAddressParams) (model.Address, error)
+
+	// update
+	UpdateAddress(ctx context.Context, addressId uuid.UUID, params model.SaveAddressParams) (model.Address, error)
+	SetDefaultAddress(ctx context.Context, userId uuid.UUID, addressType string, addressId uuid.UUID) (model.Address, error)
+
+	// delete
+	DeleteAddress(ctx context.Context, userId uuid.UUID, addressType string, addressId uuid.UUID) error
+
+	// get
+	GetAddresses(ctx context.Context, userId uuid.UUID) (model.AddressBook, error)
+	GetAddress(ctx context.Context, userId uuid.UUID, addressType string, addressId uuid.UUID) (model.Address, error)
+	GetDefaultAddress(ctx context.Context, userId uuid.UUID, addressType string) (model.Address, error)
+}
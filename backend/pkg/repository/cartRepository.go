@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type CartRepository interface {
+	// create
+	GetOrCreateCart(ctx context.Context, userId uuid.UUID) (model.ShoppingCart, error)
+
+	// update
+	SetCartItemQuantity(ctx context.Context, cartId uuid.UUID, productId uuid.UUID, quantity int32) (model.ShoppingCart, error)
+
+	// delete
+	RemoveCartItem(ctx context.Context, cartId uuid.UUID, productId uuid.UUID) (model.ShoppingCart, error)
+
+	// get
+	GetCartItem(ctx context.Context, cartId uuid.UUID, productId uuid.UUID) (model.CartItem, error)
+	ListCartItems(ctx context.Context, cartId uuid.UUID) ([]model.CartItemDetail, error)
+}
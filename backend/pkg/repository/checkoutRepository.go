@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+)
+
+type CheckoutRepository interface {
+	// create
+	PlaceOrder(ctx context.Context, params model.PlaceOrderParams) (model.CheckoutResult, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+)
+
+type CurrencyRepository interface {
+	// create
+	SaveCurrency(ctx context.Context, params model.SaveCurrencyParams) (model.Currency, error)
+
+	// update
+	SetExchangeRates(ctx context.Context, rates []model.SetExchangeRateParams) ([]model.ExchangeRate, error)
+
+	// get
+	GetCurrencies(ctx context.Context, activeOnly bool) ([]model.Currency, error)
+	GetCurrency(ctx context.Context, code string) (model.Currency, error)
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmptyCart            = errors.New("cart is empty")
+	ErrCartChanged          = errors.New("cart prices changed while checking out, please review the cart")
+	ErrPromotionUnavailable = errors.New("the promotion code can no longer be used")
+)
+
+// InsufficientStockError reports the product that ran short while placing an order
+type InsufficientStockError struct {
+	ProductID   uuid.UUID
+	ProductName string
+	Requested   int32
+	Available   int32
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for product %s (%s): requested %d, available %d",
+		e.ProductName, e.ProductID.String(), e.Requested, e.Available)
+}
+
+// InvalidTransitionError reports an order status change that the current status does not allow
+type InvalidTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("cannot move order from %s to %s", e.From, e.To)
+}
+
+var (
+	ErrOrderNotDelivered  = errors.New("only delivered orders can be returned")
+	ErrReturnWindowClosed = errors.New("the return window for this order has closed")
+)
+
+// InvalidReturnItemError reports an order item that cannot be returned in the requested quantity
+type InvalidReturnItemError struct {
+	OrderItemID uuid.UUID
+	Requested   int32
+	Returnable  int32
+}
+
+func (e *InvalidReturnItemError) Error() string {
+	return fmt.Sprintf("cannot return %d of order item %s: %d returnable",
+		e.Requested, e.OrderItemID.String(), e.Returnable)
+}
+
+var (
+	ErrOrderNotPayable   = errors.New("order cannot be paid online")
+	ErrOrderAlreadyPaid  = errors.New("order has already been paid")
+	ErrPaymentInProgress = errors.New("a payment for this order is already in progress")
+	ErrPaymentExpired    = errors.New("the payment attempt expired before it was completed")
+)
+
+var (
+	ErrOrderNotRefundable = errors.New("only paid orders can be refunded")
+	ErrNoCapturedPayment  = errors.New("order has no captured payment to refund")
+)
+
+// RefundExceedsError reports a refund larger than what is left to refund on the order
+type RefundExceedsError struct {
+	Requested string
+	Available string
+}
+
+func (e *RefundExceedsError) Error() string {
+	return fmt.Sprintf("cannot refund %s: %s left to refund", e.Requested, e.Available)
+}
+
+// InvalidRefundItemError reports an order item that cannot be refunded in the requested quantity
+type InvalidRefundItemError struct {
+	OrderItemID uuid.UUID
+	Requested   int32
+	Refundable  int32
+}
+
+func (e *InvalidRefundItemError) Error() string {
+	return fmt.Sprintf("cannot refund %d of order item %s: %d refundable",
+		e.Requested, e.OrderItemID.String(), e.Refundable)
+}
+
+// UnknownCurrencyError reports an exchange rate for a currency that has not been added
+type UnknownCurrencyError struct {
+	Code string
+}
+
+func (e *UnknownCurrencyError) Error() string {
+	return fmt.Sprintf("unknown currency %s", e.Code)
+}
+
+var ErrInsufficientStoreCredit = errors.New("not enough store credit")
+
+// GiftCardUnavailableError reports a gift card code that cannot pay for an order
+type GiftCardUnavailableError struct {
+	Code string
+}
+
+func (e *GiftCardUnavailableError) Error() string {
+	return fmt.Sprintf("gift card %s cannot be used: it is unknown, inactive, expired or has no balance left", e.Code)
+}
+
+var (
+	ErrAlreadyReviewed = errors.New("you have already reviewed this product")
+	ErrAlreadyReported = errors.New("you have already reported this review")
+	ErrOwnReview       = errors.New("you cannot vote on or report your own review")
+)
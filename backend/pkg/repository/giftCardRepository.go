@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type GiftCardRepository interface {
+	// create
+	CreateGiftCard(ctx context.Context, params model.IssueGiftCardParams) (model.GiftCardDetail, error)
+
+	// update
+	SetGiftCardActive(ctx context.Context, giftCardId uuid.UUID, active bool) (model.GiftCard, error)
+
+	// get
+	GetGiftCards(ctx context.Context) ([]model.GiftCard, error)
+	GetGiftCard(ctx context.Context, giftCardId uuid.UUID) (model.GiftCardDetail, error)
+	GetGiftCardByCode(ctx context.Context, code string) (model.GiftCard, error)
+}
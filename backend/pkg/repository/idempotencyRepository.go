@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type IdempotencyRepository interface {
+	// create
+	ClaimIdempotencyKey(ctx context.Context, params model.ClaimIdempotencyKeyParams) (model.IdempotencyKey, bool, error)
+
+	// update
+	CompleteIdempotencyKey(ctx context.Context, userId uuid.UUID, key string, responseStatus int, responseBody string) error
+
+	// delete
+	DeleteIdempotencyKey(ctx context.Context, userId uuid.UUID, key string) error
+}
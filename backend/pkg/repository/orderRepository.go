@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type OrderRepository interface {
+	// update
+	TransitionOrderStatus(ctx context.Context, params model.OrderTransitionParams) (model.Order, error)
+	CancelOrder(ctx context.Context, params model.OrderCancelParams) (model.Order, error)
+
+	// get
+	GetUserOrder(ctx context.Context, orderId uuid.UUID, userId uuid.UUID) (model.Order, error)
+	GetOrderStatusHistory(ctx context.Context, orderId uuid.UUID) ([]model.OrderStatusChange, error)
+	GetOrderItems(ctx context.Context, orderId uuid.UUID) ([]model.OrderItemDetail, error)
+	GetUserOrders(ctx context.Context, userId uuid.UUID, filter model.OrderFilter, offset int32, limit int32) (interface{}, error)
+	GetUserOrderCount(ctx context.Context, userId uuid.UUID, filter model.OrderFilter) (int64, error)
+	GetOrderPromotion(ctx context.Context, orderId uuid.UUID) (*model.PromotionRedemption, error)
+}
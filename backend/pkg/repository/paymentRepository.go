@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type PaymentRepository interface {
+	// create
+	BeginPayment(ctx context.Context, params model.BeginPaymentParams) (model.Order, model.Payment, error)
+
+	// update
+	UpdatePayment(ctx context.Context, params model.UpdatePaymentParams) (model.Payment, error)
+	AuthorizePayment(ctx context.Context, attempt model.Payment, transactionId string) (model.Payment, error)
+	CapturePayment(ctx context.Context, attempt model.Payment) (model.Payment, error)
+	ExpirePayment(ctx context.Context, paymentId uuid.UUID, status string, message string) error
+	ApplyPaymentEvent(ctx context.Context, params model.PaymentEventParams) (string, error)
+
+	// get
+	GetOrderPayments(ctx context.Context, orderId uuid.UUID) ([]model.Payment, error)
+	GetStalePayments(ctx context.Context, orderId uuid.UUID, startedBefore time.Time) ([]model.Payment, error)
+}
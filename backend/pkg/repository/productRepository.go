@@ -19,12 +19,13 @@ type ProductRepository interface {
 	UpdateProduct(ctx context.Context, product model.UpdateProductParams) (model.Product, error)
 	UpdateProductColour(ctx context.Context, productId uuid.UUID, colourId uuid.UUID) (model.ProductColour, error)
 	UpdateProductMaterial(ctx context.Context, productId uuid.UUID, materialId uuid.UUID) (model.ProductMaterial, error)
+	UpdateProductSale(ctx context.Context, productId uuid.UUID, params model.UpdateProductSaleParams) error
 
 	// Delete Product
 	DeleteProduct(ctx context.Context, productID uuid.UUID) error
 
 	// Get Product methods
-	GetProducts(ctx context.Context, offset int32, limit int32) (interface{}, error)
+	GetProducts(ctx context.Context, offset int32, limit int32) ([]model.Product, error)
 
 	GetProductColours(ctx context.Context, productId uuid.UUID, offset int32, limit int32) ([]model.Colour, error)
 	GetAllMaterials(ctx context.Context, offset int32, limit int32) ([]model.Material, error)
@@ -35,15 +36,15 @@ type ProductRepository interface {
 	GetMaterialCount(ctx context.Context) (int64, error)
 	GetMaterialByName(ctx context.Context, materialName string) (model.Material, error)
 
-	GetAvailableProducts(ctx context.Context) ([]database.Product, error)
-	GetProductById(ctx context.Context, id uuid.UUID) (database.Product, error)
-	GetProductsByCategory(ctx context.Context, categoryID uuid.UUID) (interface{}, error)
+	GetAvailableProducts(ctx context.Context) ([]model.Product, error)
+	GetProductById(ctx context.Context, id uuid.UUID) (model.Product, error)
+	GetProductsByCategory(ctx context.Context, categoryID uuid.UUID) ([]model.GetProductsByCategoryRow, error)
 	GetProductCountByCategory(ctx context.Context, categoryID uuid.UUID) (int64, error)
 	GetProductCount(ctx context.Context) (int64, error)
 	GetTrendingProducts(ctx context.Context) ([]model.TrendingProduct, error)
 
 	// Search Products
-	SearchProducts(ctx context.Context, query sql.NullString) ([]database.Product, error)
+	SearchProducts(ctx context.Context, query sql.NullString) ([]model.Product, error)
 
 	// Additional methods ...
 	GetSalesTrends(ctx context.Context) ([]database.GetSalesTrendsRow, error)
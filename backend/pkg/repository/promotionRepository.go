@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type PromotionRepository interface {
+	// create
+	CreatePromotion(ctx context.Context, params model.CreatePromotionParams) (model.PromotionDetail, error)
+
+	// update
+	SetPromotionActive(ctx context.Context, promotionId uuid.UUID, active bool) error
+	SetCartPromotion(ctx context.Context, cartId uuid.UUID, promotionId uuid.UUID) error
+
+	// delete
+	RemoveCartPromotion(ctx context.Context, cartId uuid.UUID) error
+
+	// get
+	GetPromotions(ctx context.Context) ([]model.Promotion, error)
+	GetPromotion(ctx context.Context, promotionId uuid.UUID) (model.PromotionDetail, error)
+	GetPromotionByCode(ctx context.Context, code string) (model.PromotionDetail, error)
+	GetCartPromotion(ctx context.Context, cartId uuid.UUID) (model.PromotionDetail, error)
+	GetUserRedemptionCount(ctx context.Context, promotionId uuid.UUID, userId uuid.UUID) (int64, error)
+	GetPromotionReport(ctx context.Context, promotionId uuid.UUID) (model.PromotionReport, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type RecentlyViewedRepository interface {
+	// update
+	RecordProductView(ctx context.Context, userId uuid.UUID, productId uuid.UUID, retain int32) error
+
+	// get
+	GetRecentlyViewedProducts(ctx context.Context, userId uuid.UUID, limit int32) ([]model.Product, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type RecommendationRepository interface {
+	// update
+	RefreshCoPurchases(ctx context.Context, minOrders int32, perProduct int32) (int64, error)
+	RefreshWishlistAffinities(ctx context.Context, minUsers int32, perProduct int32) (int64, error)
+
+	// get
+	GetCoPurchasedProducts(ctx context.Context, productId uuid.UUID, limit int32) ([]model.Product, error)
+	GetSubCategoryBestsellers(ctx context.Context, subCategoryId uuid.UUID, excludeProductId uuid.UUID, limit int32) ([]model.Product, error)
+	GetAlsoSavedProducts(ctx context.Context, productId uuid.UUID, limit int32) ([]model.Product, error)
+	GetUserRecommendedProducts(ctx context.Context, userId uuid.UUID, limit int32) ([]model.Product, error)
+	GetPopularWishlistedProducts(ctx context.Context, userId uuid.UUID, limit int32) ([]model.Product, error)
+}
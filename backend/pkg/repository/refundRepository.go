@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type RefundRepository interface {
+	// create
+	CreateRefund(ctx context.Context, params model.CreateRefundParams) (model.RefundDetail, error)
+
+	// update
+	StartRefund(ctx context.Context, refundId uuid.UUID) (model.Refund, model.Payment, error)
+	FinishRefund(ctx context.Context, refundId uuid.UUID, succeeded bool, providerRefundId string, failureMessage string) (model.Refund, error)
+	CreditRefundToStoreCredit(ctx context.Context, refundId uuid.UUID) (model.Refund, error)
+
+	// get
+	GetRefund(ctx context.Context, refundId uuid.UUID) (model.Refund, error)
+	GetOrderRefunds(ctx context.Context, orderId uuid.UUID) ([]model.RefundDetail, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type ReturnRepository interface {
+	// create
+	CreateReturnRequest(ctx context.Context, params model.CreateReturnRequestParams) (model.ReturnRequestDetail, error)
+
+	// update
+	ReviewReturnRequest(ctx context.Context, returnId uuid.UUID, status string, adminNote string) (model.ReturnRequest, error)
+	ReceiveReturnRequest(ctx context.Context, returnId uuid.UUID, adminNote string) (model.ReturnRequestDetail, error)
+
+	// get
+	GetReturnRequest(ctx context.Context, returnId uuid.UUID) (model.ReturnRequestDetail, error)
+	GetOrderReturnRequests(ctx context.Context, orderId uuid.UUID) ([]model.ReturnRequestDetail, error)
+	GetReturnRequestsByStatus(ctx context.Context, status string, offset int32, limit int32) (interface{}, error)
+	GetReturnRequestCountByStatus(ctx context.Context, status string) (int64, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type ReviewRepository interface {
+	// create
+	CreateReview(ctx context.Context, params model.SaveReviewParams) (model.ProductReview, error)
+	ReportReview(ctx context.Context, params model.ReportReviewParams, threshold int64) error
+
+	// update
+	UpdateReview(ctx context.Context, reviewId uuid.UUID, params model.SaveReviewParams) (model.ProductReview, error)
+	ModerateReview(ctx context.Context, params model.ModerateReviewParams) (model.ProductReview, error)
+	VoteReview(ctx context.Context, params model.ReviewVoteParams) error
+
+	// delete
+	DeleteReview(ctx context.Context, userId uuid.UUID, reviewId uuid.UUID) error
+	DeleteReviewVote(ctx context.Context, userId uuid.UUID, reviewId uuid.UUID) error
+
+	// get
+	GetProductReviews(ctx context.Context, productId uuid.UUID, sort string, offset int32, limit int32) (interface{}, error)
+	GetProductReviewCount(ctx context.Context, productId uuid.UUID) (int64, error)
+	GetModerationReviews(ctx context.Context, status string, flaggedOnly bool, offset int32, limit int32) (interface{}, error)
+	GetModerationReviewCount(ctx context.Context, status string, flaggedOnly bool) (int64, error)
+}
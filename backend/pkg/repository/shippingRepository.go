@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type ShippingRepository interface {
+	// create
+	CreateShippingZone(ctx context.Context, params model.CreateShippingZoneParams) (model.ShippingZone, error)
+	CreateShippingRate(ctx context.Context, params model.CreateShippingRateParams) (model.ShippingRate, error)
+
+	// update
+	UpdateProductShipping(ctx context.Context, productId uuid.UUID, params model.UpdateProductShippingParams) error
+
+	// delete
+	DeleteShippingZone(ctx context.Context, zoneId uuid.UUID) error
+	DeleteShippingRate(ctx context.Context, rateId uuid.UUID) error
+
+	// get
+	GetShippingZones(ctx context.Context) ([]model.ShippingZoneDetail, error)
+	HasShippingZones(ctx context.Context) (bool, error)
+	GetUserShippingDestination(ctx context.Context, userId uuid.UUID, addressId uuid.NullUUID) (model.ShippingDestination, error)
+	MatchShippingZone(ctx context.Context, destination model.ShippingDestination) (model.ShippingZone, error)
+	GetShippingRatesForWeight(ctx context.Context, zoneId uuid.UUID, weightGrams int32) ([]model.ShippingRate, error)
+}
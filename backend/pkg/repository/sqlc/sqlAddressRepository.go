@@ -0,0 +1,311 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type SQLAddressRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLAddressRepository(conn *sql.DB, db *database.Queries) *SQLAddressRepository {
+	return &SQLAddressRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// CreateAddress adds an address to a user's address book. A user's first address of a type
+// becomes their default even when not asked to; asking for a later one to be the default
+// takes the flag off the old default.
+func (r *SQLAddressRepository) CreateAddress(ctx context.Context, params model.SaveAddressParams) (model.Address, error) {
+	var address model.Address
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		// lock the address book, so two new defaults cannot be added at once
+		if _, err := q.LockAddressBook(ctx, params.UserID); err != nil {
+			return err
+		}
+
+		_, err := getDefaultAddress(ctx, q, params.UserID, params.Type)
+		if errors.Is(err, sql.ErrNoRows) {
+			params.IsDefault = true
+		} else if err != nil {
+			return err
+		} else if params.IsDefault {
+			if err := clearDefaultAddress(ctx, q, params.UserID, params.Type); err != nil {
+				return err
+			}
+		}
+
+		address, err = createAddress(ctx, q, params)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error creating %s address for user with id %s: %s", params.Type, params.UserID.String(), err.Error())
+		return model.Address{}, err
+	}
+
+	return address, nil
+}
+
+// UpdateAddress changes an address in a user's address book. Orders placed with it keep the
+// address as it was. sql.ErrNoRows is returned if the user has no such address.
+func (r *SQLAddressRepository) UpdateAddress(ctx context.Context, addressId uuid.UUID, params model.SaveAddressParams) (model.Address, error) {
+	switch params.Type {
+	case model.AddressTypeShipping:
+		address, err := r.DB.UpdateShippingAddress(ctx, database.UpdateShippingAddressParams{
+			ID:         addressId,
+			UserID:     params.UserID,
+			Street:     params.Street,
+			City:       params.City,
+			State:      params.State,
+			Country:    params.Country,
+			PostalCode: params.PostalCode,
+		})
+		return toModelShippingAddress(address), err
+	case model.AddressTypeBilling:
+		address, err := r.DB.UpdateBillingAddress(ctx, database.UpdateBillingAddressParams{
+			ID:         addressId,
+			UserID:     params.UserID,
+			Street:     params.Street,
+			City:       params.City,
+			State:      params.State,
+			Country:    params.Country,
+			PostalCode: params.PostalCode,
+		})
+		return toModelBillingAddress(address), err
+	default:
+		return model.Address{}, unknownAddressType(params.Type)
+	}
+}
+
+// SetDefaultAddress makes an address the user's default of its type. sql.ErrNoRows is returned if
+// the user has no such address.
+func (r *SQLAddressRepository) SetDefaultAddress(ctx context.Context, userId uuid.UUID, addressType string, addressId uuid.UUID) (model.Address, error) {
+	var address model.Address
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		if _, err := q.LockAddressBook(ctx, userId); err != nil {
+			return err
+		}
+		if _, err := getAddress(ctx, q, userId, addressType, addressId); err != nil {
+			return err
+		}
+
+		if err := clearDefaultAddress(ctx, q, userId, addressType); err != nil {
+			return err
+		}
+
+		var err error
+		switch addressType {
+		case model.AddressTypeShipping:
+			var shipping database.ShippingAddress
+			shipping, err = q.SetDefaultShippingAddress(ctx, database.SetDefaultShippingAddressParams{
+				ID:     addressId,
+				UserID: userId,
+			})
+			address = toModelShippingAddress(shipping)
+		case model.AddressTypeBilling:
+			var billing database.BillingAddress
+			billing, err = q.SetDefaultBillingAddress(ctx, database.SetDefaultBillingAddressParams{
+				ID:     addressId,
+				UserID: userId,
+			})
+			address = toModelBillingAddress(billing)
+		}
+		return err
+	})
+	if err != nil {
+		return model.Address{}, err
+	}
+
+	return address, nil
+}
+
+// DeleteAddress removes an address from a user's address book. Deleting the default makes the
+// oldest remaining address of its type the default. sql.ErrNoRows is returned if the user has no
+// such address.
+func (r *SQLAddressRepository) DeleteAddress(ctx context.Context, userId uuid.UUID, addressType string, addressId uuid.UUID) error {
+	return execTx(ctx, r.Conn, func(q *database.Queries) error {
+		if _, err := q.LockAddressBook(ctx, userId); err != nil {
+			return err
+		}
+
+		switch addressType {
+		case model.AddressTypeShipping:
+			address, err := q.DeleteShippingAddress(ctx, database.DeleteShippingAddressParams{
+				ID:     addressId,
+				UserID: userId,
+			})
+			if err != nil || !address.IsDefault {
+				return err
+			}
+			return q.PromoteOldestShippingAddress(ctx, userId)
+		case model.AddressTypeBilling:
+			address, err := q.DeleteBillingAddress(ctx, database.DeleteBillingAddressParams{
+				ID:     addressId,
+				UserID: userId,
+			})
+			if err != nil || !address.IsDefault {
+				return err
+			}
+			return q.PromoteOldestBillingAddress(ctx, userId)
+		default:
+			return unknownAddressType(addressType)
+		}
+	})
+}
+
+// GetAddresses gets a user's address book
+func (r *SQLAddressRepository) GetAddresses(ctx context.Context, userId uuid.UUID) (model.AddressBook, error) {
+	shipping, err := r.DB.ListShippingAddresses(ctx, userId)
+	if err != nil {
+		return model.AddressBook{}, err
+	}
+	billing, err := r.DB.ListBillingAddresses(ctx, userId)
+	if err != nil {
+		return model.AddressBook{}, err
+	}
+
+	book := model.AddressBook{
+		Shipping: make([]model.Address, len(shipping)),
+		Billing:  make([]model.Address, len(billing)),
+	}
+	for i, address := range shipping {
+		book.Shipping[i] = toModelShippingAddress(address)
+	}
+	for i, address := range billing {
+		book.Billing[i] = toModelBillingAddress(address)
+	}
+	return book, nil
+}
+
+// GetAddress gets one of a user's addresses. sql.ErrNoRows is returned if the user has no such
+// address.
+func (r *SQLAddressRepository) GetAddress(ctx context.Context, userId uuid.UUID, addressType string, addressId uuid.UUID) (model.Address, error) {
+	return getAddress(ctx, r.DB, userId, addressType, addressId)
+}
+
+// GetDefaultAddress gets a user's default address of a type. sql.ErrNoRows is returned if they
+// have no address of that type.
+func (r *SQLAddressRepository) GetDefaultAddress(ctx context.Context, userId uuid.UUID, addressType string) (model.Address, error) {
+	return getDefaultAddress(ctx, r.DB, userId, addressType)
+}
+
+func createAddress(ctx context.Context, q *database.Queries, params model.SaveAddressParams) (model.Address, error) {
+	switch params.Type {
+	case model.AddressTypeShipping:
+		address, err := q.CreateShippingAddress(ctx, database.CreateShippingAddressParams{
+			ID:         uuid.New(),
+			UserID:     params.UserID,
+			Street:     params.Street,
+			City:       params.City,
+			State:      params.State,
+			Country:    params.Country,
+			PostalCode: params.PostalCode,
+			IsDefault:  params.IsDefault,
+		})
+		return toModelShippingAddress(address), err
+	case model.AddressTypeBilling:
+		address, err := q.CreateBillingAddress(ctx, database.CreateBillingAddressParams{
+			ID:         uuid.New(),
+			UserID:     params.UserID,
+			Street:     params.Street,
+			City:       params.City,
+			State:      params.State,
+			Country:    params.Country,
+			PostalCode: params.PostalCode,
+			IsDefault:  params.IsDefault,
+		})
+		return toModelBillingAddress(address), err
+	default:
+		return model.Address{}, unknownAddressType(params.Type)
+	}
+}
+
+func getAddress(ctx context.Context, q *database.Queries, userId uuid.UUID, addressType string, addressId uuid.UUID) (model.Address, error) {
+	switch addressType {
+	case model.AddressTypeShipping:
+		address, err := q.GetShippingAddress(ctx, database.GetShippingAddressParams{
+			ID:     addressId,
+			UserID: userId,
+		})
+		return toModelShippingAddress(address), err
+	case model.AddressTypeBilling:
+		address, err := q.GetBillingAddress(ctx, database.GetBillingAddressParams{
+			ID:     addressId,
+			UserID: userId,
+		})
+		return toModelBillingAddress(address), err
+	default:
+		return model.Address{}, unknownAddressType(addressType)
+	}
+}
+
+func getDefaultAddress(ctx context.Context, q *database.Queries, userId uuid.UUID, addressType string) (model.Address, error) {
+	switch addressType {
+	case model.AddressTypeShipping:
+		address, err := q.GetDefaultShippingAddress(ctx, userId)
+		return toModelShippingAddress(address), err
+	case model.AddressTypeBilling:
+		address, err := q.GetDefaultBillingAddress(ctx, userId)
+		return toModelBillingAddress(address), err
+	default:
+		return model.Address{}, unknownAddressType(addressType)
+	}
+}
+
+func clearDefaultAddress(ctx context.Context, q *database.Queries, userId uuid.UUID, addressType string) error {
+	switch addressType {
+	case model.AddressTypeShipping:
+		return q.ClearDefaultShippingAddress(ctx, userId)
+	case model.AddressTypeBilling:
+		return q.ClearDefaultBillingAddress(ctx, userId)
+	default:
+		return unknownAddressType(addressType)
+	}
+}
+
+func unknownAddressType(addressType string) error {
+	return fmt.Errorf("unknown address type %q", addressType)
+}
+
+func toModelShippingAddress(address database.ShippingAddress) model.Address {
+	return model.Address{
+		ID:          address.ID,
+		UserID:      address.UserID,
+		Type:        model.AddressTypeShipping,
+		Street:      address.Street,
+		City:        address.City,
+		State:       address.State,
+		Country:     address.Country,
+		PostalCode:  address.PostalCode,
+		IsDefault:   address.IsDefault,
+		CreatedAt:   address.CreatedAt,
+		LastUpdated: address.LastUpdated,
+	}
+}
+
+func toModelBillingAddress(address database.BillingAddress) model.Address {
+	return model.Address{
+		ID:          address.ID,
+		UserID:      address.UserID,
+		Type:        model.AddressTypeBilling,
+		Street:      address.Street,
+		City:        address.City,
+		State:       address.State,
+		Country:     address.Country,
+		PostalCode:  address.PostalCode,
+		IsDefault:   address.IsDefault,
+		CreatedAt:   address.CreatedAt,
+		LastUpdated: address.LastUpdated,
+	}
+}
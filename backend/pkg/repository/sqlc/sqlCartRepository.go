@@ -0,0 +1,216 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/pricing"
+	"github.com/google/uuid"
+)
+
+type SQLCartRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLCartRepository(conn *sql.DB, db *database.Queries) *SQLCartRepository {
+	return &SQLCartRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// GetOrCreateCart returns the user's shopping cart, creating an empty one if none exists
+func (r *SQLCartRepository) GetOrCreateCart(ctx context.Context, userId uuid.UUID) (model.ShoppingCart, error) {
+	cart, err := r.DB.GetOrCreateShoppingCart(ctx, database.GetOrCreateShoppingCartParams{
+		ID:     uuid.New(),
+		UserID: userId,
+	})
+	if err != nil {
+		log.Printf("Error fetching shopping cart for user with id %s: %s", userId.String(), err.Error())
+		return model.ShoppingCart{}, err
+	}
+
+	return toModelShoppingCart(cart)
+}
+
+// SetCartItemQuantity sets the quantity of a product in the cart and refreshes the cart totals
+func (r *SQLCartRepository) SetCartItemQuantity(ctx context.Context, cartId uuid.UUID, productId uuid.UUID, quantity int32) (model.ShoppingCart, error) {
+	var cart database.ShoppingCart
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		// insert or update the cart item
+		_, err := q.UpsertCartItem(ctx, database.UpsertCartItemParams{
+			ID:             uuid.New(),
+			ShoppingCartID: cartId,
+			ProductID:      productId,
+			Quantity:       quantity,
+		})
+		if err != nil {
+			return err
+		}
+
+		// keep the cart totals in step with its items
+		cart, err = refreshCartTotals(ctx, q, cartId)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error setting quantity of product %s in cart %s: %s", productId.String(), cartId.String(), err.Error())
+		return model.ShoppingCart{}, err
+	}
+
+	return toModelShoppingCart(cart)
+}
+
+// RemoveCartItem removes a product from the cart and refreshes the cart totals
+func (r *SQLCartRepository) RemoveCartItem(ctx context.Context, cartId uuid.UUID, productId uuid.UUID) (model.ShoppingCart, error) {
+	var cart database.ShoppingCart
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		// delete the cart item
+		deleted, err := q.DeleteCartItem(ctx, database.DeleteCartItemParams{
+			ShoppingCartID: cartId,
+			ProductID:      productId,
+		})
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return sql.ErrNoRows
+		}
+
+		// keep the cart totals in step with its items
+		cart, err = refreshCartTotals(ctx, q, cartId)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error removing product %s from cart %s: %s", productId.String(), cartId.String(), err.Error())
+		return model.ShoppingCart{}, err
+	}
+
+	return toModelShoppingCart(cart)
+}
+
+// GetCartItem returns a single item in the cart
+func (r *SQLCartRepository) GetCartItem(ctx context.Context, cartId uuid.UUID, productId uuid.UUID) (model.CartItem, error) {
+	item, err := r.DB.GetCartItem(ctx, database.GetCartItemParams{
+		ShoppingCartID: cartId,
+		ProductID:      productId,
+	})
+	if err != nil {
+		return model.CartItem{}, err
+	}
+
+	return model.CartItem{
+		ID:             item.ID,
+		ShoppingCartID: item.ShoppingCartID,
+		ProductID:      item.ProductID,
+		Quantity:       item.Quantity,
+		CreatedAt:      item.CreatedAt,
+		LastUpdated:    item.LastUpdated,
+	}, nil
+}
+
+// ListCartItems returns all items in the cart together with their product details
+func (r *SQLCartRepository) ListCartItems(ctx context.Context, cartId uuid.UUID) ([]model.CartItemDetail, error) {
+	items, err := r.DB.ListCartItems(ctx, cartId)
+	if err != nil {
+		log.Printf("Error fetching items in cart %s: %s", cartId.String(), err.Error())
+		return nil, err
+	}
+
+	// Return cart items
+	now := time.Now()
+	cartItems := make([]model.CartItemDetail, len(items))
+	for i, item := range items {
+		price, err := money.FromDecimal(item.Price, money.DefaultCurrency)
+		if err != nil {
+			return nil, err
+		}
+		unitPrice := pricing.Calculate(price, item.DiscountRate, item.SaleStartsAt, item.SaleEndsAt, now).SalePrice
+		cartItems[i] = model.CartItemDetail{
+			CartItem: model.CartItem{
+				ID:             item.ID,
+				ShoppingCartID: item.ShoppingCartID,
+				ProductID:      item.ProductID,
+				Quantity:       item.Quantity,
+				CreatedAt:      item.CreatedAt,
+				LastUpdated:    item.LastUpdated,
+			},
+			ProductName:   item.ProductName,
+			ImageUrl:      item.ImageUrl,
+			Price:         price,
+			DiscountRate:  item.DiscountRate,
+			UnitPrice:     unitPrice,
+			Stock:         item.Stock,
+			IsActive:      item.IsActive,
+			TaxClass:      item.TaxClass,
+			WeightGrams:   item.WeightGrams,
+			LengthCm:      item.LengthCm,
+			WidthCm:       item.WidthCm,
+			HeightCm:      item.HeightCm,
+			SubCategoryID: item.SubCategoryID,
+			CategoryID:    item.CategoryID,
+			SaleStartsAt:  item.SaleStartsAt,
+			SaleEndsAt:    item.SaleEndsAt,
+		}
+	}
+
+	return cartItems, nil
+}
+
+// refreshCartTotals keeps a cart's totals in step with its items, priced as they sell for now
+func refreshCartTotals(ctx context.Context, q *database.Queries, cartId uuid.UUID) (database.ShoppingCart, error) {
+	items, err := q.ListCartItems(ctx, cartId)
+	if err != nil {
+		return database.ShoppingCart{}, err
+	}
+
+	now := time.Now()
+	var totalItems int32
+	totalPrice := money.Zero(money.DefaultCurrency)
+	for _, item := range items {
+		unitPrice, err := productUnitPrice(item.Price, item.DiscountRate, item.SaleStartsAt, item.SaleEndsAt, now)
+		if err != nil {
+			return database.ShoppingCart{}, err
+		}
+		totalItems += item.Quantity
+		totalPrice = totalPrice.Add(unitPrice.Mul(int64(item.Quantity)))
+	}
+
+	return q.UpdateShoppingCartTotals(ctx, database.UpdateShoppingCartTotalsParams{
+		ID:         cartId,
+		TotalItems: totalItems,
+		TotalPrice: totalPrice.String(),
+	})
+}
+
+// productUnitPrice works out what a product with the stored price, discount rate and sale window
+// sells for at the given time
+func productUnitPrice(price string, discountRate string, saleStartsAt sql.NullTime, saleEndsAt sql.NullTime, now time.Time) (money.Money, error) {
+	listPrice, err := money.FromDecimal(price, money.DefaultCurrency)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return pricing.Calculate(listPrice, discountRate, saleStartsAt, saleEndsAt, now).SalePrice, nil
+}
+
+func toModelShoppingCart(cart database.ShoppingCart) (model.ShoppingCart, error) {
+	totalPrice, err := money.FromDecimal(cart.TotalPrice, money.DefaultCurrency)
+	if err != nil {
+		return model.ShoppingCart{}, err
+	}
+
+	return model.ShoppingCart{
+		ID:          cart.ID,
+		UserID:      cart.UserID,
+		CreatedAt:   cart.CreatedAt,
+		LastUpdated: cart.LastUpdated,
+		TotalItems:  cart.TotalItems,
+		TotalPrice:  totalPrice,
+	}, nil
+}
@@ -0,0 +1,340 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+type SQLCheckoutRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLCheckoutRepository(conn *sql.DB, db *database.Queries) *SQLCheckoutRepository {
+	return &SQLCheckoutRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// PlaceOrder turns the cart into an order in a single transaction. The product rows are
+// locked while their stock is checked and decremented, so concurrent checkouts cannot oversell.
+// Gift cards and store credit are locked the same way before any of their balance is spent.
+func (r *SQLCheckoutRepository) PlaceOrder(ctx context.Context, params model.PlaceOrderParams) (model.CheckoutResult, error) {
+	var result model.CheckoutResult
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		// lock the cart so the same cart cannot be checked out twice
+		if _, err := q.LockShoppingCart(ctx, params.CartID); err != nil {
+			return err
+		}
+
+		cartItems, err := q.ListCartItems(ctx, params.CartID)
+		if err != nil {
+			return err
+		}
+		if len(cartItems) == 0 {
+			return repository.ErrEmptyCart
+		}
+
+		// lock the product rows in a consistent order to avoid deadlocks
+		productIds := make([]uuid.UUID, len(cartItems))
+		for i, item := range cartItems {
+			productIds[i] = item.ProductID
+		}
+		lockedProducts, err := q.LockProductsForCheckout(ctx, productIds)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		products := make(map[uuid.UUID]database.LockProductsForCheckoutRow, len(lockedProducts))
+		unitPrices := make(map[uuid.UUID]money.Money, len(lockedProducts))
+		for _, product := range lockedProducts {
+			products[product.ID] = product
+			price, err := productUnitPrice(product.Price, product.DiscountRate, product.SaleStartsAt, product.SaleEndsAt, now)
+			if err != nil {
+				return err
+			}
+			unitPrices[product.ID] = price
+		}
+
+		// the order is placed at the prices its tax was worked out on
+		taxes := make(map[uuid.UUID]model.LineTax, len(params.Taxes))
+		for _, lineTax := range params.Taxes {
+			taxes[lineTax.ProductID] = lineTax
+		}
+		if len(taxes) != len(cartItems) {
+			return repository.ErrCartChanged
+		}
+		for _, item := range cartItems {
+			product := products[item.ProductID]
+			lineTax, ok := taxes[item.ProductID]
+			if !ok || lineTax.Quantity != item.Quantity || lineTax.UnitPrice.Cmp(unitPrices[item.ProductID]) != 0 ||
+				lineTax.TaxClass != product.TaxClass {
+				return repository.ErrCartChanged
+			}
+		}
+
+		// check stock for every item before writing anything
+		for _, item := range cartItems {
+			product := products[item.ProductID]
+			available := product.Stock
+			if !product.IsActive {
+				available = 0
+			}
+			if item.Quantity > available {
+				return &repository.InsufficientStockError{
+					ProductID:   item.ProductID,
+					ProductName: item.ProductName,
+					Requested:   item.Quantity,
+					Available:   available,
+				}
+			}
+		}
+
+		// check the promotion can still be used now that it is locked
+		if params.Promotion != nil {
+			if err := checkPromotionRedeemable(ctx, q, params.Promotion.PromotionID, params.UserID); err != nil {
+				return err
+			}
+		}
+
+		// create the order
+		order, err := q.CreateOrder(ctx, database.CreateOrderParams{
+			ID:              uuid.New(),
+			UserID:          params.UserID,
+			PaymentMethod:   params.PaymentMethod,
+			ShippingAddress: params.ShippingAddress,
+			BillingAddress:  params.BillingAddress,
+			ShippingMethod:  params.ShippingMethod,
+			ShippingCost:    params.ShippingCost.String(),
+			Currency:        params.Currency.Currency(),
+			ExchangeRate:    params.Currency.Rate(),
+		})
+		if err != nil {
+			return err
+		}
+
+		// start the order's status history
+		_, err = q.CreateOrderStatusHistory(ctx, database.CreateOrderStatusHistoryParams{
+			ID:       uuid.New(),
+			OrderID:  order.ID,
+			ToStatus: order.OrderStatus,
+			ActorID:  uuid.NullUUID{UUID: params.UserID, Valid: true},
+			Reason:   sql.NullString{String: "order placed", Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		// create the order items at the current price and take them out of stock
+		orderItems := make([]model.OrderItem, len(cartItems))
+		for i, item := range cartItems {
+			lineTax := taxes[item.ProductID]
+			orderItem, err := q.CreateOrderItem(ctx, database.CreateOrderItemParams{
+				ID:             uuid.New(),
+				OrderID:        order.ID,
+				ProductID:      item.ProductID,
+				Quantity:       item.Quantity,
+				UnitPrice:      unitPrices[item.ProductID].String(),
+				TaxedPrice:     lineTax.TaxedPrice.String(),
+				TaxRate:        lineTax.Rate,
+				TaxAmount:      lineTax.TaxAmount.String(),
+				DiscountAmount: lineTax.Discount.String(),
+			})
+			if err != nil {
+				return err
+			}
+			orderItems[i] = toModelOrderItem(orderItem)
+
+			err = q.DecrementProductStock(ctx, database.DecrementProductStockParams{
+				ID:    item.ProductID,
+				Stock: item.Quantity,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		order, err = q.UpdateOrderTotal(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		// pay what the gift cards and store credit cover, leaving the rest to the payment method
+		total, err := money.FromDecimal(order.TotalPrice, money.DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		giftCards, due, err := redeemGiftCards(ctx, q, order.ID, params.GiftCardCodes, total)
+		if err != nil {
+			return err
+		}
+		giftCardAmount := total.Sub(due)
+		var storeCredit *model.StoreCreditTransaction
+		if params.UseStoreCredit {
+			storeCredit, due, err = redeemStoreCredit(ctx, q, params.UserID, order.ID, due)
+			if err != nil {
+				return err
+			}
+		}
+		if due.Cmp(total) != 0 {
+			order, err = q.UpdateOrderTenders(ctx, database.UpdateOrderTendersParams{
+				ID:                order.ID,
+				GiftCardAmount:    giftCardAmount.String(),
+				StoreCreditAmount: total.Sub(giftCardAmount).Sub(due).String(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// settle the rest in the currency it was shown in, at the rate it was shown at
+		order, err = q.UpdateOrderSettlementTotal(ctx, database.UpdateOrderSettlementTotalParams{
+			ID:              order.ID,
+			SettlementTotal: sql.NullString{String: params.Currency.Convert(due).String(), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		// nothing is left for the payment method to take
+		if due.IsZero() {
+			order, err = q.UpdateOrderPaymentStatus(ctx, database.UpdateOrderPaymentStatusParams{
+				ID:            order.ID,
+				PaymentStatus: "paid",
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// record the promotion against the order
+		var redemption *model.PromotionRedemption
+		if params.Promotion != nil {
+			if err := q.IncrementPromotionUses(ctx, params.Promotion.PromotionID); err != nil {
+				return err
+			}
+			created, err := q.CreatePromotionRedemption(ctx, database.CreatePromotionRedemptionParams{
+				ID:             uuid.New(),
+				PromotionID:    params.Promotion.PromotionID,
+				OrderID:        order.ID,
+				UserID:         params.UserID,
+				Code:           params.Promotion.Code,
+				DiscountAmount: params.Promotion.DiscountAmount.String(),
+			})
+			if err != nil {
+				return err
+			}
+			modelRedemption := toModelPromotionRedemption(created)
+			redemption = &modelRedemption
+
+			if _, err := q.DeleteCartPromotion(ctx, params.CartID); err != nil {
+				return err
+			}
+		}
+
+		// empty the cart
+		if err := q.ClearCartItems(ctx, params.CartID); err != nil {
+			return err
+		}
+		if _, err := refreshCartTotals(ctx, q, params.CartID); err != nil {
+			return err
+		}
+
+		result = model.CheckoutResult{
+			Order:       toModelOrder(order),
+			Items:       orderItems,
+			Promotion:   redemption,
+			GiftCards:   giftCards,
+			StoreCredit: storeCredit,
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error placing order for user with id %s: %s", params.UserID.String(), err.Error())
+		return model.CheckoutResult{}, err
+	}
+
+	return result, nil
+}
+
+// checkPromotionRedeemable locks a promotion and checks it is live and has uses left, overall and
+// for the user
+func checkPromotionRedeemable(ctx context.Context, q *database.Queries, promotionId uuid.UUID, userId uuid.UUID) error {
+	promotion, err := q.LockPromotion(ctx, promotionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrPromotionUnavailable
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !promotion.IsActive ||
+		(promotion.StartsAt.Valid && now.Before(promotion.StartsAt.Time)) ||
+		(promotion.EndsAt.Valid && !now.Before(promotion.EndsAt.Time)) ||
+		(promotion.MaxUses.Valid && promotion.TimesUsed >= promotion.MaxUses.Int32) {
+		return repository.ErrPromotionUnavailable
+	}
+
+	if promotion.MaxUsesPerUser.Valid {
+		used, err := q.CountUserPromotionRedemptions(ctx, database.CountUserPromotionRedemptionsParams{
+			PromotionID: promotionId,
+			UserID:      userId,
+		})
+		if err != nil {
+			return err
+		}
+		if used >= int64(promotion.MaxUsesPerUser.Int32) {
+			return repository.ErrPromotionUnavailable
+		}
+	}
+	return nil
+}
+
+func toModelOrder(order database.Order) model.Order {
+	return model.Order{
+		ID:                order.ID,
+		UserID:            order.UserID,
+		OrderStatus:       order.OrderStatus,
+		PaymentStatus:     order.PaymentStatus,
+		PaymentMethod:     order.PaymentMethod,
+		ShippingAddress:   order.ShippingAddress,
+		BillingAddress:    order.BillingAddress,
+		TotalPrice:        order.TotalPrice,
+		CreatedAt:         order.CreatedAt,
+		LastUpdated:       order.LastUpdated,
+		ShippingMethod:    order.ShippingMethod,
+		ShippingCost:      order.ShippingCost,
+		Currency:          order.Currency,
+		ExchangeRate:      order.ExchangeRate,
+		SettlementTotal:   order.SettlementTotal,
+		GiftCardAmount:    order.GiftCardAmount,
+		StoreCreditAmount: order.StoreCreditAmount,
+	}
+}
+
+func toModelOrderItem(item database.OrderItem) model.OrderItem {
+	return model.OrderItem{
+		ID:             item.ID,
+		OrderID:        item.OrderID,
+		ProductID:      item.ProductID,
+		Quantity:       item.Quantity,
+		UnitPrice:      item.UnitPrice,
+		TaxedPrice:     item.TaxedPrice,
+		TaxRate:        item.TaxRate,
+		TaxAmount:      item.TaxAmount,
+		DiscountAmount: item.DiscountAmount,
+		CreatedAt:      item.CreatedAt,
+		LastUpdated:    item.LastUpdated,
+	}
+}
@@ -0,0 +1,122 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+)
+
+type SQLCurrencyRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLCurrencyRepository(conn *sql.DB, db *database.Queries) *SQLCurrencyRepository {
+	return &SQLCurrencyRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// SaveCurrency adds a currency or updates the one with the same code
+func (r *SQLCurrencyRepository) SaveCurrency(ctx context.Context, params model.SaveCurrencyParams) (model.Currency, error) {
+	currency, err := r.DB.UpsertCurrency(ctx, database.UpsertCurrencyParams{
+		Code:              params.Code,
+		Name:              params.Name,
+		Symbol:            params.Symbol,
+		RoundingIncrement: params.RoundingIncrement,
+		RoundingMode:      params.RoundingMode,
+		IsActive:          params.IsActive,
+	})
+	if err != nil {
+		log.Printf("Error saving currency %s: %s", params.Code, err.Error())
+		return model.Currency{}, err
+	}
+
+	return r.GetCurrency(ctx, currency.Code)
+}
+
+// SetExchangeRates stores a batch of exchange rates in a single transaction, so an import either
+// applies in full or not at all. An UnknownCurrencyError is returned for a rate whose currency has
+// not been added.
+func (r *SQLCurrencyRepository) SetExchangeRates(ctx context.Context, rates []model.SetExchangeRateParams) ([]model.ExchangeRate, error) {
+	saved := make([]model.ExchangeRate, len(rates))
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		for i, rate := range rates {
+			exchangeRate, err := q.UpsertExchangeRate(ctx, database.UpsertExchangeRateParams{
+				Code:   rate.CurrencyCode,
+				Rate:   rate.Rate,
+				Source: rate.Source,
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				return &repository.UnknownCurrencyError{Code: rate.CurrencyCode}
+			}
+			if err != nil {
+				return err
+			}
+			saved[i] = toModelExchangeRate(exchangeRate)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error setting %d exchange rates: %s", len(rates), err.Error())
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// GetCurrencies lists currencies with their exchange rates, optionally only the active ones
+func (r *SQLCurrencyRepository) GetCurrencies(ctx context.Context, activeOnly bool) ([]model.Currency, error) {
+	currencies, err := r.DB.ListCurrencies(ctx, activeOnly)
+	if err != nil {
+		log.Printf("Error getting currencies: %s", err.Error())
+		return nil, err
+	}
+
+	result := make([]model.Currency, len(currencies))
+	for i, currency := range currencies {
+		result[i] = toModelCurrency(database.GetCurrencyRow(currency))
+	}
+	return result, nil
+}
+
+// GetCurrency gets a currency and its exchange rate
+func (r *SQLCurrencyRepository) GetCurrency(ctx context.Context, code string) (model.Currency, error) {
+	currency, err := r.DB.GetCurrency(ctx, code)
+	if err != nil {
+		return model.Currency{}, err
+	}
+
+	return toModelCurrency(currency), nil
+}
+
+func toModelCurrency(currency database.GetCurrencyRow) model.Currency {
+	return model.Currency{
+		Code:              currency.Code,
+		Name:              currency.Name,
+		Symbol:            currency.Symbol,
+		RoundingIncrement: currency.RoundingIncrement,
+		RoundingMode:      currency.RoundingMode,
+		IsActive:          currency.IsActive,
+		CreatedAt:         currency.CreatedAt,
+		LastUpdated:       currency.LastUpdated,
+		Rate:              currency.Rate,
+		RateSource:        currency.Source,
+		RateUpdatedAt:     currency.RateUpdatedAt,
+	}
+}
+
+func toModelExchangeRate(rate database.ExchangeRate) model.ExchangeRate {
+	return model.ExchangeRate{
+		CurrencyCode: rate.CurrencyCode,
+		Rate:         rate.Rate,
+		Source:       rate.Source,
+		UpdatedAt:    rate.UpdatedAt,
+	}
+}
@@ -0,0 +1,251 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+type SQLGiftCardRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLGiftCardRepository(conn *sql.DB, db *database.Queries) *SQLGiftCardRepository {
+	return &SQLGiftCardRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// CreateGiftCard issues a gift card and opens its ledger with the amount it was issued for.
+// sql.ErrNoRows is returned if the code is already taken.
+func (r *SQLGiftCardRepository) CreateGiftCard(ctx context.Context, params model.IssueGiftCardParams) (model.GiftCardDetail, error) {
+	var detail model.GiftCardDetail
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		giftCard, err := q.CreateGiftCard(ctx, database.CreateGiftCardParams{
+			ID:             uuid.New(),
+			Code:           params.Code,
+			InitialBalance: params.Amount.String(),
+			ExpiresAt:      params.ExpiresAt,
+			CreatedBy:      uuid.NullUUID{UUID: params.CreatedBy, Valid: params.CreatedBy != uuid.Nil},
+		})
+		if err != nil {
+			return err
+		}
+
+		transaction, err := q.CreateGiftCardTransaction(ctx, database.CreateGiftCardTransactionParams{
+			ID:           uuid.New(),
+			GiftCardID:   giftCard.ID,
+			Amount:       giftCard.InitialBalance,
+			BalanceAfter: giftCard.Balance,
+			Reason:       "issue",
+		})
+		if err != nil {
+			return err
+		}
+
+		detail = model.GiftCardDetail{
+			GiftCard:     toModelGiftCard(giftCard),
+			Transactions: []model.GiftCardTransaction{toModelGiftCardTransaction(transaction)},
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error creating gift card: %s", err.Error())
+		return model.GiftCardDetail{}, err
+	}
+
+	return detail, nil
+}
+
+// SetGiftCardActive switches a gift card on or off. A card switched off keeps its balance but
+// cannot pay for orders until it is switched back on.
+func (r *SQLGiftCardRepository) SetGiftCardActive(ctx context.Context, giftCardId uuid.UUID, active bool) (model.GiftCard, error) {
+	giftCard, err := r.DB.SetGiftCardActive(ctx, database.SetGiftCardActiveParams{
+		ID:       giftCardId,
+		IsActive: active,
+	})
+	if err != nil {
+		return model.GiftCard{}, err
+	}
+
+	return toModelGiftCard(giftCard), nil
+}
+
+// GetGiftCards gets every gift card, newest first
+func (r *SQLGiftCardRepository) GetGiftCards(ctx context.Context) ([]model.GiftCard, error) {
+	giftCards, err := r.DB.ListGiftCards(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	modelGiftCards := make([]model.GiftCard, len(giftCards))
+	for i, giftCard := range giftCards {
+		modelGiftCards[i] = toModelGiftCard(giftCard)
+	}
+	return modelGiftCards, nil
+}
+
+// GetGiftCard gets a gift card with its ledger, oldest first
+func (r *SQLGiftCardRepository) GetGiftCard(ctx context.Context, giftCardId uuid.UUID) (model.GiftCardDetail, error) {
+	giftCard, err := r.DB.GetGiftCardByID(ctx, giftCardId)
+	if err != nil {
+		return model.GiftCardDetail{}, err
+	}
+
+	transactions, err := r.DB.ListGiftCardTransactions(ctx, giftCardId)
+	if err != nil {
+		return model.GiftCardDetail{}, err
+	}
+
+	modelTransactions := make([]model.GiftCardTransaction, len(transactions))
+	for i, transaction := range transactions {
+		modelTransactions[i] = toModelGiftCardTransaction(transaction)
+	}
+	return model.GiftCardDetail{
+		GiftCard:     toModelGiftCard(giftCard),
+		Transactions: modelTransactions,
+	}, nil
+}
+
+// GetGiftCardByCode gets a gift card by its code
+func (r *SQLGiftCardRepository) GetGiftCardByCode(ctx context.Context, code string) (model.GiftCard, error) {
+	giftCard, err := r.DB.GetGiftCardByCode(ctx, code)
+	if err != nil {
+		return model.GiftCard{}, err
+	}
+
+	return toModelGiftCard(giftCard), nil
+}
+
+// redeemGiftCards pays as much of an order as the gift cards cover, in the order the codes were
+// given, and returns what each card paid along with what is left to pay. The cards are locked in
+// code order, so checkouts sharing a card wait for each other instead of both spending its balance.
+func redeemGiftCards(ctx context.Context, q *database.Queries, orderId uuid.UUID, codes []string, due money.Money) ([]model.GiftCardRedemption, money.Money, error) {
+	if len(codes) == 0 {
+		return nil, due, nil
+	}
+
+	lockedCards, err := q.LockGiftCardsByCode(ctx, codes)
+	if err != nil {
+		return nil, due, err
+	}
+	giftCards := make(map[string]database.GiftCard, len(lockedCards))
+	for _, giftCard := range lockedCards {
+		giftCards[giftCard.Code] = giftCard
+	}
+
+	// check every card before taking anything off them
+	now := time.Now()
+	for _, code := range codes {
+		giftCard, ok := giftCards[code]
+		if !ok || !giftCard.IsActive || (giftCard.ExpiresAt.Valid && !now.Before(giftCard.ExpiresAt.Time)) {
+			return nil, due, &repository.GiftCardUnavailableError{Code: code}
+		}
+		balance, err := money.FromDecimal(giftCard.Balance, money.DefaultCurrency)
+		if err != nil {
+			return nil, due, err
+		}
+		if balance.Sign() <= 0 {
+			return nil, due, &repository.GiftCardUnavailableError{Code: code}
+		}
+	}
+
+	var redemptions []model.GiftCardRedemption
+	for _, code := range codes {
+		if due.IsZero() {
+			break
+		}
+		giftCard := giftCards[code]
+		balance, _ := money.FromDecimal(giftCard.Balance, money.DefaultCurrency)
+		amount := balance.Min(due)
+
+		transaction, err := recordGiftCard(ctx, q, database.CreateGiftCardTransactionParams{
+			GiftCardID: giftCard.ID,
+			Amount:     money.Zero(money.DefaultCurrency).Sub(amount).String(),
+			Reason:     "redeem",
+			OrderID:    uuid.NullUUID{UUID: orderId, Valid: true},
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, due, &repository.GiftCardUnavailableError{Code: code}
+		}
+		if err != nil {
+			return nil, due, err
+		}
+
+		redemptions = append(redemptions, model.GiftCardRedemption{
+			GiftCardID:   giftCard.ID,
+			Code:         giftCard.Code,
+			Amount:       amount.String(),
+			BalanceAfter: transaction.BalanceAfter,
+		})
+		due = due.Sub(amount)
+	}
+	return redemptions, due, nil
+}
+
+// recordGiftCard moves a gift card's balance by the entry's amount, which is negative for money
+// taken off the card, and adds the entry to its ledger. Money is only taken off a usable card
+// with enough balance left, in a single conditional update; sql.ErrNoRows is returned otherwise.
+func recordGiftCard(ctx context.Context, q *database.Queries, entry database.CreateGiftCardTransactionParams) (database.GiftCardTransaction, error) {
+	amount, err := money.FromDecimal(entry.Amount, money.DefaultCurrency)
+	if err != nil {
+		return database.GiftCardTransaction{}, err
+	}
+
+	var giftCard database.GiftCard
+	if amount.Sign() < 0 {
+		giftCard, err = q.DebitGiftCard(ctx, database.DebitGiftCardParams{
+			Amount: money.Zero(money.DefaultCurrency).Sub(amount).String(),
+			ID:     entry.GiftCardID,
+		})
+	} else {
+		giftCard, err = q.CreditGiftCard(ctx, database.CreditGiftCardParams{
+			Amount: amount.String(),
+			ID:     entry.GiftCardID,
+		})
+	}
+	if err != nil {
+		return database.GiftCardTransaction{}, err
+	}
+
+	entry.ID = uuid.New()
+	entry.BalanceAfter = giftCard.Balance
+	return q.CreateGiftCardTransaction(ctx, entry)
+}
+
+func toModelGiftCard(giftCard database.GiftCard) model.GiftCard {
+	return model.GiftCard{
+		ID:             giftCard.ID,
+		Code:           giftCard.Code,
+		InitialBalance: giftCard.InitialBalance,
+		Balance:        giftCard.Balance,
+		ExpiresAt:      giftCard.ExpiresAt,
+		IsActive:       giftCard.IsActive,
+		CreatedBy:      giftCard.CreatedBy,
+		CreatedAt:      giftCard.CreatedAt,
+		LastUpdated:    giftCard.LastUpdated,
+	}
+}
+
+func toModelGiftCardTransaction(transaction database.GiftCardTransaction) model.GiftCardTransaction {
+	return model.GiftCardTransaction{
+		ID:           transaction.ID,
+		GiftCardID:   transaction.GiftCardID,
+		Amount:       transaction.Amount,
+		BalanceAfter: transaction.BalanceAfter,
+		Reason:       transaction.Reason,
+		OrderID:      transaction.OrderID,
+		RefundID:     transaction.RefundID,
+		CreatedAt:    transaction.CreatedAt,
+	}
+}
@@ -0,0 +1,85 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type SQLIdempotencyRepository struct {
+	DB *database.Queries
+}
+
+func NewSQLIdempotencyRepository(db *database.Queries) *SQLIdempotencyRepository {
+	return &SQLIdempotencyRepository{
+		DB: db,
+	}
+}
+
+// ClaimIdempotencyKey stores a new key, or takes over one that has expired. When the key is
+// already held it returns the stored key and false, so the caller can replay or reject the request.
+func (r *SQLIdempotencyRepository) ClaimIdempotencyKey(ctx context.Context, params model.ClaimIdempotencyKeyParams) (model.IdempotencyKey, bool, error) {
+	key, err := r.DB.CreateIdempotencyKey(ctx, database.CreateIdempotencyKeyParams{
+		UserID:         params.UserID,
+		IdempotencyKey: params.Key,
+		RequestMethod:  params.RequestMethod,
+		RequestPath:    params.RequestPath,
+		RequestHash:    params.RequestHash,
+		ExpiredBefore:  params.ExpiredBefore,
+	})
+	if err == nil {
+		return toModelIdempotencyKey(key), true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Error claiming idempotency key %s: %s", params.Key, err.Error())
+		return model.IdempotencyKey{}, false, err
+	}
+
+	// the key is held by an earlier request
+	key, err = r.DB.GetIdempotencyKey(ctx, database.GetIdempotencyKeyParams{
+		UserID:         params.UserID,
+		IdempotencyKey: params.Key,
+	})
+	if err != nil {
+		log.Printf("Error getting idempotency key %s: %s", params.Key, err.Error())
+		return model.IdempotencyKey{}, false, err
+	}
+	return toModelIdempotencyKey(key), false, nil
+}
+
+// CompleteIdempotencyKey stores the response to replay for the key
+func (r *SQLIdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, userId uuid.UUID, key string, responseStatus int, responseBody string) error {
+	return r.DB.CompleteIdempotencyKey(ctx, database.CompleteIdempotencyKeyParams{
+		UserID:         userId,
+		IdempotencyKey: key,
+		ResponseStatus: sql.NullInt32{Int32: int32(responseStatus), Valid: true},
+		ResponseBody:   sql.NullString{String: responseBody, Valid: true},
+	})
+}
+
+// DeleteIdempotencyKey releases a key so the request can be tried again
+func (r *SQLIdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, userId uuid.UUID, key string) error {
+	return r.DB.DeleteIdempotencyKey(ctx, database.DeleteIdempotencyKeyParams{
+		UserID:         userId,
+		IdempotencyKey: key,
+	})
+}
+
+func toModelIdempotencyKey(key database.IdempotencyKey) model.IdempotencyKey {
+	return model.IdempotencyKey{
+		UserID:         key.UserID,
+		Key:            key.IdempotencyKey,
+		RequestMethod:  key.RequestMethod,
+		RequestPath:    key.RequestPath,
+		RequestHash:    key.RequestHash,
+		ResponseStatus: key.ResponseStatus,
+		ResponseBody:   key.ResponseBody,
+		CreatedAt:      key.CreatedAt,
+		CompletedAt:    key.CompletedAt,
+	}
+}
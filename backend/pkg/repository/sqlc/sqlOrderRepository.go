@@ -0,0 +1,390 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+type SQLOrderRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLOrderRepository(conn *sql.DB, db *database.Queries) *SQLOrderRepository {
+	return &SQLOrderRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// TransitionOrderStatus moves an order to a new status and records the change in its history.
+// The order row is locked first, so the status it is checked against cannot change underneath us.
+func (r *SQLOrderRepository) TransitionOrderStatus(ctx context.Context, params model.OrderTransitionParams) (model.Order, error) {
+	var updatedOrder database.Order
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		order, err := q.LockOrder(ctx, params.OrderID)
+		if err != nil {
+			return err
+		}
+
+		// check the move is allowed from the current status
+		if !containsStatus(params.AllowedFrom, order.OrderStatus) {
+			return &repository.InvalidTransitionError{
+				From: order.OrderStatus,
+				To:   params.ToStatus,
+			}
+		}
+
+		updatedOrder, err = q.UpdateOrderStatus(ctx, database.UpdateOrderStatusParams{
+			ID:          order.ID,
+			OrderStatus: params.ToStatus,
+		})
+		if err != nil {
+			return err
+		}
+
+		// reviews the customer wrote before the order arrived now count as verified purchases
+		if params.ToStatus == "delivered" {
+			if err := q.MarkVerifiedPurchases(ctx, order.ID); err != nil {
+				return err
+			}
+		}
+
+		// record the transition
+		return recordStatusChange(ctx, q, order, params.ToStatus, params.ActorID, params.Reason)
+	})
+	if err != nil {
+		log.Printf("Error moving order with id %s to %s: %s", params.OrderID.String(), params.ToStatus, err.Error())
+		return model.Order{}, err
+	}
+
+	return toModelOrder(updatedOrder), nil
+}
+
+// CancelOrder cancels an order, puts its items back in stock, releases its promotion code and flags
+// a paid order for refund, all in one transaction. Cancelling an order that is already cancelled changes nothing, so a
+// repeated request cannot restock twice.
+func (r *SQLOrderRepository) CancelOrder(ctx context.Context, params model.OrderCancelParams) (model.Order, error) {
+	var cancelledOrder database.Order
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		order, err := q.LockOrder(ctx, params.OrderID)
+		if err != nil {
+			return err
+		}
+
+		// customers may only cancel their own orders
+		if params.OwnerID.Valid && order.UserID != params.OwnerID.UUID {
+			return sql.ErrNoRows
+		}
+
+		// nothing to do if already cancelled
+		if order.OrderStatus == "cancelled" {
+			cancelledOrder = order
+			return nil
+		}
+
+		if !containsStatus(params.AllowedFrom, order.OrderStatus) {
+			return &repository.InvalidTransitionError{
+				From: order.OrderStatus,
+				To:   "cancelled",
+			}
+		}
+
+		// put the items back in stock
+		if err := q.RestockOrderItems(ctx, order.ID); err != nil {
+			return err
+		}
+
+		cancelledOrder, err = q.UpdateOrderStatus(ctx, database.UpdateOrderStatusParams{
+			ID:          order.ID,
+			OrderStatus: "cancelled",
+		})
+		if err != nil {
+			return err
+		}
+
+		// gift cards and store credit are given back straight away
+		if err := restoreOrderTenders(ctx, q, order, params.ActorID); err != nil {
+			return err
+		}
+
+		// the promotion code can be used again
+		if err := releaseOrderPromotion(ctx, q, order.ID); err != nil {
+			return err
+		}
+
+		// money already taken has to go back, unless the payment method was not charged at all
+		if cancelledOrder.PaymentStatus == "paid" {
+			charged, err := gatewayRefundable(ctx, q, cancelledOrder)
+			if err != nil {
+				return err
+			}
+			paymentStatus := "refund_pending"
+			if charged.Sign() <= 0 {
+				paymentStatus = "refunded"
+			}
+			cancelledOrder, err = q.UpdateOrderPaymentStatus(ctx, database.UpdateOrderPaymentStatusParams{
+				ID:            order.ID,
+				PaymentStatus: paymentStatus,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// record the transition
+		return recordStatusChange(ctx, q, order, "cancelled", params.ActorID, params.Reason)
+	})
+	if err != nil {
+		log.Printf("Error cancelling order with id %s: %s", params.OrderID.String(), err.Error())
+		return model.Order{}, err
+	}
+
+	return toModelOrder(cancelledOrder), nil
+}
+
+// releaseOrderPromotion drops the redemption of the promotion used on an order, if any, so that
+// neither the promotion's uses nor the customer's count it any more
+func releaseOrderPromotion(ctx context.Context, q *database.Queries, orderId uuid.UUID) error {
+	redemption, err := q.DeleteOrderPromotionRedemption(ctx, orderId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return q.DecrementPromotionUses(ctx, redemption.PromotionID)
+}
+
+// restoreOrderTenders gives back what gift cards and store credit paid towards an order, each
+// recorded as a refund that has already succeeded. Nothing more than is left to refund on the
+// order is given back.
+func restoreOrderTenders(ctx context.Context, q *database.Queries, order database.Order, actorId uuid.UUID) error {
+	remaining, err := orderRefundable(ctx, q, order)
+	if err != nil {
+		return err
+	}
+	orderId := uuid.NullUUID{UUID: order.ID, Valid: true}
+
+	giftCards, err := q.ListOrderGiftCardBalances(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	for _, giftCard := range giftCards {
+		amount, err := money.FromDecimal(giftCard.Amount, money.DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		amount = amount.Min(remaining)
+		if amount.Sign() <= 0 {
+			break
+		}
+
+		refund, err := createSettledRefund(ctx, q, order.ID, amount, "gift_card", actorId)
+		if err != nil {
+			return err
+		}
+		_, err = recordGiftCard(ctx, q, database.CreateGiftCardTransactionParams{
+			GiftCardID: giftCard.GiftCardID,
+			Amount:     amount.String(),
+			Reason:     "restore",
+			OrderID:    orderId,
+			RefundID:   uuid.NullUUID{UUID: refund.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		remaining = remaining.Sub(amount)
+	}
+
+	storeCredit, err := q.GetOrderStoreCreditAmount(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	amount, err := money.FromDecimal(storeCredit, money.DefaultCurrency)
+	if err != nil {
+		return err
+	}
+	amount = amount.Min(remaining)
+	if amount.Sign() <= 0 {
+		return nil
+	}
+
+	refund, err := createSettledRefund(ctx, q, order.ID, amount, "store_credit", actorId)
+	if err != nil {
+		return err
+	}
+	_, err = recordStoreCredit(ctx, q, database.CreateStoreCreditTransactionParams{
+		UserID:   order.UserID,
+		Amount:   amount.String(),
+		Reason:   "restore",
+		OrderID:  orderId,
+		RefundID: uuid.NullUUID{UUID: refund.ID, Valid: true},
+	})
+	return err
+}
+
+// createSettledRefund records a refund that needs nothing from the payment gateway
+func createSettledRefund(ctx context.Context, q *database.Queries, orderId uuid.UUID, amount money.Money, method string, actorId uuid.UUID) (database.Refund, error) {
+	refund, err := q.CreateRefund(ctx, database.CreateRefundParams{
+		ID:        uuid.New(),
+		OrderID:   orderId,
+		Amount:    amount.String(),
+		Reason:    sql.NullString{String: "order cancelled", Valid: true},
+		CreatedBy: uuid.NullUUID{UUID: actorId, Valid: actorId != uuid.Nil},
+		Method:    method,
+	})
+	if err != nil {
+		return database.Refund{}, err
+	}
+
+	return q.FinishRefund(ctx, database.FinishRefundParams{
+		ID:     refund.ID,
+		Status: "succeeded",
+	})
+}
+
+// GetUserOrder gets an order by id, provided it belongs to the user
+func (r *SQLOrderRepository) GetUserOrder(ctx context.Context, orderId uuid.UUID, userId uuid.UUID) (model.Order, error) {
+	order, err := r.DB.GetUserOrder(ctx, database.GetUserOrderParams{
+		ID:     orderId,
+		UserID: userId,
+	})
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	return toModelOrder(order), nil
+}
+
+// GetOrderStatusHistory gets the status changes of an order, oldest first
+func (r *SQLOrderRepository) GetOrderStatusHistory(ctx context.Context, orderId uuid.UUID) ([]model.OrderStatusChange, error) {
+	history, err := r.DB.ListOrderStatusHistory(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]model.OrderStatusChange, len(history))
+	for i, change := range history {
+		changes[i] = toModelOrderStatusChange(change)
+	}
+	return changes, nil
+}
+
+// GetOrderItems gets the items of an order along with their product names and images
+func (r *SQLOrderRepository) GetOrderItems(ctx context.Context, orderId uuid.UUID) ([]model.OrderItemDetail, error) {
+	items, err := r.DB.ListOrderItemsWithProducts(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]model.OrderItemDetail, len(items))
+	for i, item := range items {
+		details[i] = model.OrderItemDetail{
+			OrderItem: model.OrderItem{
+				ID:             item.ID,
+				OrderID:        item.OrderID,
+				ProductID:      item.ProductID,
+				Quantity:       item.Quantity,
+				UnitPrice:      item.UnitPrice,
+				TaxedPrice:     item.TaxedPrice,
+				TaxRate:        item.TaxRate,
+				TaxAmount:      item.TaxAmount,
+				DiscountAmount: item.DiscountAmount,
+				CreatedAt:      item.CreatedAt,
+				LastUpdated:    item.LastUpdated,
+			},
+			ProductName: item.ProductName,
+			ImageUrl:    item.ImageUrl,
+		}
+	}
+	return details, nil
+}
+
+// GetUserOrders gets a page of the user's orders, newest first
+func (r *SQLOrderRepository) GetUserOrders(ctx context.Context, userId uuid.UUID, filter model.OrderFilter, offset int32, limit int32) (interface{}, error) {
+	orders, err := r.DB.ListUserOrders(ctx, database.ListUserOrdersParams{
+		UserID:      userId,
+		OrderStatus: filter.Status,
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	modelOrders := make([]model.Order, len(orders))
+	for i, order := range orders {
+		modelOrders[i] = toModelOrder(order)
+	}
+	return modelOrders, nil
+}
+
+// GetUserOrderCount counts the user's orders matching the filter
+func (r *SQLOrderRepository) GetUserOrderCount(ctx context.Context, userId uuid.UUID, filter model.OrderFilter) (int64, error) {
+	return r.DB.CountUserOrders(ctx, database.CountUserOrdersParams{
+		UserID:      userId,
+		OrderStatus: filter.Status,
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+	})
+}
+
+// recordStatusChange adds an entry to the order's status history
+func recordStatusChange(ctx context.Context, q *database.Queries, order database.Order, toStatus string, actorId uuid.UUID, reason string) error {
+	_, err := q.CreateOrderStatusHistory(ctx, database.CreateOrderStatusHistoryParams{
+		ID:         uuid.New(),
+		OrderID:    order.ID,
+		FromStatus: sql.NullString{String: order.OrderStatus, Valid: true},
+		ToStatus:   toStatus,
+		ActorID:    uuid.NullUUID{UUID: actorId, Valid: actorId != uuid.Nil},
+		Reason:     sql.NullString{String: reason, Valid: reason != ""},
+	})
+	return err
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func toModelOrderStatusChange(change database.OrderStatusHistory) model.OrderStatusChange {
+	return model.OrderStatusChange{
+		ID:         change.ID,
+		OrderID:    change.OrderID,
+		FromStatus: change.FromStatus,
+		ToStatus:   change.ToStatus,
+		ActorID:    change.ActorID,
+		Reason:     change.Reason,
+		CreatedAt:  change.CreatedAt,
+	}
+}
+
+// GetOrderPromotion gets the promotion redeemed on an order, or nil if none was
+func (r *SQLOrderRepository) GetOrderPromotion(ctx context.Context, orderId uuid.UUID) (*model.PromotionRedemption, error) {
+	redemption, err := r.DB.GetOrderPromotionRedemption(ctx, orderId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	promotion := toModelPromotionRedemption(redemption)
+	return &promotion, nil
+}
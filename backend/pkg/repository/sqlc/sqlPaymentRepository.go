@@ -0,0 +1,348 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+type SQLPaymentRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLPaymentRepository(conn *sql.DB, db *database.Queries) *SQLPaymentRepository {
+	return &SQLPaymentRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// BeginPayment records a new payment attempt for the order's total, in the currency the order
+// settles in. The order is locked while it is checked, so only one attempt can be in flight for an
+// order at a time.
+func (r *SQLPaymentRepository) BeginPayment(ctx context.Context, params model.BeginPaymentParams) (model.Order, model.Payment, error) {
+	var order database.Order
+	var payment database.Payment
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		var err error
+		order, err = q.LockOrder(ctx, params.OrderID)
+		if err != nil {
+			return err
+		}
+
+		// customers may only pay for their own orders
+		if params.OwnerID.Valid && order.UserID != params.OwnerID.UUID {
+			return sql.ErrNoRows
+		}
+		if order.PaymentMethod == "cash" || order.OrderStatus == "cancelled" {
+			return repository.ErrOrderNotPayable
+		}
+		if order.PaymentStatus != "pending" && order.PaymentStatus != "failed" {
+			return repository.ErrOrderAlreadyPaid
+		}
+
+		active, err := q.CountActivePayments(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if active > 0 {
+			return repository.ErrPaymentInProgress
+		}
+
+		// orders placed before settlement totals were recorded settle at their total price
+		amount := order.TotalPrice
+		if order.SettlementTotal.Valid {
+			amount = order.SettlementTotal.String
+		}
+		payment, err = q.CreatePayment(ctx, database.CreatePaymentParams{
+			ID:            uuid.New(),
+			OrderID:       order.ID,
+			Provider:      params.Provider,
+			PaymentMethod: order.PaymentMethod,
+			Amount:        amount,
+			Currency:      order.Currency,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Error starting payment for order with id %s: %s", params.OrderID.String(), err.Error())
+		return model.Order{}, model.Payment{}, err
+	}
+
+	return toModelOrder(order), toModelPayment(payment), nil
+}
+
+// UpdatePayment records the outcome of a gateway call, and the order's payment status with it
+func (r *SQLPaymentRepository) UpdatePayment(ctx context.Context, params model.UpdatePaymentParams) (model.Payment, error) {
+	var payment database.Payment
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		var err error
+		payment, err = q.UpdatePayment(ctx, database.UpdatePaymentParams{
+			Status:                params.Status,
+			ProviderTransactionID: sql.NullString{String: params.ProviderTransactionID, Valid: params.ProviderTransactionID != ""},
+			FailureMessage:        sql.NullString{String: params.FailureMessage, Valid: params.FailureMessage != ""},
+			ID:                    params.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if params.OrderPaymentStatus == "" {
+			return nil
+		}
+		_, err = q.UpdateOrderPaymentStatus(ctx, database.UpdateOrderPaymentStatusParams{
+			ID:            payment.OrderID,
+			PaymentStatus: params.OrderPaymentStatus,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Error updating payment with id %s: %s", params.ID.String(), err.Error())
+		return model.Payment{}, err
+	}
+
+	return toModelPayment(payment), nil
+}
+
+// AuthorizePayment records that the gateway reserved the money for a payment attempt, once the
+// order has been checked again under lock. It returns ErrOrderNotPayable or ErrOrderAlreadyPaid
+// when the order was cancelled or paid while the money was being reserved, and ErrPaymentExpired
+// when the attempt was given up on meanwhile, in which case the reservation must be voided.
+func (r *SQLPaymentRepository) AuthorizePayment(ctx context.Context, attempt model.Payment, transactionId string) (model.Payment, error) {
+	var payment database.Payment
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		order, err := q.LockOrder(ctx, attempt.OrderID)
+		if err != nil {
+			return err
+		}
+		payment, err = q.LockPayment(ctx, attempt.ID)
+		if err != nil {
+			return err
+		}
+
+		if payment.Status != "pending" {
+			return repository.ErrPaymentExpired
+		}
+		if order.OrderStatus == "cancelled" {
+			return repository.ErrOrderNotPayable
+		}
+		if order.PaymentStatus != "pending" && order.PaymentStatus != "failed" {
+			return repository.ErrOrderAlreadyPaid
+		}
+
+		payment, err = q.UpdatePayment(ctx, database.UpdatePaymentParams{
+			Status:                "authorized",
+			ProviderTransactionID: sql.NullString{String: transactionId, Valid: transactionId != ""},
+			ID:                    attempt.ID,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Error authorizing payment with id %s: %s", attempt.ID.String(), err.Error())
+		return model.Payment{}, err
+	}
+
+	return toModelPayment(payment), nil
+}
+
+// CapturePayment records that the gateway took the money for an authorized payment attempt and
+// pays the order. An order cancelled while the money was being taken is flagged for refund
+// instead, as CancelOrder does for orders that were already paid.
+func (r *SQLPaymentRepository) CapturePayment(ctx context.Context, attempt model.Payment) (model.Payment, error) {
+	var payment database.Payment
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		order, err := q.LockOrder(ctx, attempt.OrderID)
+		if err != nil {
+			return err
+		}
+
+		payment, err = q.UpdatePayment(ctx, database.UpdatePaymentParams{
+			Status: "captured",
+			ID:     attempt.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		paymentStatus := "paid"
+		if order.OrderStatus == "cancelled" {
+			paymentStatus = "refund_pending"
+		} else if order.PaymentStatus != "pending" && order.PaymentStatus != "failed" {
+			log.Printf("Order with id %s was already %s when payment with id %s was captured", order.ID.String(), order.PaymentStatus, attempt.ID.String())
+			return nil
+		}
+		_, err = q.UpdateOrderPaymentStatus(ctx, database.UpdateOrderPaymentStatusParams{
+			ID:            order.ID,
+			PaymentStatus: paymentStatus,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Error capturing payment with id %s: %s", attempt.ID.String(), err.Error())
+		return model.Payment{}, err
+	}
+
+	return toModelPayment(payment), nil
+}
+
+// ExpirePayment gives up on a payment attempt that never finished, unless it has finished since
+func (r *SQLPaymentRepository) ExpirePayment(ctx context.Context, paymentId uuid.UUID, status string, message string) error {
+	_, err := r.DB.ExpirePayment(ctx, database.ExpirePaymentParams{
+		ID:             paymentId,
+		Status:         status,
+		FailureMessage: sql.NullString{String: message, Valid: message != ""},
+	})
+	if err != nil {
+		log.Printf("Error expiring payment with id %s: %s", paymentId.String(), err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// ApplyPaymentEvent records a provider event and applies it to the payment it is about. It returns
+// "duplicate" for an event that was already received, "ignored" when the payment has already moved
+// past the reported status and "applied" otherwise. An unknown transaction leaves nothing behind, so
+// the provider can deliver the event again once the payment exists. A capture for an order that
+// was cancelled in the meantime leaves the order refund_pending rather than paid.
+func (r *SQLPaymentRepository) ApplyPaymentEvent(ctx context.Context, params model.PaymentEventParams) (string, error) {
+	var outcome string
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		// the unique (provider, event_id) makes replays a no-op
+		inserted, err := q.CreatePaymentEvent(ctx, database.CreatePaymentEventParams{
+			ID:                    uuid.New(),
+			Provider:              params.Provider,
+			EventID:               params.EventID,
+			EventType:             params.EventType,
+			ProviderTransactionID: params.TransactionID,
+			Payload:               params.Payload,
+		})
+		if err != nil {
+			return err
+		}
+		if inserted == 0 {
+			outcome = "duplicate"
+			return nil
+		}
+
+		// lock the order before the payment, as checkout does, so a capture sees whether the
+		// order was cancelled in the meantime
+		transactionId := sql.NullString{String: params.TransactionID, Valid: true}
+		found, err := q.GetPaymentByTransaction(ctx, database.GetPaymentByTransactionParams{
+			Provider:              params.Provider,
+			ProviderTransactionID: transactionId,
+		})
+		if err != nil {
+			return err
+		}
+		order, err := q.LockOrder(ctx, found.OrderID)
+		if err != nil {
+			return err
+		}
+		payment, err := q.LockPaymentByTransaction(ctx, database.LockPaymentByTransactionParams{
+			Provider:              params.Provider,
+			ProviderTransactionID: transactionId,
+		})
+		if err != nil {
+			return err
+		}
+
+		// events arriving out of order must not move the payment backwards
+		outcome = "ignored"
+		if containsStatus(params.AllowedFrom, payment.Status) {
+			outcome = "applied"
+
+			_, err = q.UpdatePayment(ctx, database.UpdatePaymentParams{
+				Status:         params.Status,
+				FailureMessage: sql.NullString{String: params.FailureMessage, Valid: params.FailureMessage != ""},
+				ID:             payment.ID,
+			})
+			if err != nil {
+				return err
+			}
+
+			// money captured for a cancelled order is owed back
+			orderPaymentStatus := params.OrderPaymentStatus
+			if params.Status == "captured" && order.OrderStatus == "cancelled" {
+				orderPaymentStatus = "refund_pending"
+			}
+			if orderPaymentStatus != "" {
+				_, err = q.UpdateOrderPaymentStatusFrom(ctx, database.UpdateOrderPaymentStatusFromParams{
+					PaymentStatus: orderPaymentStatus,
+					ID:            payment.OrderID,
+					FromStatuses:  params.OrderPaymentFrom,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return q.MarkPaymentEventProcessed(ctx, database.MarkPaymentEventProcessedParams{
+			Provider: params.Provider,
+			EventID:  params.EventID,
+			Outcome:  sql.NullString{String: outcome, Valid: true},
+		})
+	})
+	if err != nil {
+		log.Printf("Error applying %s payment event %s: %s", params.Provider, params.EventID, err.Error())
+		return "", err
+	}
+
+	return outcome, nil
+}
+
+// GetOrderPayments gets every payment attempt for an order, oldest first
+func (r *SQLPaymentRepository) GetOrderPayments(ctx context.Context, orderId uuid.UUID) ([]model.Payment, error) {
+	payments, err := r.DB.ListOrderPayments(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	modelPayments := make([]model.Payment, len(payments))
+	for i, payment := range payments {
+		modelPayments[i] = toModelPayment(payment)
+	}
+	return modelPayments, nil
+}
+
+// GetStalePayments gets an order's payment attempts started before the given time that are still
+// pending or authorized
+func (r *SQLPaymentRepository) GetStalePayments(ctx context.Context, orderId uuid.UUID, startedBefore time.Time) ([]model.Payment, error) {
+	payments, err := r.DB.ListStalePayments(ctx, database.ListStalePaymentsParams{
+		OrderID:       orderId,
+		StartedBefore: startedBefore,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	modelPayments := make([]model.Payment, len(payments))
+	for i, payment := range payments {
+		modelPayments[i] = toModelPayment(payment)
+	}
+	return modelPayments, nil
+}
+
+func toModelPayment(payment database.Payment) model.Payment {
+	return model.Payment{
+		ID:                    payment.ID,
+		OrderID:               payment.OrderID,
+		Provider:              payment.Provider,
+		PaymentMethod:         payment.PaymentMethod,
+		Amount:                payment.Amount,
+		Status:                payment.Status,
+		ProviderTransactionID: payment.ProviderTransactionID,
+		FailureMessage:        payment.FailureMessage,
+		CreatedAt:             payment.CreatedAt,
+		LastUpdated:           payment.LastUpdated,
+		Currency:              payment.Currency,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
 	"log"
 
 	"github.com/geraldbahati/ecommerce/internal/database"
@@ -28,7 +29,7 @@ func (r *SQLProductRepository) AddProduct(ctx context.Context, product model.Add
 		Name:          product.Name,
 		Description:   product.Description,
 		ImageUrl:      product.ImageUrl,
-		Price:         product.Price,
+		Price:         product.Price.String(),
 		Stock:         product.Stock,
 		SubCategoryID: product.SubCategoryID,
 		Brand:         product.Brand,
@@ -39,23 +40,7 @@ func (r *SQLProductRepository) AddProduct(ctx context.Context, product model.Add
 	}
 
 	// Return newly added product
-	return model.Product{
-		ID:            addProduct.ID,
-		Name:          addProduct.Name,
-		Description:   addProduct.Description,
-		ImageUrl:      addProduct.ImageUrl,
-		Price:         addProduct.Price,
-		Stock:         addProduct.Stock,
-		SubCategoryID: addProduct.SubCategoryID,
-		Brand:         addProduct.Brand,
-		Rating:        addProduct.Rating,
-		ReviewCount:   addProduct.ReviewCount,
-		DiscountRate:  addProduct.DiscountRate,
-		Keywords:      addProduct.Keywords,
-		IsActive:      addProduct.IsActive,
-		CreatedAt:     addProduct.CreatedAt,
-		LastUpdated:   addProduct.LastUpdated,
-	}, err
+	return toProductModel(addProduct)
 }
 
 // UpdateProduct updates an already existing product in the database
@@ -67,12 +52,10 @@ func (r *SQLProductRepository) UpdateProduct(ctx context.Context, product model.
 		Name:          product.Name,
 		Description:   product.Description,
 		ImageUrl:      product.ImageUrl,
-		Price:         product.Price,
+		Price:         product.Price.String(),
 		Stock:         product.Stock,
 		SubCategoryID: product.SubCategoryID,
 		Brand:         product.Brand,
-		Rating:        product.Rating,
-		ReviewCount:   product.ReviewCount,
 		DiscountRate:  product.DiscountRate,
 		Keywords:      product.Keywords,
 		IsActive:      product.IsActive,
@@ -84,23 +67,7 @@ func (r *SQLProductRepository) UpdateProduct(ctx context.Context, product model.
 	}
 
 	// Return updated Product
-	return model.Product{
-		ID:            updatedProduct.ID,
-		Name:          updatedProduct.Name,
-		Description:   updatedProduct.Description,
-		ImageUrl:      updatedProduct.ImageUrl,
-		Price:         updatedProduct.Price,
-		Stock:         updatedProduct.Stock,
-		SubCategoryID: updatedProduct.SubCategoryID,
-		Brand:         updatedProduct.Brand,
-		Rating:        updatedProduct.Rating,
-		ReviewCount:   updatedProduct.ReviewCount,
-		DiscountRate:  updatedProduct.DiscountRate,
-		Keywords:      updatedProduct.Keywords,
-		IsActive:      updatedProduct.IsActive,
-		CreatedAt:     updatedProduct.CreatedAt,
-		LastUpdated:   updatedProduct.LastUpdated,
-	}, err
+	return toProductModel(updatedProduct)
 }
 
 // DeleteProduct implements repository.ProductRepository.
@@ -121,49 +88,78 @@ func (r *SQLProductRepository) DeleteProduct(ctx context.Context, productID uuid
 }
 
 // GetAvailableProducts implements repository.ProductRepository.
-func (r *SQLProductRepository) GetAvailableProducts(ctx context.Context) ([]database.Product, error) {
+func (r *SQLProductRepository) GetAvailableProducts(ctx context.Context) ([]model.Product, error) {
 	availableProducts, err := r.DB.GetAvailableProducts(ctx)
 	if err != nil {
 		log.Printf("Error fetching available products : %s", err.Error())
-		return []database.Product{}, err
+		return []model.Product{}, err
 	}
 
-	return availableProducts, nil
+	return toProductModels(availableProducts)
 }
 
 // GetProductById implements repository.ProductRepository.
-func (r *SQLProductRepository) GetProductById(ctx context.Context, id uuid.UUID) (database.Product, error) {
+func (r *SQLProductRepository) GetProductById(ctx context.Context, id uuid.UUID) (model.Product, error) {
 	product, err := r.DB.GetProductById(ctx, id)
 	if err != nil {
 		log.Printf("Error fetching product with id %s: %s", id.String(), err.Error())
-		return database.Product{}, err
+		return model.Product{}, err
 	}
-	return product, nil
+	return toProductModel(product)
 }
 
 // GetProducts implements repository.ProductRepository.
-func (r *SQLProductRepository) GetProducts(ctx context.Context, offset int32, limit int32) (interface{}, error) {
+func (r *SQLProductRepository) GetProducts(ctx context.Context, offset int32, limit int32) ([]model.Product, error) {
 	products, err := r.DB.GetProducts(ctx, database.GetProductsParams{
 		Offset: offset,
 		Limit:  limit,
 	})
 	if err != nil {
 		log.Printf("Error fetching all products in the database : %s", err.Error())
-		return []database.Product{}, err
+		return []model.Product{}, err
 	}
-	return products, nil
+	return toProductModels(products)
 }
 
 // GetProductsByCategory implements repository.ProductRepository.
-func (r *SQLProductRepository) GetProductsByCategory(ctx context.Context, categoryID uuid.UUID) (interface{}, error) {
+func (r *SQLProductRepository) GetProductsByCategory(ctx context.Context, categoryID uuid.UUID) ([]model.GetProductsByCategoryRow, error) {
 	categorizedProducts, err := r.DB.GetProductsByCategory(ctx, database.GetProductsByCategoryParams{
 		ID: categoryID,
 	})
 	if err != nil {
 		log.Printf("Error fetching categorized products with category id %s: %s", categoryID.String(), err.Error())
-		return []database.Product{}, err
+		return []model.GetProductsByCategoryRow{}, err
+	}
+
+	var products []model.GetProductsByCategoryRow
+	for _, product := range categorizedProducts {
+		price, err := money.FromDecimal(product.Price, money.DefaultCurrency)
+		if err != nil {
+			return []model.GetProductsByCategoryRow{}, err
+		}
+		products = append(products, model.GetProductsByCategoryRow{
+			ID:              product.ID,
+			Name:            product.Name,
+			Description:     product.Description,
+			ImageUrl:        product.ImageUrl,
+			Price:           price,
+			Stock:           product.Stock,
+			Brand:           product.Brand,
+			Rating:          product.Rating,
+			ReviewCount:     product.ReviewCount,
+			DiscountRate:    product.DiscountRate,
+			Keywords:        product.Keywords,
+			IsActive:        product.IsActive,
+			CreatedAt:       product.CreatedAt,
+			LastUpdated:     product.LastUpdated,
+			SubCategoryID:   product.SubCategoryID,
+			SubCategoryName: product.SubCategoryName,
+			CategoryName:    product.CategoryName,
+			SaleStartsAt:    product.SaleStartsAt,
+			SaleEndsAt:      product.SaleEndsAt,
+		})
 	}
-	return categorizedProducts, nil
+	return products, nil
 }
 
 // GetSalesTrends implements repository.ProductRepository.
@@ -177,13 +173,13 @@ func (r *SQLProductRepository) GetSalesTrends(ctx context.Context) ([]database.G
 }
 
 // SearchProducts implements repository.ProductRepository.
-func (r *SQLProductRepository) SearchProducts(ctx context.Context, query sql.NullString) ([]database.Product, error) {
+func (r *SQLProductRepository) SearchProducts(ctx context.Context, query sql.NullString) ([]model.Product, error) {
 	queryResults, err := r.DB.SearchProducts(ctx, query)
 	if err != nil {
 		log.Printf("Error fetching products with query  %s: %s", query.String, err.Error())
-		return []database.Product{}, err
+		return []model.Product{}, err
 	}
-	return queryResults, nil
+	return toProductModels(queryResults)
 }
 
 // GetTrendingProducts implements repository.ProductRepository.
@@ -197,13 +193,20 @@ func (r *SQLProductRepository) GetTrendingProducts(ctx context.Context) ([]model
 	// Return trending products
 	var modelTrendingProducts []model.TrendingProduct
 	for _, product := range trendingProducts {
+		price, err := money.FromDecimal(product.Price, money.DefaultCurrency)
+		if err != nil {
+			return []model.TrendingProduct{}, err
+		}
 		modelTrendingProducts = append(modelTrendingProducts, model.TrendingProduct{
 			ProductID:    product.ProductID,
 			ProductName:  product.ProductName,
-			Price:        product.Price,
+			Price:        price,
 			CategoryID:   product.CategoryID,
 			CategoryName: product.CategoryName,
 			SalesVolume:  product.SalesVolume,
+			DiscountRate: product.DiscountRate,
+			SaleStartsAt: product.SaleStartsAt,
+			SaleEndsAt:   product.SaleEndsAt,
 		})
 	}
 
@@ -474,3 +477,64 @@ func (r *SQLProductRepository) GetMaterialByName(ctx context.Context, materialNa
 		LastUpdated: material.LastUpdated,
 	}, nil
 }
+
+// UpdateProductSale implements repository.ProductRepository.
+func (r *SQLProductRepository) UpdateProductSale(ctx context.Context, productId uuid.UUID, params model.UpdateProductSaleParams) error {
+	rows, err := r.DB.UpdateProductSale(ctx, database.UpdateProductSaleParams{
+		ID:           productId,
+		DiscountRate: params.DiscountRate,
+		SaleStartsAt: params.SaleStartsAt,
+		SaleEndsAt:   params.SaleEndsAt,
+	})
+	if err != nil {
+		log.Printf("Error updating sale of product with id %s: %s", productId.String(), err.Error())
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func toProductModel(product database.Product) (model.Product, error) {
+	price, err := money.FromDecimal(product.Price, money.DefaultCurrency)
+	if err != nil {
+		return model.Product{}, err
+	}
+
+	return model.Product{
+		ID:            product.ID,
+		Name:          product.Name,
+		Description:   product.Description,
+		ImageUrl:      product.ImageUrl,
+		Price:         price,
+		Stock:         product.Stock,
+		SubCategoryID: product.SubCategoryID,
+		Brand:         product.Brand,
+		Rating:        product.Rating,
+		ReviewCount:   product.ReviewCount,
+		DiscountRate:  product.DiscountRate,
+		Keywords:      product.Keywords,
+		IsActive:      product.IsActive,
+		CreatedAt:     product.CreatedAt,
+		LastUpdated:   product.LastUpdated,
+		WeightGrams:   product.WeightGrams,
+		LengthCm:      product.LengthCm,
+		WidthCm:       product.WidthCm,
+		HeightCm:      product.HeightCm,
+		SaleStartsAt:  product.SaleStartsAt,
+		SaleEndsAt:    product.SaleEndsAt,
+	}, nil
+}
+
+func toProductModels(products []database.Product) ([]model.Product, error) {
+	productModels := make([]model.Product, 0, len(products))
+	for _, product := range products {
+		productModel, err := toProductModel(product)
+		if err != nil {
+			return []model.Product{}, err
+		}
+		productModels = append(productModels, productModel)
+	}
+	return productModels, nil
+}
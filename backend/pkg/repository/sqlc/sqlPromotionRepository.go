@@ -0,0 +1,245 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type SQLPromotionRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLPromotionRepository(conn *sql.DB, db *database.Queries) *SQLPromotionRepository {
+	return &SQLPromotionRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// CreatePromotion creates a promotion along with the products and categories it is limited to.
+// sql.ErrNoRows is returned if the code is already taken.
+func (r *SQLPromotionRepository) CreatePromotion(ctx context.Context, params model.CreatePromotionParams) (model.PromotionDetail, error) {
+	var detail model.PromotionDetail
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		promotion, err := q.CreatePromotion(ctx, database.CreatePromotionParams{
+			ID:             uuid.New(),
+			Code:           params.Code,
+			Description:    params.Description,
+			DiscountType:   params.DiscountType,
+			DiscountValue:  params.DiscountValue,
+			MinCartValue:   params.MinCartValue,
+			StartsAt:       params.StartsAt,
+			EndsAt:         params.EndsAt,
+			MaxUses:        params.MaxUses,
+			MaxUsesPerUser: params.MaxUsesPerUser,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, target := range params.Targets {
+			err := q.CreatePromotionTarget(ctx, database.CreatePromotionTargetParams{
+				PromotionID: promotion.ID,
+				TargetType:  target.TargetType,
+				TargetID:    target.TargetID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		targets, err := q.ListPromotionTargets(ctx, promotion.ID)
+		if err != nil {
+			return err
+		}
+
+		detail = model.PromotionDetail{
+			Promotion: toModelPromotion(promotion),
+			Targets:   toModelPromotionTargets(targets),
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error creating promotion %s: %s", params.Code, err.Error())
+		return model.PromotionDetail{}, err
+	}
+
+	return detail, nil
+}
+
+// SetPromotionActive switches a promotion on or off
+func (r *SQLPromotionRepository) SetPromotionActive(ctx context.Context, promotionId uuid.UUID, active bool) error {
+	rows, err := r.DB.SetPromotionActive(ctx, database.SetPromotionActiveParams{
+		ID:       promotionId,
+		IsActive: active,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetCartPromotion applies a promotion to a cart, replacing any already applied
+func (r *SQLPromotionRepository) SetCartPromotion(ctx context.Context, cartId uuid.UUID, promotionId uuid.UUID) error {
+	err := r.DB.SetCartPromotion(ctx, database.SetCartPromotionParams{
+		ShoppingCartID: cartId,
+		PromotionID:    promotionId,
+	})
+	if err != nil {
+		log.Printf("Error applying promotion to cart %s: %s", cartId.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// RemoveCartPromotion takes the promotion off a cart
+func (r *SQLPromotionRepository) RemoveCartPromotion(ctx context.Context, cartId uuid.UUID) error {
+	rows, err := r.DB.DeleteCartPromotion(ctx, cartId)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetPromotions gets every promotion, newest first
+func (r *SQLPromotionRepository) GetPromotions(ctx context.Context) ([]model.Promotion, error) {
+	promotions, err := r.DB.ListPromotions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	modelPromotions := make([]model.Promotion, len(promotions))
+	for i, promotion := range promotions {
+		modelPromotions[i] = toModelPromotion(promotion)
+	}
+	return modelPromotions, nil
+}
+
+// GetPromotion gets a promotion with its targets
+func (r *SQLPromotionRepository) GetPromotion(ctx context.Context, promotionId uuid.UUID) (model.PromotionDetail, error) {
+	promotion, err := r.DB.GetPromotion(ctx, promotionId)
+	if err != nil {
+		return model.PromotionDetail{}, err
+	}
+
+	return r.withTargets(ctx, promotion)
+}
+
+// GetPromotionByCode gets the promotion for a code with its targets
+func (r *SQLPromotionRepository) GetPromotionByCode(ctx context.Context, code string) (model.PromotionDetail, error) {
+	promotion, err := r.DB.GetPromotionByCode(ctx, code)
+	if err != nil {
+		return model.PromotionDetail{}, err
+	}
+
+	return r.withTargets(ctx, promotion)
+}
+
+// GetCartPromotion gets the promotion applied to a cart with its targets
+func (r *SQLPromotionRepository) GetCartPromotion(ctx context.Context, cartId uuid.UUID) (model.PromotionDetail, error) {
+	promotion, err := r.DB.GetCartPromotion(ctx, cartId)
+	if err != nil {
+		return model.PromotionDetail{}, err
+	}
+
+	return r.withTargets(ctx, promotion)
+}
+
+// GetUserRedemptionCount counts how many orders a user has placed with a promotion
+func (r *SQLPromotionRepository) GetUserRedemptionCount(ctx context.Context, promotionId uuid.UUID, userId uuid.UUID) (int64, error) {
+	return r.DB.CountUserPromotionRedemptions(ctx, database.CountUserPromotionRedemptionsParams{
+		PromotionID: promotionId,
+		UserID:      userId,
+	})
+}
+
+// GetPromotionReport sums up the orders placed with a promotion. Revenue leaves out cancelled orders.
+func (r *SQLPromotionRepository) GetPromotionReport(ctx context.Context, promotionId uuid.UUID) (model.PromotionReport, error) {
+	promotion, err := r.DB.GetPromotion(ctx, promotionId)
+	if err != nil {
+		return model.PromotionReport{}, err
+	}
+
+	report, err := r.DB.GetPromotionReport(ctx, promotionId)
+	if err != nil {
+		log.Printf("Error building report for promotion %s: %s", promotion.Code, err.Error())
+		return model.PromotionReport{}, err
+	}
+
+	return model.PromotionReport{
+		PromotionID:   promotion.ID,
+		Code:          promotion.Code,
+		TimesUsed:     promotion.TimesUsed,
+		Redemptions:   report.Redemptions,
+		Customers:     report.Customers,
+		DiscountTotal: report.DiscountTotal,
+		Revenue:       report.Revenue,
+	}, nil
+}
+
+// withTargets attaches a promotion's targets to it
+func (r *SQLPromotionRepository) withTargets(ctx context.Context, promotion database.Promotion) (model.PromotionDetail, error) {
+	targets, err := r.DB.ListPromotionTargets(ctx, promotion.ID)
+	if err != nil {
+		return model.PromotionDetail{}, err
+	}
+
+	return model.PromotionDetail{
+		Promotion: toModelPromotion(promotion),
+		Targets:   toModelPromotionTargets(targets),
+	}, nil
+}
+
+func toModelPromotion(promotion database.Promotion) model.Promotion {
+	return model.Promotion{
+		ID:             promotion.ID,
+		Code:           promotion.Code,
+		Description:    promotion.Description,
+		DiscountType:   promotion.DiscountType,
+		DiscountValue:  promotion.DiscountValue,
+		MinCartValue:   promotion.MinCartValue,
+		StartsAt:       promotion.StartsAt,
+		EndsAt:         promotion.EndsAt,
+		MaxUses:        promotion.MaxUses,
+		MaxUsesPerUser: promotion.MaxUsesPerUser,
+		TimesUsed:      promotion.TimesUsed,
+		IsActive:       promotion.IsActive,
+		CreatedAt:      promotion.CreatedAt,
+		LastUpdated:    promotion.LastUpdated,
+	}
+}
+
+func toModelPromotionTargets(targets []database.PromotionTarget) []model.PromotionTarget {
+	modelTargets := make([]model.PromotionTarget, len(targets))
+	for i, target := range targets {
+		modelTargets[i] = model.PromotionTarget{
+			TargetType: target.TargetType,
+			TargetID:   target.TargetID,
+		}
+	}
+	return modelTargets
+}
+
+func toModelPromotionRedemption(redemption database.PromotionRedemption) model.PromotionRedemption {
+	return model.PromotionRedemption{
+		ID:             redemption.ID,
+		PromotionID:    redemption.PromotionID,
+		OrderID:        redemption.OrderID,
+		UserID:         redemption.UserID,
+		Code:           redemption.Code,
+		DiscountAmount: redemption.DiscountAmount,
+		CreatedAt:      redemption.CreatedAt,
+	}
+}
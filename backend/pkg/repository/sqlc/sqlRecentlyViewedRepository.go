@@ -0,0 +1,53 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type SQLRecentlyViewedRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLRecentlyViewedRepository(conn *sql.DB, db *database.Queries) *SQLRecentlyViewedRepository {
+	return &SQLRecentlyViewedRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// RecordProductView moves a product to the top of a user's recently viewed products, keeping only
+// the retain most recent
+func (r *SQLRecentlyViewedRepository) RecordProductView(ctx context.Context, userId uuid.UUID, productId uuid.UUID, retain int32) error {
+	return execTx(ctx, r.Conn, func(q *database.Queries) error {
+		if err := q.UpsertRecentlyViewedProduct(ctx, database.UpsertRecentlyViewedProductParams{
+			UserID:    userId,
+			ProductID: productId,
+		}); err != nil {
+			return err
+		}
+
+		return q.TrimRecentlyViewedProducts(ctx, database.TrimRecentlyViewedProductsParams{
+			UserID: userId,
+			Retain: retain,
+		})
+	})
+}
+
+// GetRecentlyViewedProducts gets a user's most recently viewed products that are still sold
+func (r *SQLRecentlyViewedRepository) GetRecentlyViewedProducts(ctx context.Context, userId uuid.UUID, limit int32) ([]model.Product, error) {
+	products, err := r.DB.ListRecentlyViewedProducts(ctx, database.ListRecentlyViewedProductsParams{
+		UserID: userId,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductModels(products)
+}
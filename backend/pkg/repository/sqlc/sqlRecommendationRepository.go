@@ -0,0 +1,177 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type SQLRecommendationRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLRecommendationRepository(conn *sql.DB, db *database.Queries) *SQLRecommendationRepository {
+	return &SQLRecommendationRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// RefreshCoPurchases recomputes which products are bought together from all orders that were not
+// cancelled, keeping the perProduct best scoring pairs of each product that share at least
+// minOrders orders. Readers keep seeing the previous scores until the refresh commits, and
+// concurrent refreshes run one after the other. It returns the number of pairs stored.
+func (r *SQLRecommendationRepository) RefreshCoPurchases(ctx context.Context, minOrders int32, perProduct int32) (int64, error) {
+	var pairs int64
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		if err := q.LockProductCoPurchases(ctx); err != nil {
+			return err
+		}
+
+		if err := q.DeleteProductCoPurchases(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		pairs, err = q.InsertProductCoPurchases(ctx, database.InsertProductCoPurchasesParams{
+			MinOrders:  minOrders,
+			PerProduct: perProduct,
+		})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return pairs, nil
+}
+
+// RefreshWishlistAffinities recomputes which products are saved to wishlists by the same users,
+// keeping the perProduct best scoring pairs of each product that at least minUsers users saved
+// both of. Like RefreshCoPurchases, readers keep seeing the previous scores until it commits. It
+// returns the number of pairs stored.
+func (r *SQLRecommendationRepository) RefreshWishlistAffinities(ctx context.Context, minUsers int32, perProduct int32) (int64, error) {
+	var pairs int64
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		if err := q.LockProductWishlistAffinities(ctx); err != nil {
+			return err
+		}
+
+		if err := q.DeleteProductWishlistAffinities(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		pairs, err = q.InsertProductWishlistAffinities(ctx, database.InsertProductWishlistAffinitiesParams{
+			MinUsers:   minUsers,
+			PerProduct: perProduct,
+		})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return pairs, nil
+}
+
+// GetCoPurchasedProducts gets the products most often bought together with a product, as of the
+// last refresh, leaving out any no longer sold or out of stock
+func (r *SQLRecommendationRepository) GetCoPurchasedProducts(ctx context.Context, productId uuid.UUID, limit int32) ([]model.Product, error) {
+	products, err := r.DB.ListCoPurchasedProducts(ctx, database.ListCoPurchasedProductsParams{
+		ProductID: productId,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductModels(products)
+}
+
+// GetSubCategoryBestsellers gets the best selling products of the last three months in a
+// sub-category, other than the given product, that are sold and in stock
+func (r *SQLRecommendationRepository) GetSubCategoryBestsellers(ctx context.Context, subCategoryId uuid.UUID, excludeProductId uuid.UUID, limit int32) ([]model.Product, error) {
+	products, err := r.DB.ListSubCategoryBestsellers(ctx, database.ListSubCategoryBestsellersParams{
+		SubCategoryID: uuid.NullUUID{UUID: subCategoryId, Valid: true},
+		ProductID:     excludeProductId,
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductModels(products)
+}
+
+// GetAlsoSavedProducts gets the products most often saved to wishlists by the users who saved a
+// product, as of the last refresh, leaving out any no longer sold or out of stock
+func (r *SQLRecommendationRepository) GetAlsoSavedProducts(ctx context.Context, productId uuid.UUID, limit int32) ([]model.Product, error) {
+	products, err := r.DB.ListAlsoSavedProducts(ctx, database.ListAlsoSavedProductsParams{
+		ProductID: productId,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductModels(products)
+}
+
+// GetUserRecommendedProducts gets the products most often saved together with the products a user
+// saved or recently viewed, weighing recent views more, leaving out products the user already
+// saved or bought
+func (r *SQLRecommendationRepository) GetUserRecommendedProducts(ctx context.Context, userId uuid.UUID, limit int32) ([]model.Product, error) {
+	products, err := r.DB.ListUserRecommendedProducts(ctx, database.ListUserRecommendedProductsParams{
+		UserID: userId,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductModels(products)
+}
+
+// GetPopularWishlistedProducts gets the products saved by the most users, other than those a user
+// already saved or bought. The products saved by more than one user come from the wishlist query
+// FindCommonWishlistLists; ties go to the better rated product.
+func (r *SQLRecommendationRepository) GetPopularWishlistedProducts(ctx context.Context, userId uuid.UUID, limit int32) ([]model.Product, error) {
+	common, err := r.DB.FindCommonWishlistLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(common) == 0 {
+		return []model.Product{}, nil
+	}
+
+	savedBy := make(map[uuid.UUID]int64, len(common))
+	productIds := make([]uuid.UUID, len(common))
+	for i, row := range common {
+		savedBy[row.ProductID] = row.UserCount
+		productIds[i] = row.ProductID
+	}
+
+	// products come back best rated first
+	products, err := r.DB.ListProductsNotSavedOrBought(ctx, database.ListProductsNotSavedOrBoughtParams{
+		ProductIds: productIds,
+		UserID:     userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(products, func(i, j int) bool {
+		return savedBy[products[i].ID] > savedBy[products[j].ID]
+	})
+	if int32(len(products)) > limit {
+		products = products[:limit]
+	}
+
+	return toProductModels(products)
+}
@@ -0,0 +1,469 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+type SQLRefundRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLRefundRepository(conn *sql.DB, db *database.Queries) *SQLRefundRepository {
+	return &SQLRefundRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// CreateRefund adds a pending refund to the order's ledger. Without an amount the refund covers
+// the given items, or everything left to refund when no items are given either. The order is
+// locked while the amount is checked against what is left, so refunds cannot add up past the total.
+// Refunds to the original payment method are further limited to what was charged to it, as the
+// part paid by gift cards and store credit can only go back as store credit.
+func (r *SQLRefundRepository) CreateRefund(ctx context.Context, params model.CreateRefundParams) (model.RefundDetail, error) {
+	var detail model.RefundDetail
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		order, err := q.LockOrder(ctx, params.OrderID)
+		if err != nil {
+			return err
+		}
+		if !refundablePaymentStatus(order.PaymentStatus) {
+			return repository.ErrOrderNotRefundable
+		}
+
+		var paymentId uuid.NullUUID
+		if params.Method == "original" {
+			payment, err := q.GetCapturedPayment(ctx, order.ID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return repository.ErrNoCapturedPayment
+			}
+			if err != nil {
+				return err
+			}
+			paymentId = uuid.NullUUID{UUID: payment.ID, Valid: true}
+		}
+
+		// price the items at what was paid for them
+		itemAmounts, itemsTotal, err := priceRefundItems(ctx, q, order.ID, params.Items)
+		if err != nil {
+			return err
+		}
+
+		// work out what is left to refund
+		remaining, err := orderRefundable(ctx, q, order)
+		if err != nil {
+			return err
+		}
+		if params.Method == "original" {
+			gatewayRemaining, err := gatewayRefundable(ctx, q, order)
+			if err != nil {
+				return err
+			}
+			remaining = remaining.Min(gatewayRemaining)
+		}
+
+		amount := remaining
+		if !params.Amount.IsZero() {
+			amount = params.Amount
+		} else if len(params.Items) > 0 {
+			amount = itemsTotal
+		}
+		if amount.Sign() <= 0 || amount.Cmp(remaining) > 0 {
+			return &repository.RefundExceedsError{
+				Requested: amount.String(),
+				Available: remaining.String(),
+			}
+		}
+
+		var settlementAmount sql.NullString
+		if params.Method == "original" {
+			settlementAmount, err = refundSettlementAmount(ctx, q, order, amount)
+			if err != nil {
+				return err
+			}
+		}
+
+		// record the refund and the items it covers
+		refund, err := q.CreateRefund(ctx, database.CreateRefundParams{
+			ID:               uuid.New(),
+			OrderID:          order.ID,
+			Amount:           amount.String(),
+			Reason:           sql.NullString{String: params.Reason, Valid: params.Reason != ""},
+			PaymentID:        paymentId,
+			CreatedBy:        uuid.NullUUID{UUID: params.CreatedBy, Valid: params.CreatedBy != uuid.Nil},
+			SettlementAmount: settlementAmount,
+			Method:           params.Method,
+		})
+		if err != nil {
+			return err
+		}
+
+		items := make([]model.RefundItem, len(params.Items))
+		for i, item := range params.Items {
+			refundItem, err := q.CreateRefundItem(ctx, database.CreateRefundItemParams{
+				ID:          uuid.New(),
+				RefundID:    refund.ID,
+				OrderItemID: item.OrderItemID,
+				Quantity:    item.Quantity,
+				Amount:      itemAmounts[i].String(),
+			})
+			if err != nil {
+				return err
+			}
+			items[i] = toModelRefundItem(refundItem)
+		}
+
+		detail = model.RefundDetail{
+			Refund: toModelRefund(refund),
+			Items:  items,
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error creating refund for order with id %s: %s", params.OrderID.String(), err.Error())
+		return model.RefundDetail{}, err
+	}
+
+	return detail, nil
+}
+
+// StartRefund claims a pending refund for processing and returns the payment it is refunded
+// against. Only one caller can claim a refund, so it cannot reach the gateway twice.
+func (r *SQLRefundRepository) StartRefund(ctx context.Context, refundId uuid.UUID) (model.Refund, model.Payment, error) {
+	var refund database.Refund
+	var payment database.Payment
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		var err error
+		refund, err = q.LockRefund(ctx, refundId)
+		if err != nil {
+			return err
+		}
+		if refund.Status != "pending" {
+			return &repository.InvalidTransitionError{
+				From: refund.Status,
+				To:   "processing",
+			}
+		}
+
+		payment, err = q.GetCapturedPayment(ctx, refund.OrderID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrNoCapturedPayment
+		}
+		if err != nil {
+			return err
+		}
+
+		refund, err = q.StartRefund(ctx, database.StartRefundParams{
+			ID:        refundId,
+			PaymentID: uuid.NullUUID{UUID: payment.ID, Valid: true},
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Error starting refund with id %s: %s", refundId.String(), err.Error())
+		return model.Refund{}, model.Payment{}, err
+	}
+
+	return toModelRefund(refund), toModelPayment(payment), nil
+}
+
+// FinishRefund records the gateway's answer and, for a successful refund, the order's new payment status
+func (r *SQLRefundRepository) FinishRefund(ctx context.Context, refundId uuid.UUID, succeeded bool, providerRefundId string, failureMessage string) (model.Refund, error) {
+	status := "failed"
+	if succeeded {
+		status = "succeeded"
+	}
+
+	var refund database.Refund
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		var err error
+		refund, err = q.FinishRefund(ctx, database.FinishRefundParams{
+			ID:               refundId,
+			Status:           status,
+			ProviderRefundID: sql.NullString{String: providerRefundId, Valid: providerRefundId != ""},
+			FailureMessage:   sql.NullString{String: failureMessage, Valid: failureMessage != ""},
+		})
+		if err != nil {
+			return err
+		}
+
+		if !succeeded {
+			return nil
+		}
+		return q.SyncOrderRefundStatus(ctx, refund.OrderID)
+	})
+	if err != nil {
+		log.Printf("Error finishing refund with id %s: %s", refundId.String(), err.Error())
+		return model.Refund{}, err
+	}
+
+	return toModelRefund(refund), nil
+}
+
+// CreditRefundToStoreCredit settles a pending store credit refund by adding its amount to the
+// customer's store credit, in the same transaction that marks it succeeded
+func (r *SQLRefundRepository) CreditRefundToStoreCredit(ctx context.Context, refundId uuid.UUID) (model.Refund, error) {
+	var refund database.Refund
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		var err error
+		refund, err = q.LockRefund(ctx, refundId)
+		if err != nil {
+			return err
+		}
+		if refund.Method != "store_credit" {
+			return fmt.Errorf("refund %s is not a store credit refund", refundId.String())
+		}
+		if refund.Status != "pending" {
+			return &repository.InvalidTransitionError{
+				From: refund.Status,
+				To:   "succeeded",
+			}
+		}
+
+		order, err := q.LockOrder(ctx, refund.OrderID)
+		if err != nil {
+			return err
+		}
+		_, err = recordStoreCredit(ctx, q, database.CreateStoreCreditTransactionParams{
+			UserID:   order.UserID,
+			Amount:   refund.Amount,
+			Reason:   "refund",
+			OrderID:  uuid.NullUUID{UUID: order.ID, Valid: true},
+			RefundID: uuid.NullUUID{UUID: refund.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		refund, err = q.FinishRefund(ctx, database.FinishRefundParams{
+			ID:     refundId,
+			Status: "succeeded",
+		})
+		if err != nil {
+			return err
+		}
+		return q.SyncOrderRefundStatus(ctx, refund.OrderID)
+	})
+	if err != nil {
+		log.Printf("Error crediting refund with id %s to store credit: %s", refundId.String(), err.Error())
+		return model.Refund{}, err
+	}
+
+	return toModelRefund(refund), nil
+}
+
+// GetRefund gets a refund by id
+func (r *SQLRefundRepository) GetRefund(ctx context.Context, refundId uuid.UUID) (model.Refund, error) {
+	refund, err := r.DB.GetRefund(ctx, refundId)
+	if err != nil {
+		return model.Refund{}, err
+	}
+
+	return toModelRefund(refund), nil
+}
+
+// GetOrderRefunds gets the refund ledger of an order, oldest first
+func (r *SQLRefundRepository) GetOrderRefunds(ctx context.Context, orderId uuid.UUID) ([]model.RefundDetail, error) {
+	refunds, err := r.DB.ListOrderRefunds(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]model.RefundDetail, len(refunds))
+	for i, refund := range refunds {
+		refundItems, err := r.DB.ListRefundItems(ctx, refund.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]model.RefundItem, len(refundItems))
+		for j, item := range refundItems {
+			items[j] = toModelRefundItem(item)
+		}
+		details[i] = model.RefundDetail{
+			Refund: toModelRefund(refund),
+			Items:  items,
+		}
+	}
+	return details, nil
+}
+
+// priceRefundItems checks the items against what is left to refund of each and prices them at
+// their share of the line total
+func priceRefundItems(ctx context.Context, q *database.Queries, orderId uuid.UUID, items []model.RefundItemParams) ([]money.Money, money.Money, error) {
+	total := money.Zero(money.DefaultCurrency)
+	if len(items) == 0 {
+		return nil, total, nil
+	}
+
+	orderItems, err := q.ListRefundableOrderItems(ctx, orderId)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+	refundable := make(map[uuid.UUID]database.ListRefundableOrderItemsRow, len(orderItems))
+	for _, item := range orderItems {
+		refundable[item.ID] = item
+	}
+
+	amounts := make([]money.Money, len(items))
+	for i, item := range items {
+		orderItem, ok := refundable[item.OrderItemID]
+		available := orderItem.Quantity - orderItem.RefundedQuantity
+		if !ok || item.Quantity > available {
+			return nil, money.Money{}, &repository.InvalidRefundItemError{
+				OrderItemID: item.OrderItemID,
+				Requested:   item.Quantity,
+				Refundable:  available,
+			}
+		}
+
+		lineTotal, err := money.FromDecimal(orderItem.TaxedPrice, money.DefaultCurrency)
+		if err != nil {
+			return nil, money.Money{}, err
+		}
+
+		// round each item so the items add up to the refund
+		amounts[i] = lineTotal.MulRat(big.NewRat(int64(item.Quantity), int64(orderItem.Quantity)))
+		total = total.Add(amounts[i])
+	}
+	return amounts, total, nil
+}
+
+// refundSettlementAmount works out what a refund to the original payment method gives back in
+// the currency the order settled in, at the exchange rate the order was placed at, rounded to the
+// cent. It must be called before the refund is recorded. The refund that clears what was charged
+// gives back whatever is left of the settlement total, so rounding never leaves money behind or
+// refunds more than was paid. Orders placed before settlement totals were recorded are refunded
+// as they were charged, in their total price.
+func refundSettlementAmount(ctx context.Context, q *database.Queries, order database.Order, amount money.Money) (sql.NullString, error) {
+	if !order.SettlementTotal.Valid {
+		return sql.NullString{}, nil
+	}
+
+	gatewayRemaining, err := gatewayRefundable(ctx, q, order)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	final := amount.Cmp(gatewayRemaining) >= 0
+
+	settlementTotal, err := money.FromDecimal(order.SettlementTotal.String, order.Currency)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	refundedSettlement, err := q.GetOrderRefundedSettlementAmount(ctx, order.ID)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	refunded, err := money.FromDecimal(refundedSettlement, order.Currency)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	remaining := settlementTotal.Sub(refunded)
+
+	settlement := remaining
+	if !final {
+		rate, err := money.ParseRate(order.ExchangeRate)
+		if err != nil {
+			return sql.NullString{}, err
+		}
+		converted := money.New(amount.MulRat(rate).Cents(), order.Currency)
+		settlement = converted.Min(remaining)
+	}
+	if settlement.Sign() < 0 {
+		settlement = money.Zero(order.Currency)
+	}
+
+	return sql.NullString{String: settlement.String(), Valid: true}, nil
+}
+
+// orderRefundable works out how much of an order is left to refund: its total less what was
+// already refunded, or is being refunded, by any method
+func orderRefundable(ctx context.Context, q *database.Queries, order database.Order) (money.Money, error) {
+	total, err := money.FromDecimal(order.TotalPrice, money.DefaultCurrency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	refundedAmount, err := q.GetOrderRefundedAmount(ctx, order.ID)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return lessRefunded(total, refundedAmount)
+}
+
+// refundablePaymentStatus reports whether an order with the payment status was paid and so can
+// be refunded
+func refundablePaymentStatus(status string) bool {
+	switch status {
+	case "paid", "refund_pending", "partially_refunded":
+		return true
+	}
+	return false
+}
+
+// gatewayRefundable works out how much of an order can still go back through the payment
+// gateway: what was left to pay after gift cards and store credit, less what was already refunded
+// that way
+func gatewayRefundable(ctx context.Context, q *database.Queries, order database.Order) (money.Money, error) {
+	charged, err := gatewayCharged(order)
+	if err != nil {
+		return money.Money{}, err
+	}
+	refundedAmount, err := q.GetOrderRefundedAmountByMethod(ctx, database.GetOrderRefundedAmountByMethodParams{
+		OrderID: order.ID,
+		Method:  "original",
+	})
+	if err != nil {
+		return money.Money{}, err
+	}
+	return lessRefunded(charged, refundedAmount)
+}
+
+// gatewayCharged works out what the payment method was charged for an order: its total less what
+// gift cards and store credit paid
+func gatewayCharged(order database.Order) (money.Money, error) {
+	charged, err := money.FromDecimal(order.TotalPrice, money.DefaultCurrency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	for _, tender := range []string{order.GiftCardAmount, order.StoreCreditAmount} {
+		amount, err := money.FromDecimal(tender, money.DefaultCurrency)
+		if err != nil {
+			return money.Money{}, err
+		}
+		charged = charged.Sub(amount)
+	}
+	return charged, nil
+}
+
+// lessRefunded takes an already refunded amount, as Postgres formats it, off what could be refunded
+func lessRefunded(refundable money.Money, refundedAmount string) (money.Money, error) {
+	refunded, err := money.FromDecimal(refundedAmount, money.DefaultCurrency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return refundable.Sub(refunded), nil
+}
+
+func toModelRefundItem(item database.RefundItem) model.RefundItem {
+	amount, _ := money.FromDecimal(item.Amount, money.DefaultCurrency)
+	return model.RefundItem{
+		ID:          item.ID,
+		RefundID:    item.RefundID,
+		OrderItemID: item.OrderItemID,
+		Quantity:    item.Quantity,
+		Amount:      amount,
+		CreatedAt:   item.CreatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package sqlc
+
+import (
+	"testing"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+)
+
+func TestGatewayCharged(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       string
+		giftCard    string
+		storeCredit string
+		want        int64
+		wantErr     bool
+	}{
+		{"card only", "120.50", "0.00", "0.00", 12050, false},
+		{"gift card paid part", "120.50", "20.00", "0.00", 10050, false},
+		{"gift card and store credit paid part", "120.50", "20.00", "0.50", 10000, false},
+		{"tenders paid it all", "45.00", "40.00", "5.00", 0, false},
+		{"bad total", "", "0.00", "0.00", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := gatewayCharged(database.Order{
+				TotalPrice:        tt.total,
+				GiftCardAmount:    tt.giftCard,
+				StoreCreditAmount: tt.storeCredit,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("gatewayCharged() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Cents() != tt.want {
+				t.Errorf("gatewayCharged() = %d cents, want %d", got.Cents(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLessRefunded(t *testing.T) {
+	tests := []struct {
+		name       string
+		refundable int64
+		refunded   string
+		want       int64
+		wantErr    bool
+	}{
+		{"nothing refunded", 10000, "0", 10000, false},
+		{"partly refunded", 10000, "25.50", 7450, false},
+		{"fully refunded", 10000, "100.00", 0, false},
+		{"over refunded goes below zero", 10000, "100.01", -1, false},
+		{"bad refunded amount", 10000, "abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lessRefunded(money.New(tt.refundable, money.DefaultCurrency), tt.refunded)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("lessRefunded() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Cents() != tt.want {
+				t.Errorf("lessRefunded() = %d cents, want %d", got.Cents(), tt.want)
+			}
+		})
+	}
+}
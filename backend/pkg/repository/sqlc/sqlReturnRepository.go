@@ -0,0 +1,440 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+type SQLReturnRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLReturnRepository(conn *sql.DB, db *database.Queries) *SQLReturnRepository {
+	return &SQLReturnRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// CreateReturnRequest opens a return for items of a delivered order. The order is locked while the
+// quantities are checked, so two requests cannot return the same items between them.
+func (r *SQLReturnRepository) CreateReturnRequest(ctx context.Context, params model.CreateReturnRequestParams) (model.ReturnRequestDetail, error) {
+	var detail model.ReturnRequestDetail
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		order, err := q.LockOrder(ctx, params.OrderID)
+		if err != nil {
+			return err
+		}
+
+		// customers may only return their own orders
+		if order.UserID != params.UserID {
+			return sql.ErrNoRows
+		}
+		if order.OrderStatus != "delivered" {
+			return repository.ErrOrderNotDelivered
+		}
+
+		// orders delivered before status history was kept fall back to their last update
+		deliveredAt, err := q.GetOrderDeliveredAt(ctx, order.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			deliveredAt = order.CreatedAt
+			if order.LastUpdated.Valid {
+				deliveredAt = order.LastUpdated.Time
+			}
+		} else if err != nil {
+			return err
+		}
+		if deliveredAt.Before(params.DeliveredAfter) {
+			return repository.ErrReturnWindowClosed
+		}
+
+		// check every item against what is left to return
+		orderItems, err := q.ListReturnableOrderItems(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		returnable := make(map[uuid.UUID]database.ListReturnableOrderItemsRow, len(orderItems))
+		for _, item := range orderItems {
+			returnable[item.ID] = item
+		}
+		for _, item := range params.Items {
+			orderItem, ok := returnable[item.OrderItemID]
+			available := orderItem.Quantity - orderItem.ReturnedQuantity
+			if !ok || item.Quantity > available {
+				return &repository.InvalidReturnItemError{
+					OrderItemID: item.OrderItemID,
+					Requested:   item.Quantity,
+					Returnable:  available,
+				}
+			}
+		}
+
+		// create the return request
+		returnRequest, err := q.CreateReturnRequest(ctx, database.CreateReturnRequestParams{
+			ID:      uuid.New(),
+			OrderID: order.ID,
+			UserID:  params.UserID,
+			Reason:  params.Reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		items := make([]model.ReturnRequestItem, len(params.Items))
+		for i, item := range params.Items {
+			returnItem, err := q.CreateReturnRequestItem(ctx, database.CreateReturnRequestItemParams{
+				ID:              uuid.New(),
+				ReturnRequestID: returnRequest.ID,
+				OrderItemID:     item.OrderItemID,
+				Quantity:        item.Quantity,
+			})
+			if err != nil {
+				return err
+			}
+			items[i] = model.ReturnRequestItem{
+				ID:              returnItem.ID,
+				ReturnRequestID: returnItem.ReturnRequestID,
+				OrderItemID:     returnItem.OrderItemID,
+				ProductID:       returnable[item.OrderItemID].ProductID,
+				Quantity:        returnItem.Quantity,
+				CreatedAt:       returnItem.CreatedAt,
+			}
+		}
+
+		detail = model.ReturnRequestDetail{
+			ReturnRequest: toModelReturnRequest(returnRequest),
+			Items:         items,
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error creating return request for order with id %s: %s", params.OrderID.String(), err.Error())
+		return model.ReturnRequestDetail{}, err
+	}
+
+	return detail, nil
+}
+
+// ReviewReturnRequest approves or rejects a requested return
+func (r *SQLReturnRepository) ReviewReturnRequest(ctx context.Context, returnId uuid.UUID, status string, adminNote string) (model.ReturnRequest, error) {
+	var reviewedRequest database.ReturnRequest
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		returnRequest, err := q.LockReturnRequest(ctx, returnId)
+		if err != nil {
+			return err
+		}
+
+		// nothing to do if it has already been reviewed the same way
+		if returnRequest.Status == status {
+			reviewedRequest = returnRequest
+			return nil
+		}
+		if returnRequest.Status != "requested" {
+			return &repository.InvalidTransitionError{
+				From: returnRequest.Status,
+				To:   status,
+			}
+		}
+
+		reviewedRequest, err = q.UpdateReturnRequestStatus(ctx, database.UpdateReturnRequestStatusParams{
+			Status:    status,
+			AdminNote: sql.NullString{String: adminNote, Valid: adminNote != ""},
+			ID:        returnId,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Error reviewing return request with id %s: %s", returnId.String(), err.Error())
+		return model.ReturnRequest{}, err
+	}
+
+	return toModelReturnRequest(reviewedRequest), nil
+}
+
+// ReceiveReturnRequest marks the goods of an approved return as received, puts them back in stock
+// and records the refund that is owed, if any. Receiving the same return twice changes nothing.
+func (r *SQLReturnRepository) ReceiveReturnRequest(ctx context.Context, returnId uuid.UUID, adminNote string) (model.ReturnRequestDetail, error) {
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		returnRequest, err := q.LockReturnRequest(ctx, returnId)
+		if err != nil {
+			return err
+		}
+
+		if returnRequest.Status == "received" {
+			return nil
+		}
+		if returnRequest.Status != "approved" {
+			return &repository.InvalidTransitionError{
+				From: returnRequest.Status,
+				To:   "received",
+			}
+		}
+
+		// put the items back in stock
+		if err := q.RestockReturnRequestItems(ctx, returnId); err != nil {
+			return err
+		}
+
+		// refund what was paid for the returned items
+		if err := createReturnRefund(ctx, q, returnRequest); err != nil {
+			return err
+		}
+
+		_, err = q.UpdateReturnRequestStatus(ctx, database.UpdateReturnRequestStatusParams{
+			Status:    "received",
+			AdminNote: sql.NullString{String: adminNote, Valid: adminNote != ""},
+			ID:        returnId,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Error receiving return request with id %s: %s", returnId.String(), err.Error())
+		return model.ReturnRequestDetail{}, err
+	}
+
+	return r.GetReturnRequest(ctx, returnId)
+}
+
+// createReturnRefund records the refund owed for a received return, with the returned items it
+// covers: what was paid for the returned units not already refunded, but no more than is left to
+// refund of the order. The order is locked while that is worked out, as in CreateRefund. Nothing
+// is owed, and no refund is recorded, when the order was never paid or has already been refunded
+// in full.
+func createReturnRefund(ctx context.Context, q *database.Queries, returnRequest database.ReturnRequest) error {
+	order, err := q.LockOrder(ctx, returnRequest.OrderID)
+	if err != nil {
+		return err
+	}
+	if !refundablePaymentStatus(order.PaymentStatus) {
+		return nil
+	}
+
+	// price the returned units at what was paid for them
+	items, err := returnRefundItems(ctx, q, returnRequest.ID, order.ID)
+	if err != nil {
+		return err
+	}
+	itemAmounts, refundAmount, err := priceRefundItems(ctx, q, order.ID, items)
+	if err != nil {
+		return err
+	}
+	remaining, err := orderRefundable(ctx, q, order)
+	if err != nil {
+		return err
+	}
+	refundAmount = refundAmount.Min(remaining)
+	if refundAmount.Sign() <= 0 {
+		return nil
+	}
+
+	// money goes back to the card it came from, or to store credit when the card did not pay
+	// enough of the order to cover it
+	method := "store_credit"
+	var settlementAmount sql.NullString
+	_, err = q.GetCapturedPayment(ctx, order.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if err == nil {
+		gatewayRemaining, err := gatewayRefundable(ctx, q, order)
+		if err != nil {
+			return err
+		}
+		if refundAmount.Cmp(gatewayRemaining) <= 0 {
+			method = "original"
+			settlementAmount, err = refundSettlementAmount(ctx, q, order, refundAmount)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// record the refund and the items it covers
+	refund, err := q.CreateRefund(ctx, database.CreateRefundParams{
+		ID:               uuid.New(),
+		OrderID:          order.ID,
+		ReturnRequestID:  uuid.NullUUID{UUID: returnRequest.ID, Valid: true},
+		Amount:           refundAmount.String(),
+		Reason:           sql.NullString{String: returnRequest.Reason, Valid: true},
+		SettlementAmount: settlementAmount,
+		Method:           method,
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, item := range items {
+		_, err := q.CreateRefundItem(ctx, database.CreateRefundItemParams{
+			ID:          uuid.New(),
+			RefundID:    refund.ID,
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+			Amount:      itemAmounts[i].String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returnRefundItems lists the returned units of each order item that have not been refunded
+// already, so units refunded before they were returned are not refunded twice
+func returnRefundItems(ctx context.Context, q *database.Queries, returnId uuid.UUID, orderId uuid.UUID) ([]model.RefundItemParams, error) {
+	returnItems, err := q.ListReturnRequestItems(ctx, returnId)
+	if err != nil {
+		return nil, err
+	}
+	orderItems, err := q.ListRefundableOrderItems(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	available := make(map[uuid.UUID]int32, len(orderItems))
+	for _, item := range orderItems {
+		available[item.ID] = item.Quantity - item.RefundedQuantity
+	}
+
+	var items []model.RefundItemParams
+	for _, item := range returnItems {
+		quantity := item.Quantity
+		if quantity > available[item.OrderItemID] {
+			quantity = available[item.OrderItemID]
+		}
+		if quantity <= 0 {
+			continue
+		}
+		available[item.OrderItemID] -= quantity
+		items = append(items, model.RefundItemParams{
+			OrderItemID: item.OrderItemID,
+			Quantity:    quantity,
+		})
+	}
+	return items, nil
+}
+
+// GetReturnRequest gets a return request with its items and refund
+func (r *SQLReturnRepository) GetReturnRequest(ctx context.Context, returnId uuid.UUID) (model.ReturnRequestDetail, error) {
+	returnRequest, err := r.DB.GetReturnRequestByID(ctx, returnId)
+	if err != nil {
+		return model.ReturnRequestDetail{}, err
+	}
+
+	return r.getReturnRequestDetail(ctx, returnRequest)
+}
+
+// GetOrderReturnRequests gets the return requests of an order, oldest first
+func (r *SQLReturnRepository) GetOrderReturnRequests(ctx context.Context, orderId uuid.UUID) ([]model.ReturnRequestDetail, error) {
+	returnRequests, err := r.DB.ListOrderReturnRequests(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]model.ReturnRequestDetail, len(returnRequests))
+	for i, returnRequest := range returnRequests {
+		details[i], err = r.getReturnRequestDetail(ctx, returnRequest)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return details, nil
+}
+
+// GetReturnRequestsByStatus gets a page of return requests in the given status, oldest first
+func (r *SQLReturnRepository) GetReturnRequestsByStatus(ctx context.Context, status string, offset int32, limit int32) (interface{}, error) {
+	returnRequests, err := r.DB.ListReturnRequestsByStatus(ctx, database.ListReturnRequestsByStatusParams{
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	modelRequests := make([]model.ReturnRequest, len(returnRequests))
+	for i, returnRequest := range returnRequests {
+		modelRequests[i] = toModelReturnRequest(returnRequest)
+	}
+	return modelRequests, nil
+}
+
+// GetReturnRequestCountByStatus counts the return requests in the given status
+func (r *SQLReturnRepository) GetReturnRequestCountByStatus(ctx context.Context, status string) (int64, error) {
+	return r.DB.CountReturnRequestsByStatus(ctx, status)
+}
+
+func (r *SQLReturnRepository) getReturnRequestDetail(ctx context.Context, returnRequest database.ReturnRequest) (model.ReturnRequestDetail, error) {
+	returnItems, err := r.DB.ListReturnRequestItems(ctx, returnRequest.ID)
+	if err != nil {
+		return model.ReturnRequestDetail{}, err
+	}
+
+	items := make([]model.ReturnRequestItem, len(returnItems))
+	for i, item := range returnItems {
+		items[i] = model.ReturnRequestItem{
+			ID:              item.ID,
+			ReturnRequestID: item.ReturnRequestID,
+			OrderItemID:     item.OrderItemID,
+			ProductID:       item.ProductID,
+			Quantity:        item.Quantity,
+			CreatedAt:       item.CreatedAt,
+		}
+	}
+
+	detail := model.ReturnRequestDetail{
+		ReturnRequest: toModelReturnRequest(returnRequest),
+		Items:         items,
+	}
+
+	// only received returns have a refund
+	refund, err := r.DB.GetRefundByReturnRequest(ctx, uuid.NullUUID{UUID: returnRequest.ID, Valid: true})
+	if err == nil {
+		modelRefund := toModelRefund(refund)
+		detail.Refund = &modelRefund
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return model.ReturnRequestDetail{}, err
+	}
+
+	return detail, nil
+}
+
+func toModelReturnRequest(returnRequest database.ReturnRequest) model.ReturnRequest {
+	return model.ReturnRequest{
+		ID:          returnRequest.ID,
+		OrderID:     returnRequest.OrderID,
+		UserID:      returnRequest.UserID,
+		Status:      returnRequest.Status,
+		Reason:      returnRequest.Reason,
+		AdminNote:   returnRequest.AdminNote,
+		CreatedAt:   returnRequest.CreatedAt,
+		LastUpdated: returnRequest.LastUpdated,
+	}
+}
+
+func toModelRefund(refund database.Refund) model.Refund {
+	amount, _ := money.FromDecimal(refund.Amount, money.DefaultCurrency)
+	return model.Refund{
+		ID:               refund.ID,
+		OrderID:          refund.OrderID,
+		ReturnRequestID:  refund.ReturnRequestID,
+		Amount:           amount,
+		Status:           refund.Status,
+		Reason:           refund.Reason,
+		CreatedAt:        refund.CreatedAt,
+		LastUpdated:      refund.LastUpdated,
+		PaymentID:        refund.PaymentID,
+		ProviderRefundID: refund.ProviderRefundID,
+		FailureMessage:   refund.FailureMessage,
+		CreatedBy:        refund.CreatedBy,
+		SettlementAmount: refund.SettlementAmount,
+		Method:           refund.Method,
+	}
+}
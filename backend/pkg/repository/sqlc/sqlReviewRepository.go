@@ -0,0 +1,422 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+// maxFlaggedWordsLength is the size of the flagged_words column
+const maxFlaggedWordsLength = 255
+
+type SQLReviewRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLReviewRepository(conn *sql.DB, db *database.Queries) *SQLReviewRepository {
+	return &SQLReviewRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// CreateReview adds a user's review of a product, pending moderation, and marks it as a verified
+// purchase if the user has had the product delivered. sql.ErrNoRows is returned if the product
+// does not exist or is no longer sold, and repository.ErrAlreadyReviewed if the user has reviewed
+// it before.
+func (r *SQLReviewRepository) CreateReview(ctx context.Context, params model.SaveReviewParams) (model.ProductReview, error) {
+	var review database.Review
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		// lock the product, so concurrent reviews recalculate its rating one after the other
+		isActive, err := q.LockReviewedProduct(ctx, params.ProductID)
+		if err != nil {
+			return err
+		}
+		if !isActive {
+			return sql.ErrNoRows
+		}
+
+		verified, err := q.HasReceivedProduct(ctx, database.HasReceivedProductParams{
+			UserID:    params.UserID,
+			ProductID: params.ProductID,
+		})
+		if err != nil {
+			return err
+		}
+
+		review, err = q.CreateReview(ctx, database.CreateReviewParams{
+			ID:               uuid.New(),
+			UserID:           params.UserID,
+			ProductID:        params.ProductID,
+			Rating:           strconv.Itoa(int(params.Rating)),
+			Comment:          sql.NullString{String: params.Comment, Valid: params.Comment != ""},
+			VerifiedPurchase: verified,
+			FlaggedWords:     toNullFlaggedWords(params.FlaggedWords),
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrAlreadyReviewed
+		}
+		if err != nil {
+			return err
+		}
+
+		return q.RefreshProductRating(ctx, params.ProductID)
+	})
+	if err != nil {
+		log.Printf("Error reviewing product with id %s: %s", params.ProductID.String(), err.Error())
+		return model.ProductReview{}, err
+	}
+
+	return toModelReview(review)
+}
+
+// UpdateReview changes the rating and comment of a user's review, which sends it back for
+// moderation, and recalculates the product's rating. sql.ErrNoRows is returned if the user has no
+// such review.
+func (r *SQLReviewRepository) UpdateReview(ctx context.Context, reviewId uuid.UUID, params model.SaveReviewParams) (model.ProductReview, error) {
+	var review database.Review
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		current, err := lockReviewProduct(ctx, q, reviewId)
+		if err != nil {
+			return err
+		}
+
+		verified, err := q.HasReceivedProduct(ctx, database.HasReceivedProductParams{
+			UserID:    params.UserID,
+			ProductID: current.ProductID,
+		})
+		if err != nil {
+			return err
+		}
+
+		review, err = q.UpdateReview(ctx, database.UpdateReviewParams{
+			ID:               reviewId,
+			UserID:           params.UserID,
+			Rating:           strconv.Itoa(int(params.Rating)),
+			Comment:          sql.NullString{String: params.Comment, Valid: params.Comment != ""},
+			VerifiedPurchase: verified,
+			FlaggedWords:     toNullFlaggedWords(params.FlaggedWords),
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.RefreshProductRating(ctx, current.ProductID)
+	})
+	if err != nil {
+		return model.ProductReview{}, err
+	}
+
+	return toModelReview(review)
+}
+
+// ModerateReview approves or rejects a review and recalculates the product's rating.
+// sql.ErrNoRows is returned if there is no such review.
+func (r *SQLReviewRepository) ModerateReview(ctx context.Context, params model.ModerateReviewParams) (model.ProductReview, error) {
+	var review database.Review
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		current, err := lockReviewProduct(ctx, q, params.ReviewID)
+		if err != nil {
+			return err
+		}
+
+		review, err = q.ModerateReview(ctx, database.ModerateReviewParams{
+			ID:          params.ReviewID,
+			Status:      params.Status,
+			ModeratedBy: uuid.NullUUID{UUID: params.ModeratedBy, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.RefreshProductRating(ctx, current.ProductID)
+	})
+	if err != nil {
+		log.Printf("Error moderating review with id %s: %s", params.ReviewID.String(), err.Error())
+		return model.ProductReview{}, err
+	}
+
+	return toModelReview(review)
+}
+
+// DeleteReview deletes a user's review and recalculates the product's rating. sql.ErrNoRows is
+// returned if the user has no such review.
+func (r *SQLReviewRepository) DeleteReview(ctx context.Context, userId uuid.UUID, reviewId uuid.UUID) error {
+	return execTx(ctx, r.Conn, func(q *database.Queries) error {
+		current, err := lockReviewProduct(ctx, q, reviewId)
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.DeleteReview(ctx, database.DeleteReviewParams{
+			ID:     reviewId,
+			UserID: userId,
+		}); err != nil {
+			return err
+		}
+
+		return q.RefreshProductRating(ctx, current.ProductID)
+	})
+}
+
+// VoteReview records whether a user found a published review helpful, replacing any vote they
+// made before. sql.ErrNoRows is returned if there is no such published review, and
+// repository.ErrOwnReview if it is the user's own.
+func (r *SQLReviewRepository) VoteReview(ctx context.Context, params model.ReviewVoteParams) error {
+	if _, err := getPublishedReview(ctx, r.DB, params.ReviewID, params.UserID); err != nil {
+		return err
+	}
+
+	return r.DB.UpsertReviewVote(ctx, database.UpsertReviewVoteParams{
+		ReviewID: params.ReviewID,
+		UserID:   params.UserID,
+		Helpful:  params.Helpful,
+	})
+}
+
+// DeleteReviewVote withdraws a user's vote on a review. sql.ErrNoRows is returned if they have
+// not voted on it.
+func (r *SQLReviewRepository) DeleteReviewVote(ctx context.Context, userId uuid.UUID, reviewId uuid.UUID) error {
+	rows, err := r.DB.DeleteReviewVote(ctx, database.DeleteReviewVoteParams{
+		ReviewID: reviewId,
+		UserID:   userId,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ReportReview records a user's report of a published review as abusive. Once the review has
+// threshold reports since it was last moderated, it is taken down and goes back to the moderation
+// queue. sql.ErrNoRows is returned if there is no such published review, repository.ErrOwnReview
+// if it is the user's own and repository.ErrAlreadyReported if they have reported it before.
+func (r *SQLReviewRepository) ReportReview(ctx context.Context, params model.ReportReviewParams, threshold int64) error {
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		review, err := getPublishedReview(ctx, q, params.ReviewID, params.UserID)
+		if err != nil {
+			return err
+		}
+
+		// lock the product, as taking the review down changes its rating
+		if _, err := q.LockReviewedProduct(ctx, review.ProductID); err != nil {
+			return err
+		}
+
+		_, err = q.CreateReviewReport(ctx, database.CreateReviewReportParams{
+			ID:       uuid.New(),
+			ReviewID: params.ReviewID,
+			UserID:   params.UserID,
+			Reason:   sql.NullString{String: params.Reason, Valid: params.Reason != ""},
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrAlreadyReported
+		}
+		if err != nil {
+			return err
+		}
+
+		// send the review back for moderation once enough people have reported it
+		reports, err := q.CountOpenReviewReports(ctx, params.ReviewID)
+		if err != nil {
+			return err
+		}
+		if reports < threshold {
+			return nil
+		}
+
+		_, err = q.RequeueReview(ctx, params.ReviewID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// already taken down by another report
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		log.Printf("Review with id %s returned to moderation after %d reports", params.ReviewID.String(), reports)
+
+		return q.RefreshProductRating(ctx, review.ProductID)
+	})
+	if err != nil {
+		log.Printf("Error reporting review with id %s: %s", params.ReviewID.String(), err.Error())
+	}
+	return err
+}
+
+// GetProductReviews gets a page of a product's approved reviews, newest first, or by helpfulness
+// or highest or lowest rating and then newest
+func (r *SQLReviewRepository) GetProductReviews(ctx context.Context, productId uuid.UUID, sort string, offset int32, limit int32) (interface{}, error) {
+	reviews, err := r.DB.ListProductReviews(ctx, database.ListProductReviewsParams{
+		ProductID: productId,
+		Sort:      sort,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	modelReviews := make([]model.ProductReview, len(reviews))
+	for i, review := range reviews {
+		modelReview, err := toModelReview(database.Review{
+			ID:               review.ID,
+			UserID:           review.UserID,
+			ProductID:        review.ProductID,
+			Rating:           review.Rating,
+			Comment:          review.Comment,
+			CreatedAt:        review.CreatedAt,
+			LastUpdated:      review.LastUpdated,
+			Status:           review.Status,
+			VerifiedPurchase: review.VerifiedPurchase,
+		})
+		if err != nil {
+			return nil, err
+		}
+		modelReview.Username = review.Username
+		modelReview.HelpfulCount = review.HelpfulCount
+		modelReview.UnhelpfulCount = review.UnhelpfulCount
+		modelReviews[i] = modelReview
+	}
+	return modelReviews, nil
+}
+
+// GetProductReviewCount counts a product's approved reviews
+func (r *SQLReviewRepository) GetProductReviewCount(ctx context.Context, productId uuid.UUID) (int64, error) {
+	return r.DB.CountProductReviews(ctx, productId)
+}
+
+// GetModerationReviews gets a page of the reviews with a status, flagged or reported ones first
+// and then oldest first
+func (r *SQLReviewRepository) GetModerationReviews(ctx context.Context, status string, flaggedOnly bool, offset int32, limit int32) (interface{}, error) {
+	reviews, err := r.DB.ListModerationReviews(ctx, database.ListModerationReviewsParams{
+		Status:      status,
+		FlaggedOnly: flaggedOnly,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]model.ReviewModerationItem, len(reviews))
+	for i, review := range reviews {
+		modelReview, err := toModelReview(database.Review{
+			ID:               review.ID,
+			UserID:           review.UserID,
+			ProductID:        review.ProductID,
+			Rating:           review.Rating,
+			Comment:          review.Comment,
+			CreatedAt:        review.CreatedAt,
+			LastUpdated:      review.LastUpdated,
+			Status:           review.Status,
+			VerifiedPurchase: review.VerifiedPurchase,
+		})
+		if err != nil {
+			return nil, err
+		}
+		modelReview.Username = review.Username
+
+		items[i] = model.ReviewModerationItem{
+			ProductReview: modelReview,
+			ProductName:   review.ProductName,
+			FlaggedWords:  fromNullFlaggedWords(review.FlaggedWords),
+			ReportCount:   review.ReportCount,
+			ModeratedBy:   review.ModeratedBy,
+			ModeratedAt:   review.ModeratedAt,
+		}
+	}
+	return items, nil
+}
+
+// GetModerationReviewCount counts the reviews with a status
+func (r *SQLReviewRepository) GetModerationReviewCount(ctx context.Context, status string, flaggedOnly bool) (int64, error) {
+	return r.DB.CountModerationReviews(ctx, database.CountModerationReviewsParams{
+		Status:      status,
+		FlaggedOnly: flaggedOnly,
+	})
+}
+
+// lockReviewProduct locks the product a review is of and returns the review. sql.ErrNoRows is
+// returned if there is no such review.
+func lockReviewProduct(ctx context.Context, q *database.Queries, reviewId uuid.UUID) (database.Review, error) {
+	review, err := q.GetReview(ctx, reviewId)
+	if err != nil {
+		return database.Review{}, err
+	}
+	if _, err := q.LockReviewedProduct(ctx, review.ProductID); err != nil {
+		return database.Review{}, err
+	}
+	return review, nil
+}
+
+// getPublishedReview gets an approved review that someone other than its author is voting on or
+// reporting. sql.ErrNoRows is returned if there is no such approved review.
+func getPublishedReview(ctx context.Context, q *database.Queries, reviewId uuid.UUID, userId uuid.UUID) (database.Review, error) {
+	review, err := q.GetReview(ctx, reviewId)
+	if err != nil {
+		return database.Review{}, err
+	}
+	if review.Status != model.ReviewStatusApproved {
+		return database.Review{}, sql.ErrNoRows
+	}
+	if review.UserID == userId {
+		return database.Review{}, repository.ErrOwnReview
+	}
+	return review, nil
+}
+
+// toNullFlaggedWords joins the banned words found in a review for the flagged_words column,
+// leaving out any that do not fit
+func toNullFlaggedWords(words []string) sql.NullString {
+	var joined string
+	for _, word := range words {
+		next := word
+		if joined != "" {
+			next = joined + "," + word
+		}
+		if len(next) > maxFlaggedWordsLength {
+			break
+		}
+		joined = next
+	}
+	return sql.NullString{String: joined, Valid: joined != ""}
+}
+
+func fromNullFlaggedWords(words sql.NullString) []string {
+	if !words.Valid {
+		return []string{}
+	}
+	return strings.Split(words.String, ",")
+}
+
+func toModelReview(review database.Review) (model.ProductReview, error) {
+	rating, err := strconv.ParseFloat(review.Rating, 32)
+	if err != nil {
+		return model.ProductReview{}, fmt.Errorf("invalid rating %q on review %s: %w", review.Rating, review.ID.String(), err)
+	}
+
+	return model.ProductReview{
+		ID:               review.ID,
+		UserID:           review.UserID,
+		ProductID:        review.ProductID,
+		Rating:           float32(rating),
+		Comment:          review.Comment.String,
+		Status:           review.Status,
+		VerifiedPurchase: review.VerifiedPurchase,
+		CreatedAt:        review.CreatedAt,
+		LastUpdated:      review.LastUpdated,
+	}, nil
+}
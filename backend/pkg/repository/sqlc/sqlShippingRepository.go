@@ -0,0 +1,213 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+type SQLShippingRepository struct {
+	DB *database.Queries
+}
+
+func NewSQLShippingRepository(db *database.Queries) *SQLShippingRepository {
+	return &SQLShippingRepository{
+		DB: db,
+	}
+}
+
+// CreateShippingZone creates a shipping zone. sql.ErrNoRows is returned if the country and postal
+// code prefix already have a zone.
+func (r *SQLShippingRepository) CreateShippingZone(ctx context.Context, params model.CreateShippingZoneParams) (model.ShippingZone, error) {
+	zone, err := r.DB.CreateShippingZone(ctx, database.CreateShippingZoneParams{
+		ID:                    uuid.New(),
+		Name:                  params.Name,
+		Country:               params.Country,
+		PostalCodePrefix:      params.PostalCodePrefix,
+		FreeShippingThreshold: params.FreeShippingThreshold,
+	})
+	if err != nil {
+		log.Printf("Error creating shipping zone %s: %s", params.Name, err.Error())
+		return model.ShippingZone{}, err
+	}
+
+	return toModelShippingZone(zone), nil
+}
+
+// CreateShippingRate adds a rate to a zone. sql.ErrNoRows is returned if the zone does not exist.
+func (r *SQLShippingRepository) CreateShippingRate(ctx context.Context, params model.CreateShippingRateParams) (model.ShippingRate, error) {
+	rate, err := r.DB.CreateShippingRate(ctx, database.CreateShippingRateParams{
+		ID:             uuid.New(),
+		ZoneID:         params.ZoneID,
+		Method:         params.Method,
+		Name:           params.Name,
+		MinWeightGrams: params.MinWeightGrams,
+		MaxWeightGrams: params.MaxWeightGrams,
+		Price:          params.Price.String(),
+		EstimatedDays:  params.EstimatedDays,
+	})
+	if err != nil {
+		log.Printf("Error creating shipping rate for zone %s: %s", params.ZoneID.String(), err.Error())
+		return model.ShippingRate{}, err
+	}
+
+	return toModelShippingRate(rate), nil
+}
+
+// UpdateProductShipping sets the weight and dimensions a product ships at
+func (r *SQLShippingRepository) UpdateProductShipping(ctx context.Context, productId uuid.UUID, params model.UpdateProductShippingParams) error {
+	rows, err := r.DB.UpdateProductShipping(ctx, database.UpdateProductShippingParams{
+		ID:          productId,
+		WeightGrams: params.WeightGrams,
+		LengthCm:    params.LengthCm,
+		WidthCm:     params.WidthCm,
+		HeightCm:    params.HeightCm,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteShippingZone deletes a shipping zone along with its rates
+func (r *SQLShippingRepository) DeleteShippingZone(ctx context.Context, zoneId uuid.UUID) error {
+	rows, err := r.DB.DeleteShippingZone(ctx, zoneId)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteShippingRate deletes a shipping rate
+func (r *SQLShippingRepository) DeleteShippingRate(ctx context.Context, rateId uuid.UUID) error {
+	rows, err := r.DB.DeleteShippingRate(ctx, rateId)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetShippingZones gets every shipping zone with its rates
+func (r *SQLShippingRepository) GetShippingZones(ctx context.Context) ([]model.ShippingZoneDetail, error) {
+	zones, err := r.DB.ListShippingZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]model.ShippingZoneDetail, len(zones))
+	for i, zone := range zones {
+		rates, err := r.DB.ListZoneShippingRates(ctx, zone.ID)
+		if err != nil {
+			log.Printf("Error fetching rates for shipping zone %s: %s", zone.ID.String(), err.Error())
+			return nil, err
+		}
+
+		details[i] = model.ShippingZoneDetail{
+			ShippingZone: toModelShippingZone(zone),
+			Rates:        toModelShippingRates(rates),
+		}
+	}
+
+	return details, nil
+}
+
+// HasShippingZones reports whether any shipping zone is set up
+func (r *SQLShippingRepository) HasShippingZones(ctx context.Context) (bool, error) {
+	return r.DB.HasShippingZones(ctx)
+}
+
+// GetUserShippingDestination gets where a user ships to: the given shipping address, or their
+// default one if none is given. sql.ErrNoRows is returned if they have no such address.
+func (r *SQLShippingRepository) GetUserShippingDestination(ctx context.Context, userId uuid.UUID, addressId uuid.NullUUID) (model.ShippingDestination, error) {
+	destination, err := r.DB.GetUserShippingDestination(ctx, database.GetUserShippingDestinationParams{
+		UserID:    userId,
+		AddressID: addressId,
+	})
+	if err != nil {
+		return model.ShippingDestination{}, err
+	}
+
+	return model.ShippingDestination{
+		Country:    destination.Country,
+		Region:     destination.State,
+		PostalCode: destination.PostalCode,
+	}, nil
+}
+
+// MatchShippingZone finds the zone for a destination: the zone for its country with the longest
+// matching postal code prefix
+func (r *SQLShippingRepository) MatchShippingZone(ctx context.Context, destination model.ShippingDestination) (model.ShippingZone, error) {
+	zone, err := r.DB.MatchShippingZone(ctx, database.MatchShippingZoneParams{
+		Country:    destination.Country,
+		PostalCode: destination.PostalCode,
+	})
+	if err != nil {
+		return model.ShippingZone{}, err
+	}
+
+	return toModelShippingZone(zone), nil
+}
+
+// GetShippingRatesForWeight gets, for each method of a zone, the rate whose weight band the
+// weight falls in
+func (r *SQLShippingRepository) GetShippingRatesForWeight(ctx context.Context, zoneId uuid.UUID, weightGrams int32) ([]model.ShippingRate, error) {
+	rates, err := r.DB.ListShippingRatesForWeight(ctx, database.ListShippingRatesForWeightParams{
+		ZoneID:      zoneId,
+		WeightGrams: weightGrams,
+	})
+	if err != nil {
+		log.Printf("Error fetching shipping rates for zone %s: %s", zoneId.String(), err.Error())
+		return nil, err
+	}
+
+	return toModelShippingRates(rates), nil
+}
+
+func toModelShippingZone(zone database.ShippingZone) model.ShippingZone {
+	return model.ShippingZone{
+		ID:                    zone.ID,
+		Name:                  zone.Name,
+		Country:               zone.Country,
+		PostalCodePrefix:      zone.PostalCodePrefix,
+		FreeShippingThreshold: zone.FreeShippingThreshold,
+		CreatedAt:             zone.CreatedAt,
+		LastUpdated:           zone.LastUpdated,
+	}
+}
+
+func toModelShippingRates(rates []database.ShippingRate) []model.ShippingRate {
+	shippingRates := make([]model.ShippingRate, len(rates))
+	for i, rate := range rates {
+		shippingRates[i] = toModelShippingRate(rate)
+	}
+	return shippingRates
+}
+
+func toModelShippingRate(rate database.ShippingRate) model.ShippingRate {
+	price, _ := money.FromDecimal(rate.Price, money.DefaultCurrency)
+	return model.ShippingRate{
+		ID:             rate.ID,
+		ZoneID:         rate.ZoneID,
+		Method:         rate.Method,
+		Name:           rate.Name,
+		MinWeightGrams: rate.MinWeightGrams,
+		MaxWeightGrams: rate.MaxWeightGrams,
+		Price:          price,
+		EstimatedDays:  rate.EstimatedDays,
+		CreatedAt:      rate.CreatedAt,
+	}
+}
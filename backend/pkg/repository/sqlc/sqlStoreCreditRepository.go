@@ -0,0 +1,172 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+type SQLStoreCreditRepository struct {
+	Conn *sql.DB
+	DB   *database.Queries
+}
+
+func NewSQLStoreCreditRepository(conn *sql.DB, db *database.Queries) *SQLStoreCreditRepository {
+	return &SQLStoreCreditRepository{
+		Conn: conn,
+		DB:   db,
+	}
+}
+
+// AdjustStoreCredit adds to or, for a negative amount, takes from a user's store credit by hand.
+// Taking more than the user has fails with repository.ErrInsufficientStoreCredit, and sql.ErrNoRows
+// is returned if there is no such user.
+func (r *SQLStoreCreditRepository) AdjustStoreCredit(ctx context.Context, params model.AdjustStoreCreditParams) (model.StoreCreditTransaction, error) {
+	var transaction database.StoreCreditTransaction
+	err := execTx(ctx, r.Conn, func(q *database.Queries) error {
+		if _, err := q.FindUserByID(ctx, params.UserID); err != nil {
+			return err
+		}
+
+		var err error
+		transaction, err = recordStoreCredit(ctx, q, database.CreateStoreCreditTransactionParams{
+			UserID:    params.UserID,
+			Amount:    params.Amount.String(),
+			Reason:    "adjustment",
+			Note:      sql.NullString{String: params.Note, Valid: params.Note != ""},
+			CreatedBy: uuid.NullUUID{UUID: params.CreatedBy, Valid: params.CreatedBy != uuid.Nil},
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Error adjusting store credit of user with id %s: %s", params.UserID.String(), err.Error())
+		return model.StoreCreditTransaction{}, err
+	}
+
+	return toModelStoreCreditTransaction(transaction), nil
+}
+
+// GetStoreCredit gets a user's store credit balance and ledger. Users who never had store credit
+// have a balance of zero; sql.ErrNoRows is returned if there is no such user.
+func (r *SQLStoreCreditRepository) GetStoreCredit(ctx context.Context, userId uuid.UUID) (model.StoreCredit, error) {
+	if _, err := r.DB.FindUserByID(ctx, userId); err != nil {
+		return model.StoreCredit{}, err
+	}
+
+	balance := money.Zero(money.DefaultCurrency).String()
+	account, err := r.DB.GetStoreCreditAccount(ctx, userId)
+	if err == nil {
+		balance = account.Balance
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return model.StoreCredit{}, err
+	}
+
+	transactions, err := r.DB.ListStoreCreditTransactions(ctx, userId)
+	if err != nil {
+		return model.StoreCredit{}, err
+	}
+
+	modelTransactions := make([]model.StoreCreditTransaction, len(transactions))
+	for i, transaction := range transactions {
+		modelTransactions[i] = toModelStoreCreditTransaction(transaction)
+	}
+	return model.StoreCredit{
+		UserID:       userId,
+		Balance:      balance,
+		Transactions: modelTransactions,
+	}, nil
+}
+
+// redeemStoreCredit pays as much of an order as the user's store credit covers and returns the
+// ledger entry along with what is left to pay. The account is locked first, so concurrent
+// checkouts cannot both spend the same balance.
+func redeemStoreCredit(ctx context.Context, q *database.Queries, userId uuid.UUID, orderId uuid.UUID, due money.Money) (*model.StoreCreditTransaction, money.Money, error) {
+	if due.IsZero() {
+		return nil, due, nil
+	}
+
+	account, err := q.LockStoreCreditAccount(ctx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, due, nil
+	}
+	if err != nil {
+		return nil, due, err
+	}
+	balance, err := money.FromDecimal(account.Balance, money.DefaultCurrency)
+	if err != nil {
+		return nil, due, err
+	}
+	if balance.Sign() <= 0 {
+		return nil, due, nil
+	}
+
+	amount := balance.Min(due)
+	transaction, err := recordStoreCredit(ctx, q, database.CreateStoreCreditTransactionParams{
+		UserID:  userId,
+		Amount:  money.Zero(money.DefaultCurrency).Sub(amount).String(),
+		Reason:  "redeem",
+		OrderID: uuid.NullUUID{UUID: orderId, Valid: true},
+	})
+	if err != nil {
+		return nil, due, err
+	}
+
+	modelTransaction := toModelStoreCreditTransaction(transaction)
+	return &modelTransaction, due.Sub(amount), nil
+}
+
+// recordStoreCredit moves a user's store credit balance by the entry's amount, which is negative
+// for money taken off it, and adds the entry to their ledger. Money is only taken off in a single
+// conditional update, so the balance never goes below zero; taking more than is there fails with
+// repository.ErrInsufficientStoreCredit.
+func recordStoreCredit(ctx context.Context, q *database.Queries, entry database.CreateStoreCreditTransactionParams) (database.StoreCreditTransaction, error) {
+	amount, err := money.FromDecimal(entry.Amount, money.DefaultCurrency)
+	if err != nil {
+		return database.StoreCreditTransaction{}, err
+	}
+
+	var account database.StoreCreditAccount
+	if amount.Sign() < 0 {
+		account, err = q.DebitStoreCredit(ctx, database.DebitStoreCreditParams{
+			Amount: money.Zero(money.DefaultCurrency).Sub(amount).String(),
+			UserID: entry.UserID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.StoreCreditTransaction{}, repository.ErrInsufficientStoreCredit
+		}
+	} else {
+		account, err = q.CreditStoreCredit(ctx, database.CreditStoreCreditParams{
+			UserID:  entry.UserID,
+			Balance: amount.String(),
+		})
+	}
+	if err != nil {
+		return database.StoreCreditTransaction{}, err
+	}
+
+	entry.ID = uuid.New()
+	entry.BalanceAfter = account.Balance
+	return q.CreateStoreCreditTransaction(ctx, entry)
+}
+
+func toModelStoreCreditTransaction(transaction database.StoreCreditTransaction) model.StoreCreditTransaction {
+	return model.StoreCreditTransaction{
+		ID:           transaction.ID,
+		UserID:       transaction.UserID,
+		Amount:       transaction.Amount,
+		BalanceAfter: transaction.BalanceAfter,
+		Reason:       transaction.Reason,
+		OrderID:      transaction.OrderID,
+		RefundID:     transaction.RefundID,
+		Note:         transaction.Note,
+		CreatedBy:    transaction.CreatedBy,
+		CreatedAt:    transaction.CreatedAt,
+	}
+}
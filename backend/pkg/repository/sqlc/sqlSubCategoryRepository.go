@@ -28,6 +28,7 @@ func (r *SQLSubCategoryRepository) CreateSubCategory(ctx context.Context, subCat
 		Description: subCategory.Description,
 		ImageUrl:    subCategory.ImageUrl,
 		SeoKeywords: subCategory.SeoKeywords,
+		TaxClass:    subCategory.TaxClass,
 	})
 	if err != nil {
 		log.Printf("Error adding subcategory: %v", err)
@@ -45,6 +46,7 @@ func (r *SQLSubCategoryRepository) CreateSubCategory(ctx context.Context, subCat
 		IsActive:    addSubCategory.IsActive,
 		CreatedAt:   addSubCategory.CreatedAt,
 		LastUpdated: addSubCategory.LastUpdated,
+		TaxClass:    addSubCategory.TaxClass,
 	}, err
 }
 
@@ -58,6 +60,7 @@ func (r *SQLSubCategoryRepository) UpdateSubCategory(ctx context.Context, subCat
 		Description: subCategory.Description,
 		ImageUrl:    subCategory.ImageUrl,
 		SeoKeywords: subCategory.SeoKeywords,
+		TaxClass:    subCategory.TaxClass,
 	})
 	if err != nil {
 		return model.SubCategory{}, err
@@ -74,6 +77,7 @@ func (r *SQLSubCategoryRepository) UpdateSubCategory(ctx context.Context, subCat
 		IsActive:    updateSubCategory.IsActive,
 		CreatedAt:   updateSubCategory.CreatedAt,
 		LastUpdated: updateSubCategory.LastUpdated,
+		TaxClass:    updateSubCategory.TaxClass,
 	}, err
 }
 
@@ -89,7 +93,7 @@ func (r *SQLSubCategoryRepository) DeleteSubCategory(ctx context.Context, subCat
 }
 
 // GetProductBySubCategory returns a list of products in a subcategory
-func (r *SQLSubCategoryRepository) GetProductBySubCategory(ctx context.Context, subCategoryId uuid.NullUUID, offset int32, limit int32) (interface{}, error) {
+func (r *SQLSubCategoryRepository) GetProductBySubCategory(ctx context.Context, subCategoryId uuid.NullUUID, offset int32, limit int32) ([]model.Product, error) {
 	// Get products in a subcategory from the database
 	products, err := r.DB.GetProductBySubCategory(ctx, database.GetProductBySubCategoryParams{
 		SubCategoryID: subCategoryId,
@@ -102,29 +106,7 @@ func (r *SQLSubCategoryRepository) GetProductBySubCategory(ctx context.Context,
 	}
 
 	// Return products
-	productList := make([]model.Product, len(products))
-
-	for i, product := range products {
-		productList[i] = model.Product{
-			ID:            product.ID,
-			Name:          product.Name,
-			Description:   product.Description,
-			ImageUrl:      product.ImageUrl,
-			Price:         product.Price,
-			Stock:         product.Stock,
-			SubCategoryID: product.SubCategoryID,
-			Brand:         product.Brand,
-			Rating:        product.Rating,
-			ReviewCount:   product.ReviewCount,
-			DiscountRate:  product.DiscountRate,
-			Keywords:      product.Keywords,
-			IsActive:      product.IsActive,
-			CreatedAt:     product.CreatedAt,
-			LastUpdated:   product.LastUpdated,
-		}
-	}
-
-	return productList, nil
+	return toProductModels(products)
 }
 
 // ListSubCategories returns a list of subcategories in a category
@@ -150,6 +132,7 @@ func (r *SQLSubCategoryRepository) ListSubCategories(ctx context.Context) ([]mod
 			IsActive:    subCategory.IsActive,
 			CreatedAt:   subCategory.CreatedAt,
 			LastUpdated: subCategory.LastUpdated,
+			TaxClass:    subCategory.TaxClass,
 		}
 	}
 
@@ -196,6 +179,7 @@ func (r *SQLSubCategoryRepository) GetSubCategoryByCategory(ctx context.Context,
 			IsActive:    subCategory.IsActive,
 			CreatedAt:   subCategory.CreatedAt,
 			LastUpdated: subCategory.LastUpdated,
+			TaxClass:    subCategory.TaxClass,
 		}
 	}
 
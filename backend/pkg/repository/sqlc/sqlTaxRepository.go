@@ -0,0 +1,151 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type SQLTaxRepository struct {
+	DB *database.Queries
+}
+
+func NewSQLTaxRepository(db *database.Queries) *SQLTaxRepository {
+	return &SQLTaxRepository{
+		DB: db,
+	}
+}
+
+// SaveTaxRate creates the rate for a country, region and tax class, or replaces the existing one
+func (r *SQLTaxRepository) SaveTaxRate(ctx context.Context, params model.SaveTaxRateParams) (model.TaxRate, error) {
+	rate, err := r.DB.UpsertTaxRate(ctx, database.UpsertTaxRateParams{
+		ID:               uuid.New(),
+		Country:          params.Country,
+		Region:           params.Region,
+		TaxClass:         params.TaxClass,
+		Name:             params.Name,
+		Rate:             params.Rate,
+		PricesIncludeTax: params.PricesIncludeTax,
+	})
+	if err != nil {
+		log.Printf("Error saving tax rate for %s: %s", params.Country, err.Error())
+		return model.TaxRate{}, err
+	}
+
+	return toModelTaxRate(rate), nil
+}
+
+// SetUserTaxExempt flags or unflags a user as exempt from tax
+func (r *SQLTaxRepository) SetUserTaxExempt(ctx context.Context, userId uuid.UUID, exempt bool) error {
+	rows, err := r.DB.SetUserTaxExempt(ctx, database.SetUserTaxExemptParams{
+		ID:        userId,
+		TaxExempt: exempt,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetSubCategoryTaxClass sets the tax class applied to products in a sub category
+func (r *SQLTaxRepository) SetSubCategoryTaxClass(ctx context.Context, subCategoryId uuid.UUID, taxClass string) error {
+	rows, err := r.DB.SetSubCategoryTaxClass(ctx, database.SetSubCategoryTaxClassParams{
+		ID:       subCategoryId,
+		TaxClass: taxClass,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteTaxRate deletes a tax rate
+func (r *SQLTaxRepository) DeleteTaxRate(ctx context.Context, taxRateId uuid.UUID) error {
+	rows, err := r.DB.DeleteTaxRate(ctx, taxRateId)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetTaxRates gets every tax rate
+func (r *SQLTaxRepository) GetTaxRates(ctx context.Context) ([]model.TaxRate, error) {
+	rates, err := r.DB.ListTaxRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelTaxRates(rates), nil
+}
+
+// GetApplicableTaxRates gets the rates for a location: the country-wide ones and those for its region
+func (r *SQLTaxRepository) GetApplicableTaxRates(ctx context.Context, location model.TaxLocation) ([]model.TaxRate, error) {
+	rates, err := r.DB.ListApplicableTaxRates(ctx, database.ListApplicableTaxRatesParams{
+		Country: location.Country,
+		Region:  location.Region,
+	})
+	if err != nil {
+		log.Printf("Error fetching tax rates for %s: %s", location.Country, err.Error())
+		return nil, err
+	}
+
+	return toModelTaxRates(rates), nil
+}
+
+// GetUserTaxProfile gets whether a user is tax exempt and where they ship to: the given
+// shipping address, or their default one if none is given
+func (r *SQLTaxRepository) GetUserTaxProfile(ctx context.Context, userId uuid.UUID, addressId uuid.NullUUID) (model.TaxProfile, error) {
+	profile, err := r.DB.GetUserTaxProfile(ctx, database.GetUserTaxProfileParams{
+		AddressID: addressId,
+		ID:        userId,
+	})
+	if err != nil {
+		return model.TaxProfile{}, err
+	}
+
+	taxProfile := model.TaxProfile{
+		Exempt: profile.TaxExempt,
+	}
+	if profile.Country.Valid {
+		taxProfile.Location = &model.TaxLocation{
+			Country: profile.Country.String,
+			Region:  profile.State.String,
+		}
+	}
+	return taxProfile, nil
+}
+
+func toModelTaxRates(rates []database.TaxRate) []model.TaxRate {
+	taxRates := make([]model.TaxRate, len(rates))
+	for i, rate := range rates {
+		taxRates[i] = toModelTaxRate(rate)
+	}
+	return taxRates
+}
+
+func toModelTaxRate(rate database.TaxRate) model.TaxRate {
+	return model.TaxRate{
+		ID:               rate.ID,
+		Country:          rate.Country,
+		Region:           rate.Region,
+		TaxClass:         rate.TaxClass,
+		Name:             rate.Name,
+		Rate:             rate.Rate,
+		PricesIncludeTax: rate.PricesIncludeTax,
+		CreatedAt:        rate.CreatedAt,
+		LastUpdated:      rate.LastUpdated,
+	}
+}
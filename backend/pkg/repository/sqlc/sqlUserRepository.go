@@ -53,6 +53,7 @@ func (r *SQLUserRepository) CreateUser(ctx context.Context, user model.UserRegis
 		UserRole:       createdUser.UserRole,
 		ProfilePicture: createdUser.ProfilePicture,
 		TwoFactorAuth:  createdUser.TwoFactorAuth,
+		TaxExempt:      createdUser.TaxExempt,
 	}, nil
 }
 
@@ -86,6 +87,7 @@ func (r *SQLUserRepository) GetUserByEmail(ctx context.Context, email string) (m
 		UserRole:       user.UserRole,
 		ProfilePicture: user.ProfilePicture,
 		TwoFactorAuth:  user.TwoFactorAuth,
+		TaxExempt:      user.TaxExempt,
 	}, nil
 }
 
@@ -156,6 +158,7 @@ func (r *SQLUserRepository) GetUserById(ctx context.Context, userId uuid.UUID) (
 		UserRole:       user.UserRole,
 		ProfilePicture: user.ProfilePicture,
 		TwoFactorAuth:  user.TwoFactorAuth,
+		TaxExempt:      user.TaxExempt,
 	}, nil
 }
 
@@ -196,6 +199,7 @@ func (r *SQLUserRepository) UpdateUser(ctx context.Context, user model.User) (mo
 		UserRole:       updatedUser.UserRole,
 		ProfilePicture: updatedUser.ProfilePicture,
 		TwoFactorAuth:  updatedUser.TwoFactorAuth,
+		TaxExempt:      updatedUser.TaxExempt,
 	}, nil
 }
 
@@ -230,6 +234,7 @@ func (r *SQLUserRepository) UpdateUserProfilePicture(ctx context.Context, user m
 		UserRole:       updatedUser.UserRole,
 		ProfilePicture: updatedUser.ProfilePicture,
 		TwoFactorAuth:  updatedUser.TwoFactorAuth,
+		TaxExempt:      updatedUser.TaxExempt,
 	}, nil
 }
 
@@ -0,0 +1,27 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/geraldbahati/ecommerce/internal/database"
+)
+
+// execTx runs fn inside a database transaction, committing when fn succeeds
+// and rolling back when it returns an error
+func execTx(ctx context.Context, conn *sql.DB, fn func(q *database.Queries) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(database.New(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Printf("Error rolling back transaction: %v", rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
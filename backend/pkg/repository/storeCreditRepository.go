@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type StoreCreditRepository interface {
+	// update
+	AdjustStoreCredit(ctx context.Context, params model.AdjustStoreCreditParams) (model.StoreCreditTransaction, error)
+
+	// get
+	GetStoreCredit(ctx context.Context, userId uuid.UUID) (model.StoreCredit, error)
+}
@@ -17,7 +17,7 @@ type SubCategoryRepository interface {
 	DeleteSubCategory(ctx context.Context, subCategoryId uuid.UUID) error
 
 	// get
-	GetProductBySubCategory(ctx context.Context, subCategoryId uuid.NullUUID, offset int32, limit int32) (interface{}, error)
+	GetProductBySubCategory(ctx context.Context, subCategoryId uuid.NullUUID, offset int32, limit int32) ([]model.Product, error)
 	ListSubCategories(ctx context.Context) ([]model.SubCategory, error)
 	GetProductCountBySubCategory(ctx context.Context, subCategoryId uuid.NullUUID) (int64, error)
 	GetSubCategoryByCategory(ctx context.Context, categoryId uuid.UUID, offset int32, limit int32) (interface{}, error)
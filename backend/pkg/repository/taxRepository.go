@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/google/uuid"
+)
+
+type TaxRepository interface {
+	// create
+	SaveTaxRate(ctx context.Context, params model.SaveTaxRateParams) (model.TaxRate, error)
+
+	// update
+	SetUserTaxExempt(ctx context.Context, userId uuid.UUID, exempt bool) error
+	SetSubCategoryTaxClass(ctx context.Context, subCategoryId uuid.UUID, taxClass string) error
+
+	// delete
+	DeleteTaxRate(ctx context.Context, taxRateId uuid.UUID) error
+
+	// get
+	GetTaxRates(ctx context.Context) ([]model.TaxRate, error)
+	GetApplicableTaxRates(ctx context.Context, location model.TaxLocation) ([]model.TaxRate, error)
+	GetUserTaxProfile(ctx context.Context, userId uuid.UUID, addressId uuid.NullUUID) (model.TaxProfile, error)
+}
@@ -0,0 +1,25 @@
+package tax
+
+import (
+	"context"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+)
+
+// DefaultTaxClass is the class of products whose sub category does not name one, and the class
+// whose rate applies when a location has no rate for a product's own class
+const DefaultTaxClass = "standard"
+
+// TaxCalculator works out the tax on a set of order lines. Amounts are money.Money values in the
+// currency of the lines, and each line's tax is rounded to the cent before it is totalled.
+type TaxCalculator interface {
+	Calculate(ctx context.Context, request Request) (model.TaxBreakdown, error)
+}
+
+type Request struct {
+	// Location is where the order ships to. Without one no tax is charged.
+	Location *model.TaxLocation
+	// Exempt zero-rates every line
+	Exempt bool
+	Lines  []model.TaxLine
+}
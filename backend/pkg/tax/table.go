@@ -0,0 +1,107 @@
+package tax
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+)
+
+// TableCalculator is a TaxCalculator driven by the tax_rates table. A rate for the shipping region
+// wins over the country-wide one, and a tax class without a rate falls back to DefaultTaxClass.
+//
+// Rates flagged prices_include_tax treat the unit price as gross and take the tax out of it;
+// the others add the tax on top. Exempt customers pay the net price either way. A line's discount
+// comes off its price before it is taxed.
+type TableCalculator struct {
+	taxRepo repository.TaxRepository
+}
+
+func NewTableCalculator(taxRepo repository.TaxRepository) *TableCalculator {
+	return &TableCalculator{
+		taxRepo: taxRepo,
+	}
+}
+
+func (c *TableCalculator) Calculate(ctx context.Context, request Request) (model.TaxBreakdown, error) {
+	// find the rate for each tax class, preferring the region's
+	rates := make(map[string]model.TaxRate)
+	if request.Location != nil {
+		applicable, err := c.taxRepo.GetApplicableTaxRates(ctx, *request.Location)
+		if err != nil {
+			return model.TaxBreakdown{}, err
+		}
+		for _, rate := range applicable {
+			if existing, ok := rates[rate.TaxClass]; !ok || existing.Region == "" {
+				rates[rate.TaxClass] = rate
+			}
+		}
+	}
+
+	breakdown := model.TaxBreakdown{
+		Location: request.Location,
+		Exempt:   request.Exempt,
+		Lines:    make([]model.LineTax, len(request.Lines)),
+	}
+	zero := money.Zero(money.DefaultCurrency)
+	breakdown.NetTotal, breakdown.TaxTotal, breakdown.Total = zero, zero, zero
+	for i, line := range request.Lines {
+		rate, ok := rates[line.TaxClass]
+		if !ok {
+			rate, ok = rates[DefaultTaxClass]
+		}
+
+		lineTax, err := calculateLine(line, rate, ok, request.Exempt)
+		if err != nil {
+			return model.TaxBreakdown{}, err
+		}
+		breakdown.Lines[i] = lineTax
+
+		breakdown.NetTotal = breakdown.NetTotal.Add(lineTax.NetAmount)
+		breakdown.TaxTotal = breakdown.TaxTotal.Add(lineTax.TaxAmount)
+		breakdown.Total = breakdown.Total.Add(lineTax.TaxedPrice)
+	}
+	return breakdown, nil
+}
+
+// calculateLine works out the tax on one line at the given rate
+func calculateLine(line model.TaxLine, rate model.TaxRate, found bool, exempt bool) (model.LineTax, error) {
+	lineTotal := line.UnitPrice.Mul(int64(line.Quantity))
+	if line.Discount.Sign() < 0 || line.Discount.Cmp(lineTotal) > 0 {
+		return model.LineTax{}, fmt.Errorf("invalid discount %s", line.Discount)
+	}
+	lineTotal = lineTotal.Sub(line.Discount)
+
+	lineTax := model.LineTax{
+		TaxLine: line,
+		Rate:    "0.0000",
+	}
+	percentage := new(big.Rat)
+	if found {
+		if _, ok := percentage.SetString(rate.Rate); !ok {
+			return model.LineTax{}, fmt.Errorf("invalid tax rate %q", rate.Rate)
+		}
+		lineTax.TaxName = rate.Name
+		lineTax.Rate = rate.Rate
+		lineTax.PricesIncludeTax = rate.PricesIncludeTax
+	}
+
+	// split the line into its net amount and tax
+	net, tax := lineTotal, lineTotal.MulRat(percentage)
+	if lineTax.PricesIncludeTax {
+		// tax = gross * rate / (1 + rate)
+		tax = lineTotal.MulRat(new(big.Rat).Quo(percentage, new(big.Rat).Add(big.NewRat(1, 1), percentage)))
+		net = lineTotal.Sub(tax)
+	}
+	if exempt {
+		tax = money.Zero(lineTotal.Currency())
+	}
+
+	lineTax.NetAmount = net
+	lineTax.TaxAmount = tax
+	lineTax.TaxedPrice = net.Add(tax)
+	return lineTax, nil
+}
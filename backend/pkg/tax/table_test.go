@@ -0,0 +1,200 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+)
+
+// fakeTaxRepository serves a fixed set of rates; the other methods are not used by the calculator
+type fakeTaxRepository struct {
+	repository.TaxRepository
+	rates []model.TaxRate
+}
+
+func (r *fakeTaxRepository) GetApplicableTaxRates(ctx context.Context, location model.TaxLocation) ([]model.TaxRate, error) {
+	return r.rates, nil
+}
+
+func TestTableCalculatorCalculate(t *testing.T) {
+	countryStandard := model.TaxRate{Country: "GB", TaxClass: DefaultTaxClass, Name: "VAT", Rate: "0.2000"}
+	countryInclusive := model.TaxRate{Country: "GB", TaxClass: DefaultTaxClass, Name: "VAT", Rate: "0.2000", PricesIncludeTax: true}
+	countryReduced := model.TaxRate{Country: "US", TaxClass: "reduced", Name: "Reduced", Rate: "0.0500"}
+	usStandard := model.TaxRate{Country: "US", TaxClass: DefaultTaxClass, Name: "Sales tax", Rate: "0.0600"}
+	regionStandard := model.TaxRate{Country: "US", Region: "CA", TaxClass: DefaultTaxClass, Name: "CA sales tax", Rate: "0.0725"}
+	location := &model.TaxLocation{Country: "US", Region: "CA"}
+
+	tests := []struct {
+		name      string
+		rates     []model.TaxRate
+		location  *model.TaxLocation
+		exempt    bool
+		line      model.TaxLine
+		wantName  string
+		wantNet   int64
+		wantTax   int64
+		wantTotal int64
+	}{
+		{
+			name:      "exclusive pricing adds the tax",
+			rates:     []model.TaxRate{countryStandard},
+			location:  &model.TaxLocation{Country: "GB"},
+			line:      taxLine(DefaultTaxClass, 1000, 2, 0),
+			wantName:  "VAT",
+			wantNet:   2000,
+			wantTax:   400,
+			wantTotal: 2400,
+		},
+		{
+			name:      "inclusive pricing takes the tax out",
+			rates:     []model.TaxRate{countryInclusive},
+			location:  &model.TaxLocation{Country: "GB"},
+			line:      taxLine(DefaultTaxClass, 1200, 1, 0),
+			wantName:  "VAT",
+			wantNet:   1000,
+			wantTax:   200,
+			wantTotal: 1200,
+		},
+		{
+			name:      "inclusive tax is rounded to the cent",
+			rates:     []model.TaxRate{countryInclusive},
+			location:  &model.TaxLocation{Country: "GB"},
+			line:      taxLine(DefaultTaxClass, 999, 1, 0),
+			wantName:  "VAT",
+			wantNet:   832,
+			wantTax:   167,
+			wantTotal: 999,
+		},
+		{
+			name:      "discount comes off before tax",
+			rates:     []model.TaxRate{countryStandard},
+			location:  &model.TaxLocation{Country: "GB"},
+			line:      taxLine(DefaultTaxClass, 1000, 1, 250),
+			wantName:  "VAT",
+			wantNet:   750,
+			wantTax:   150,
+			wantTotal: 900,
+		},
+		{
+			name:      "region rate wins when listed after the country rate",
+			rates:     []model.TaxRate{usStandard, regionStandard},
+			location:  location,
+			line:      taxLine(DefaultTaxClass, 1000, 1, 0),
+			wantName:  "CA sales tax",
+			wantNet:   1000,
+			wantTax:   73,
+			wantTotal: 1073,
+		},
+		{
+			name:      "region rate wins when listed before the country rate",
+			rates:     []model.TaxRate{regionStandard, usStandard},
+			location:  location,
+			line:      taxLine(DefaultTaxClass, 1000, 1, 0),
+			wantName:  "CA sales tax",
+			wantNet:   1000,
+			wantTax:   73,
+			wantTotal: 1073,
+		},
+		{
+			name:      "class with its own rate",
+			rates:     []model.TaxRate{usStandard, countryReduced},
+			location:  location,
+			line:      taxLine("reduced", 1000, 1, 0),
+			wantName:  "Reduced",
+			wantNet:   1000,
+			wantTax:   50,
+			wantTotal: 1050,
+		},
+		{
+			name:      "class without a rate falls back to the standard rate",
+			rates:     []model.TaxRate{usStandard},
+			location:  location,
+			line:      taxLine("luxury", 1000, 1, 0),
+			wantName:  "Sales tax",
+			wantNet:   1000,
+			wantTax:   60,
+			wantTotal: 1060,
+		},
+		{
+			name:      "no rate at all charges no tax",
+			rates:     []model.TaxRate{countryReduced},
+			location:  location,
+			line:      taxLine("luxury", 1000, 1, 0),
+			wantNet:   1000,
+			wantTax:   0,
+			wantTotal: 1000,
+		},
+		{
+			name:      "no location charges no tax",
+			rates:     []model.TaxRate{usStandard},
+			line:      taxLine(DefaultTaxClass, 1000, 1, 0),
+			wantNet:   1000,
+			wantTax:   0,
+			wantTotal: 1000,
+		},
+		{
+			name:      "exempt customers pay the net price of inclusive prices",
+			rates:     []model.TaxRate{countryInclusive},
+			location:  &model.TaxLocation{Country: "GB"},
+			exempt:    true,
+			line:      taxLine(DefaultTaxClass, 1200, 1, 0),
+			wantName:  "VAT",
+			wantNet:   1000,
+			wantTax:   0,
+			wantTotal: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator := NewTableCalculator(&fakeTaxRepository{rates: tt.rates})
+			breakdown, err := calculator.Calculate(context.Background(), Request{
+				Location: tt.location,
+				Exempt:   tt.exempt,
+				Lines:    []model.TaxLine{tt.line},
+			})
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			line := breakdown.Lines[0]
+			if line.TaxName != tt.wantName {
+				t.Errorf("TaxName = %q, want %q", line.TaxName, tt.wantName)
+			}
+			if line.NetAmount.Cents() != tt.wantNet {
+				t.Errorf("NetAmount = %d cents, want %d", line.NetAmount.Cents(), tt.wantNet)
+			}
+			if line.TaxAmount.Cents() != tt.wantTax {
+				t.Errorf("TaxAmount = %d cents, want %d", line.TaxAmount.Cents(), tt.wantTax)
+			}
+			if line.TaxedPrice.Cents() != tt.wantTotal {
+				t.Errorf("TaxedPrice = %d cents, want %d", line.TaxedPrice.Cents(), tt.wantTotal)
+			}
+			if breakdown.Total.Cents() != tt.wantTotal {
+				t.Errorf("Total = %d cents, want %d", breakdown.Total.Cents(), tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestTableCalculatorRejectsBadDiscount(t *testing.T) {
+	calculator := NewTableCalculator(&fakeTaxRepository{})
+	_, err := calculator.Calculate(context.Background(), Request{
+		Lines: []model.TaxLine{taxLine(DefaultTaxClass, 1000, 1, 1001)},
+	})
+	if err == nil {
+		t.Fatal("Calculate() error = nil, want an error for a discount above the line total")
+	}
+}
+
+func taxLine(taxClass string, unitPrice int64, quantity int32, discount int64) model.TaxLine {
+	return model.TaxLine{
+		TaxClass:  taxClass,
+		UnitPrice: money.New(unitPrice, money.DefaultCurrency),
+		Quantity:  quantity,
+		Discount:  money.New(discount, money.DefaultCurrency),
+	}
+}
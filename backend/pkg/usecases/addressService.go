@@ -0,0 +1,215 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/postal"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidAddressType = errors.New("address type must be shipping or billing")
+	ErrAddressNotFound    = errors.New("address not found")
+)
+
+type AddressService struct {
+	addressRepo repository.AddressRepository
+}
+
+func NewAddressService(addressRepo repository.AddressRepository) *AddressService {
+	return &AddressService{
+		addressRepo: addressRepo,
+	}
+}
+
+// GetAddresses gets the logged-in user's address book
+func (s *AddressService) GetAddresses(ctx context.Context) (model.AddressBook, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	return s.addressRepo.GetAddresses(ctx, userId)
+}
+
+// CreateAddress adds an address to the logged-in user's address book
+func (s *AddressService) CreateAddress(ctx context.Context, params model.SaveAddressParams) (model.Address, error) {
+	// validate type and details
+	params, err := validateAddress(params)
+	if err != nil {
+		return model.Address{}, err
+	}
+
+	// get user id from context
+	params.UserID = ctx.Value("userId").(uuid.UUID)
+
+	return s.addressRepo.CreateAddress(ctx, params)
+}
+
+// UpdateAddress changes an address in the logged-in user's address book
+func (s *AddressService) UpdateAddress(ctx context.Context, addressId uuid.UUID, params model.SaveAddressParams) (model.Address, error) {
+	// validate type and details
+	params, err := validateAddress(params)
+	if err != nil {
+		return model.Address{}, err
+	}
+
+	// get user id from context
+	params.UserID = ctx.Value("userId").(uuid.UUID)
+
+	address, err := s.addressRepo.UpdateAddress(ctx, addressId, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Address{}, ErrAddressNotFound
+	}
+	return address, err
+}
+
+// SetDefaultAddress makes an address the logged-in user's default of its type
+func (s *AddressService) SetDefaultAddress(ctx context.Context, addressType string, addressId uuid.UUID) (model.Address, error) {
+	if !validAddressType(addressType) {
+		return model.Address{}, ErrInvalidAddressType
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	address, err := s.addressRepo.SetDefaultAddress(ctx, userId, addressType, addressId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Address{}, ErrAddressNotFound
+	}
+	return address, err
+}
+
+// DeleteAddress removes an address from the logged-in user's address book
+func (s *AddressService) DeleteAddress(ctx context.Context, addressType string, addressId uuid.UUID) error {
+	if !validAddressType(addressType) {
+		return ErrInvalidAddressType
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	err := s.addressRepo.DeleteAddress(ctx, userId, addressType, addressId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAddressNotFound
+	}
+	return err
+}
+
+// GetCheckoutAddress gets the address an order goes to or is billed to: the given one from the
+// logged-in user's address book, or their default of the type if none is given. The boolean is
+// false if no id is given and the user has no address of the type. The address is checked again,
+// as it may have been saved before the rules for its country changed; what is wrong with it is
+// reported under "shipping_address" or "billing_address" in a *postal.ValidationError.
+func (s *AddressService) GetCheckoutAddress(ctx context.Context, addressType string, addressId uuid.NullUUID) (model.Address, bool, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	var address model.Address
+	var err error
+	if addressId.Valid {
+		address, err = s.addressRepo.GetAddress(ctx, userId, addressType, addressId.UUID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Address{}, false, ErrAddressNotFound
+		}
+	} else {
+		address, err = s.addressRepo.GetDefaultAddress(ctx, userId, addressType)
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Address{}, false, nil
+		}
+	}
+	if err != nil {
+		return model.Address{}, false, err
+	}
+
+	// check the address still passes for its country
+	params, err := validateAddress(model.SaveAddressParams{
+		Type:       address.Type,
+		Street:     address.Street,
+		City:       address.City,
+		State:      address.State,
+		Country:    address.Country,
+		PostalCode: address.PostalCode,
+	})
+	var validationErr *postal.ValidationError
+	if errors.As(err, &validationErr) {
+		return model.Address{}, false, validationErr.WithPrefix(addressType + "_address")
+	}
+	if err != nil {
+		return model.Address{}, false, err
+	}
+	address.Street = params.Street
+	address.City = params.City
+	address.State = params.State
+	address.Country = params.Country
+	address.PostalCode = params.PostalCode
+
+	return address, true, nil
+}
+
+// ValidateCheckoutAddress tidies up an address given at checkout, rather than picked from the
+// address book, and checks it against the rules for its country. What is wrong with it is
+// reported under "shipping_address" or "billing_address" in a *postal.ValidationError.
+func (s *AddressService) ValidateCheckoutAddress(addressType string, params model.SaveAddressParams) (model.Address, error) {
+	params.Type = addressType
+	params, err := validateAddress(params)
+	var validationErr *postal.ValidationError
+	if errors.As(err, &validationErr) {
+		return model.Address{}, validationErr.WithPrefix(addressType + "_address")
+	}
+	if err != nil {
+		return model.Address{}, err
+	}
+
+	return model.Address{
+		Type:       params.Type,
+		Street:     params.Street,
+		City:       params.City,
+		State:      params.State,
+		Country:    params.Country,
+		PostalCode: params.PostalCode,
+	}, nil
+}
+
+// validateAddress tidies an address up and checks it against the rules for its country. What is
+// wrong with it is reported field by field in a *postal.ValidationError.
+func validateAddress(params model.SaveAddressParams) (model.SaveAddressParams, error) {
+	if !validAddressType(params.Type) {
+		return params, ErrInvalidAddressType
+	}
+
+	address, err := postal.Validate(postal.Address{
+		Street:     params.Street,
+		City:       params.City,
+		State:      params.State,
+		Country:    params.Country,
+		PostalCode: params.PostalCode,
+	})
+	if err != nil {
+		return params, err
+	}
+
+	params.Street = address.Street
+	params.City = address.City
+	params.State = address.State
+	params.Country = address.Country
+	params.PostalCode = address.PostalCode
+	return params, nil
+}
+
+func validAddressType(addressType string) bool {
+	return addressType == model.AddressTypeShipping || addressType == model.AddressTypeBilling
+}
+
+// formatAddress writes an address out on one line, the way it is kept on an order
+func formatAddress(address model.Address) string {
+	parts := []string{address.Street, address.City}
+	if region := strings.TrimSpace(address.State + " " + address.PostalCode); region != "" {
+		parts = append(parts, region)
+	}
+	parts = append(parts, address.Country)
+	return strings.Join(parts, ", ")
+}
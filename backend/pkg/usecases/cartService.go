@@ -0,0 +1,277 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/pricing"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidQuantity      = errors.New("quantity must be greater than zero")
+	ErrProductUnavailable   = errors.New("product is not available")
+	ErrQuantityExceedsStock = errors.New("requested quantity exceeds available stock")
+	ErrCartItemNotFound     = errors.New("product is not in the cart")
+)
+
+type CartService struct {
+	cartRepo         repository.CartRepository
+	productRepo      repository.ProductRepository
+	taxService       *TaxService
+	shippingService  *ShippingService
+	promotionService *PromotionService
+}
+
+func NewCartService(
+	cartRepo repository.CartRepository,
+	productRepo repository.ProductRepository,
+	taxService *TaxService,
+	shippingService *ShippingService,
+	promotionService *PromotionService,
+) *CartService {
+	return &CartService{
+		cartRepo:         cartRepo,
+		productRepo:      productRepo,
+		taxService:       taxService,
+		shippingService:  shippingService,
+		promotionService: promotionService,
+	}
+}
+
+// GetCart returns the user's cart with its items
+func (s *CartService) GetCart(ctx context.Context) (model.Cart, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	cart, err := s.cartRepo.GetOrCreateCart(ctx, userId)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	return s.withItems(ctx, cart)
+}
+
+// GetShippingOptions quotes the ways the user's cart can be shipped to their shipping address
+func (s *CartService) GetShippingOptions(ctx context.Context) (model.ShippingQuote, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	shoppingCart, err := s.cartRepo.GetOrCreateCart(ctx, userId)
+	if err != nil {
+		return model.ShippingQuote{}, err
+	}
+
+	// quote on the stored amounts, then show the quote in the requested currency
+	cart, err := s.pricedCart(ctx, shoppingCart)
+	if err != nil {
+		return model.ShippingQuote{}, err
+	}
+
+	quote, err := s.shippingService.QuoteShipping(ctx, cart.UserID, uuid.NullUUID{}, cart.Items, cart.Tax.Total)
+	if err != nil {
+		return model.ShippingQuote{}, err
+	}
+
+	if cart.Promotion != nil && cart.Promotion.Error == "" && cart.Promotion.FreeShipping {
+		quote = s.shippingService.WaiveCheapestOption(quote)
+	}
+	return convertShippingQuote(quote, displayCurrency(ctx)), nil
+}
+
+// ApplyPromotion applies a promotion code to the user's cart
+func (s *CartService) ApplyPromotion(ctx context.Context, code string) (model.Cart, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	cart, err := s.cartRepo.GetOrCreateCart(ctx, userId)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	items, err := s.cartRepo.ListCartItems(ctx, cart.ID)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	if err := s.promotionService.ApplyPromotion(ctx, userId, cart.ID, code, items); err != nil {
+		return model.Cart{}, err
+	}
+
+	return s.withItems(ctx, cart)
+}
+
+// RemovePromotion takes the promotion code off the user's cart
+func (s *CartService) RemovePromotion(ctx context.Context) (model.Cart, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	cart, err := s.cartRepo.GetOrCreateCart(ctx, userId)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	if err := s.promotionService.RemovePromotion(ctx, cart.ID); err != nil {
+		return model.Cart{}, err
+	}
+
+	return s.withItems(ctx, cart)
+}
+
+// AddItem adds a quantity of a product to the user's cart
+func (s *CartService) AddItem(ctx context.Context, productId string, quantity int32) (model.Cart, error) {
+	if quantity < 1 {
+		return model.Cart{}, ErrInvalidQuantity
+	}
+
+	// convert product id to uuid
+	productUUID, err := uuid.Parse(productId)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	cart, err := s.cartRepo.GetOrCreateCart(ctx, userId)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	// add to whatever is already in the cart
+	existingItem, err := s.cartRepo.GetCartItem(ctx, cart.ID, productUUID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return model.Cart{}, err
+	}
+
+	return s.setQuantity(ctx, cart, productUUID, existingItem.Quantity+quantity)
+}
+
+// UpdateItemQuantity replaces the quantity of a product already in the user's cart
+func (s *CartService) UpdateItemQuantity(ctx context.Context, productId string, quantity int32) (model.Cart, error) {
+	if quantity < 1 {
+		return model.Cart{}, ErrInvalidQuantity
+	}
+
+	// convert product id to uuid
+	productUUID, err := uuid.Parse(productId)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	cart, err := s.cartRepo.GetOrCreateCart(ctx, userId)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	// only products already in the cart can be updated
+	if _, err := s.cartRepo.GetCartItem(ctx, cart.ID, productUUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Cart{}, ErrCartItemNotFound
+		}
+		return model.Cart{}, err
+	}
+
+	return s.setQuantity(ctx, cart, productUUID, quantity)
+}
+
+// RemoveItem removes a product from the user's cart
+func (s *CartService) RemoveItem(ctx context.Context, productId string) (model.Cart, error) {
+	// convert product id to uuid
+	productUUID, err := uuid.Parse(productId)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	cart, err := s.cartRepo.GetOrCreateCart(ctx, userId)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	updatedCart, err := s.cartRepo.RemoveCartItem(ctx, cart.ID, productUUID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Cart{}, ErrCartItemNotFound
+		}
+		return model.Cart{}, err
+	}
+
+	return s.withItems(ctx, updatedCart)
+}
+
+// setQuantity validates the quantity against the product's stock before storing it
+func (s *CartService) setQuantity(ctx context.Context, cart model.ShoppingCart, productId uuid.UUID, quantity int32) (model.Cart, error) {
+	product, err := s.productRepo.GetProductById(ctx, productId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Cart{}, ErrProductUnavailable
+		}
+		return model.Cart{}, err
+	}
+
+	if !product.IsActive {
+		return model.Cart{}, ErrProductUnavailable
+	}
+
+	if quantity > product.Stock {
+		return model.Cart{}, ErrQuantityExceedsStock
+	}
+
+	updatedCart, err := s.cartRepo.SetCartItemQuantity(ctx, cart.ID, productId, quantity)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	return s.withItems(ctx, updatedCart)
+}
+
+// withItems attaches the cart's items, its promotion and their tax to the cart, in the currency
+// the request asked to see prices in
+func (s *CartService) withItems(ctx context.Context, cart model.ShoppingCart) (model.Cart, error) {
+	pricedCart, err := s.pricedCart(ctx, cart)
+	if err != nil {
+		return model.Cart{}, err
+	}
+	return convertCart(pricedCart, displayCurrency(ctx)), nil
+}
+
+// pricedCart attaches the cart's items, its promotion and their tax to the cart, as they are
+// stored. A promotion that can no longer be used is shown with the reason and gives no discount.
+func (s *CartService) pricedCart(ctx context.Context, cart model.ShoppingCart) (model.Cart, error) {
+	items, err := s.cartRepo.ListCartItems(ctx, cart.ID)
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	now := time.Now()
+	for i := range items {
+		items[i].Pricing = pricing.Calculate(items[i].Price, items[i].DiscountRate, items[i].SaleStartsAt, items[i].SaleEndsAt, now)
+	}
+
+	promotion, err := s.promotionService.EvaluateCartPromotion(ctx, cart.UserID, cart.ID, items)
+	if err != nil && !IsPromotionRejection(err) {
+		return model.Cart{}, err
+	}
+
+	tax, err := s.taxService.QuoteTax(ctx, cart.UserID, uuid.NullUUID{}, discountedTaxLines(items, promotion))
+	if err != nil {
+		return model.Cart{}, err
+	}
+
+	return model.Cart{
+		ShoppingCart: cart,
+		Items:        items,
+		Promotion:    promotion,
+		Tax:          tax,
+	}, nil
+}
@@ -0,0 +1,273 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidPaymentMethod = errors.New("payment method must be one of cash, credit_card, debit_card or paypal")
+	ErrInvalidAddress       = errors.New("a shipping address is required, and a billing address given as text must be between 1 and 100 characters")
+	ErrTooManyGiftCards     = errors.New("at most 5 gift cards can be used on an order")
+)
+
+const maxGiftCardsPerOrder = 5
+
+var paymentMethods = map[string]bool{
+	"cash":        true,
+	"credit_card": true,
+	"debit_card":  true,
+	"paypal":      true,
+}
+
+type CheckoutService struct {
+	checkoutRepo     repository.CheckoutRepository
+	cartRepo         repository.CartRepository
+	paymentService   *PaymentService
+	taxService       *TaxService
+	shippingService  *ShippingService
+	promotionService *PromotionService
+	addressService   *AddressService
+}
+
+func NewCheckoutService(
+	checkoutRepo repository.CheckoutRepository,
+	cartRepo repository.CartRepository,
+	paymentService *PaymentService,
+	taxService *TaxService,
+	shippingService *ShippingService,
+	promotionService *PromotionService,
+	addressService *AddressService,
+) *CheckoutService {
+	return &CheckoutService{
+		checkoutRepo:     checkoutRepo,
+		cartRepo:         cartRepo,
+		paymentService:   paymentService,
+		taxService:       taxService,
+		shippingService:  shippingService,
+		promotionService: promotionService,
+		addressService:   addressService,
+	}
+}
+
+// Checkout turns the user's cart into an order and, unless paying cash, takes payment for it.
+// The order ships to the given address from the user's address book, the address given in full,
+// which is checked as address book entries are, or else their default shipping address. It is
+// billed to an address from the address book, the address given as text, the default billing
+// address or else the shipping address. Addresses are copied onto the order, so later edits to
+// the address book leave it alone; tax and shipping are worked out for the country, region and
+// postal code of the chosen shipping address.
+// Gift cards, in the order given, and then store credit, if asked for, pay what they can first;
+// the payment method only pays what is left, and is not used at all when nothing is. The order
+// ships by the given method, or the cheapest one available if none is given; once shipping zones
+// are set up, ErrNoShippingToDestination is returned when no method covers the order. If the payment
+// fails the order is still placed; the result is returned along with an error wrapping
+// ErrPaymentFailed and the payment can be retried.
+func (s *CheckoutService) Checkout(
+	ctx context.Context,
+	paymentMethod string,
+	shippingAddressId uuid.NullUUID,
+	shippingAddress *model.SaveAddressParams,
+	billingAddressId uuid.NullUUID,
+	billingAddress string,
+	cardNumber string,
+	shippingMethod string,
+	giftCardCodes []string,
+	useStoreCredit bool,
+) (model.CheckoutResult, error) {
+	// validate payment method
+	if paymentMethod == "" {
+		paymentMethod = "cash"
+	}
+	if !paymentMethods[paymentMethod] {
+		return model.CheckoutResult{}, ErrInvalidPaymentMethod
+	}
+	if err := s.paymentService.ValidatePaymentDetails(paymentMethod, cardNumber); err != nil {
+		return model.CheckoutResult{}, err
+	}
+
+	// ship to an address from the address book, the one given, or the default one when neither is
+	var shipTo model.Address
+	if shippingAddressId.Valid || shippingAddress == nil {
+		address, found, err := s.addressService.GetCheckoutAddress(ctx, model.AddressTypeShipping, shippingAddressId)
+		if err != nil {
+			return model.CheckoutResult{}, err
+		}
+		if !found {
+			return model.CheckoutResult{}, ErrInvalidAddress
+		}
+		shipTo = address
+	} else {
+		address, err := s.addressService.ValidateCheckoutAddress(model.AddressTypeShipping, *shippingAddress)
+		if err != nil {
+			return model.CheckoutResult{}, err
+		}
+		shipTo = address
+	}
+	shippingText := formatAddress(shipTo)
+
+	// bill to an address from the address book, the default one, or else the shipping address
+	billingAddress = strings.TrimSpace(billingAddress)
+	if billingAddressId.Valid || billingAddress == "" {
+		address, found, err := s.addressService.GetCheckoutAddress(ctx, model.AddressTypeBilling, billingAddressId)
+		if err != nil {
+			return model.CheckoutResult{}, err
+		}
+		billingAddress = shippingText
+		if found {
+			billingAddress = formatAddress(address)
+		}
+	} else if !validAddress(billingAddress) {
+		return model.CheckoutResult{}, ErrInvalidAddress
+	}
+
+	// the same gift card only counts once
+	var codes []string
+	seen := make(map[string]bool, len(giftCardCodes))
+	for _, code := range giftCardCodes {
+		code = normalizeGiftCardCode(code)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+	if len(codes) > maxGiftCardsPerOrder {
+		return model.CheckoutResult{}, ErrTooManyGiftCards
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	cart, err := s.cartRepo.GetOrCreateCart(ctx, userId)
+	if err != nil {
+		return model.CheckoutResult{}, err
+	}
+
+	items, err := s.cartRepo.ListCartItems(ctx, cart.ID)
+	if err != nil {
+		return model.CheckoutResult{}, err
+	}
+	if len(items) == 0 {
+		return model.CheckoutResult{}, repository.ErrEmptyCart
+	}
+
+	// check the cart's promotion code can still be used
+	promotion, err := s.promotionService.EvaluateCartPromotion(ctx, userId, cart.ID, items)
+	if err != nil {
+		return model.CheckoutResult{}, err
+	}
+
+	// work out the tax on the cart as it stands; the order is only placed at these prices
+	location := model.TaxLocation{
+		Country: shipTo.Country,
+		Region:  shipTo.State,
+	}
+	tax, err := s.taxService.QuoteTaxAt(ctx, userId, location, discountedTaxLines(items, promotion))
+	if err != nil {
+		return model.CheckoutResult{}, err
+	}
+
+	// price shipping on the cart total, tax included
+	destination := model.ShippingDestination{
+		Country:    shipTo.Country,
+		Region:     shipTo.State,
+		PostalCode: shipTo.PostalCode,
+	}
+	shippingQuote, err := s.shippingService.QuoteShippingTo(ctx, destination, items, tax.Total)
+	if err != nil {
+		return model.CheckoutResult{}, err
+	}
+	offeredQuote := shippingQuote
+	if promotion != nil && promotion.FreeShipping {
+		offeredQuote = s.shippingService.WaiveCheapestOption(shippingQuote)
+	}
+	shippingOption, err := s.shippingService.ChooseShippingOption(offeredQuote, shippingMethod)
+	if err != nil {
+		return model.CheckoutResult{}, err
+	}
+	if shippingOption == nil {
+		// once shipping is set up, an order no rate covers cannot be shipped
+		shippingSetUp, err := s.shippingService.HasShippingZones(ctx)
+		if err != nil {
+			return model.CheckoutResult{}, err
+		}
+		if shippingSetUp {
+			return model.CheckoutResult{}, ErrNoShippingToDestination
+		}
+	}
+	chosenMethod := sql.NullString{}
+	shippingCost := money.Zero(money.DefaultCurrency)
+	if shippingOption != nil {
+		chosenMethod = sql.NullString{String: shippingOption.Method, Valid: true}
+		shippingCost = shippingOption.Cost
+	}
+
+	// the promotion is redeemed for what it took off the items and the shipping
+	var redemption *model.RedeemPromotionParams
+	if promotion != nil {
+		discount := promotion.ItemsDiscount
+		if shippingOption != nil {
+			fullPrice, err := s.shippingService.ChooseShippingOption(shippingQuote, shippingOption.Method)
+			if err != nil {
+				return model.CheckoutResult{}, err
+			}
+			discount = discount.Add(fullPrice.Cost.Sub(shippingCost))
+		}
+		redemption = &model.RedeemPromotionParams{
+			PromotionID:    promotion.PromotionID,
+			Code:           promotion.Code,
+			DiscountAmount: discount,
+		}
+	}
+
+	// place order
+	result, err := s.checkoutRepo.PlaceOrder(ctx, model.PlaceOrderParams{
+		UserID:          userId,
+		CartID:          cart.ID,
+		PaymentMethod:   paymentMethod,
+		ShippingAddress: shippingText,
+		BillingAddress:  billingAddress,
+		Taxes:           tax.Lines,
+		ShippingMethod:  chosenMethod,
+		ShippingCost:    shippingCost,
+		Promotion:       redemption,
+		Currency:        displayCurrency(ctx),
+		GiftCardCodes:   codes,
+		UseStoreCredit:  useStoreCredit,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Tax = tax
+	if paymentMethod == "cash" || result.Order.PaymentStatus == "paid" {
+		return result, nil
+	}
+
+	// take payment
+	payment, err := s.paymentService.PayOrder(ctx, result.Order.ID, cardNumber)
+	if errors.Is(err, ErrPaymentFailed) {
+		result.Order.PaymentStatus = "failed"
+		result.Payment = &payment
+		return result, err
+	}
+	if err != nil {
+		return result, err
+	}
+	result.Order.PaymentStatus = "paid"
+	result.Payment = &payment
+
+	return result, nil
+}
+
+// validAddress checks an address given as text is not empty or overly long
+func validAddress(address string) bool {
+	return address != "" && len(address) <= 100
+}
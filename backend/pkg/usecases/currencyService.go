@@ -0,0 +1,256 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/pricing"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+)
+
+var (
+	ErrCurrencyNotFound    = errors.New("currency not found")
+	ErrInvalidCurrencyName = errors.New("currency name must be between 1 and 100 characters and its symbol at most 10")
+	ErrBaseCurrency        = errors.New(money.DefaultCurrency + " is the base currency: its rate is always 1 and it cannot be deactivated")
+	ErrInvalidRatesFile    = errors.New("exchange rates must be given as lines of a currency code and a rate")
+)
+
+type CurrencyService struct {
+	currencyRepo repository.CurrencyRepository
+}
+
+func NewCurrencyService(currencyRepo repository.CurrencyRepository) *CurrencyService {
+	return &CurrencyService{
+		currencyRepo: currencyRepo,
+	}
+}
+
+// GetCurrencies lists currencies with their exchange rates, optionally only the ones customers can choose
+func (s *CurrencyService) GetCurrencies(ctx context.Context, activeOnly bool) ([]model.Currency, error) {
+	return s.currencyRepo.GetCurrencies(ctx, activeOnly)
+}
+
+// SaveCurrency adds a currency or updates the one with the same code. Amounts are rounded to the
+// cent, halves away from zero, unless the currency says otherwise.
+func (s *CurrencyService) SaveCurrency(ctx context.Context, params model.SaveCurrencyParams) (model.Currency, error) {
+	code, err := money.ParseCurrency(params.Code)
+	if err != nil {
+		return model.Currency{}, err
+	}
+	params.Code = code
+	params.Name = strings.TrimSpace(params.Name)
+	params.Symbol = strings.TrimSpace(params.Symbol)
+	if params.Name == "" || len(params.Name) > 100 || len(params.Symbol) > 10 {
+		return model.Currency{}, ErrInvalidCurrencyName
+	}
+	if params.RoundingIncrement == "" {
+		params.RoundingIncrement = "0.01"
+	}
+	if params.RoundingMode == "" {
+		params.RoundingMode = money.RoundHalfUp
+	}
+	if code == money.DefaultCurrency && !params.IsActive {
+		return model.Currency{}, ErrBaseCurrency
+	}
+
+	// check the rounding rule works before storing it
+	if _, err := money.NewConverter(code, "1", params.RoundingIncrement, params.RoundingMode); err != nil {
+		return model.Currency{}, err
+	}
+
+	return s.currencyRepo.SaveCurrency(ctx, params)
+}
+
+// SetExchangeRate sets how many units of a currency one unit of money.DefaultCurrency buys
+func (s *CurrencyService) SetExchangeRate(ctx context.Context, code string, rate string) (model.ExchangeRate, error) {
+	params, err := exchangeRateParams(code, rate, "manual")
+	if err != nil {
+		return model.ExchangeRate{}, err
+	}
+
+	rates, err := s.currencyRepo.SetExchangeRates(ctx, []model.SetExchangeRateParams{params})
+	var unknownCurrency *repository.UnknownCurrencyError
+	if errors.As(err, &unknownCurrency) {
+		return model.ExchangeRate{}, ErrCurrencyNotFound
+	}
+	if err != nil {
+		return model.ExchangeRate{}, err
+	}
+	return rates[0], nil
+}
+
+// ImportExchangeRates loads exchange rates from a CSV file of currency code and rate lines, such
+// as "EUR,0.92". A header line and lines starting with # are skipped. The file is checked in full
+// and its rates are stored together, so a bad line leaves every rate as it was.
+func (s *CurrencyService) ImportExchangeRates(ctx context.Context, file io.Reader) ([]model.ExchangeRate, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rates []model.SetExchangeRateParams
+	seen := make(map[string]bool)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRatesFile, err.Error())
+		}
+		line, _ := reader.FieldPos(0)
+
+		// skip the header
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "currency") {
+			continue
+		}
+
+		params, err := exchangeRateParams(record[0], record[1], "csv")
+		if errors.Is(err, ErrBaseCurrency) {
+			// the base currency may be listed as long as it is left at 1
+			if rate, rateErr := money.ParseRate(record[1]); rateErr == nil && rate.Cmp(big.NewRat(1, 1)) == 0 {
+				seen[money.DefaultCurrency] = true
+				continue
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s", ErrInvalidRatesFile, line, err.Error())
+		}
+		if seen[params.CurrencyCode] {
+			return nil, fmt.Errorf("%w: line %d: %s is listed more than once", ErrInvalidRatesFile, line, params.CurrencyCode)
+		}
+		seen[params.CurrencyCode] = true
+		rates = append(rates, params)
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("%w: no rates found", ErrInvalidRatesFile)
+	}
+
+	saved, err := s.currencyRepo.SetExchangeRates(ctx, rates)
+	var unknownCurrency *repository.UnknownCurrencyError
+	if errors.As(err, &unknownCurrency) {
+		return nil, fmt.Errorf("%w: %s", ErrCurrencyNotFound, unknownCurrency.Code)
+	}
+	return saved, err
+}
+
+// exchangeRateParams checks a currency code and rate and stores the rate with eight decimal places
+func exchangeRateParams(code string, rate string, source string) (model.SetExchangeRateParams, error) {
+	code, err := money.ParseCurrency(code)
+	if err != nil {
+		return model.SetExchangeRateParams{}, err
+	}
+	if code == money.DefaultCurrency {
+		return model.SetExchangeRateParams{}, ErrBaseCurrency
+	}
+	value, err := money.ParseRate(rate)
+	if err != nil {
+		return model.SetExchangeRateParams{}, err
+	}
+
+	return model.SetExchangeRateParams{
+		CurrencyCode: code,
+		Rate:         value.FloatString(8),
+		Source:       source,
+	}, nil
+}
+
+// displayCurrency gets the converter the Currency middleware put in the context. Without one
+// amounts are shown in money.DefaultCurrency, as they are stored.
+func displayCurrency(ctx context.Context) money.Converter {
+	converter, _ := ctx.Value("currency").(money.Converter)
+	return converter
+}
+
+// convertAmount converts a stored decimal amount for display, leaving anything that is not an
+// amount as it is
+func convertAmount(converter money.Converter, amount string) string {
+	value, err := money.FromDecimal(amount, money.DefaultCurrency)
+	if err != nil {
+		return amount
+	}
+	return converter.Convert(value).String()
+}
+
+// convertNullAmount converts an optional stored decimal amount for display
+func convertNullAmount(converter money.Converter, amount sql.NullString) sql.NullString {
+	if !amount.Valid {
+		return amount
+	}
+	return sql.NullString{String: convertAmount(converter, amount.String), Valid: true}
+}
+
+// convertCart shows a cart in another currency. Each amount is converted on its own, so a total
+// is what will be charged for it rather than the sum of the converted lines.
+func convertCart(cart model.Cart, converter money.Converter) model.Cart {
+	cart.TotalPrice = converter.Convert(cart.TotalPrice)
+
+	items := make([]model.CartItemDetail, len(cart.Items))
+	for i, item := range cart.Items {
+		item.Price = converter.Convert(item.Price)
+		item.UnitPrice = converter.Convert(item.UnitPrice)
+		item.Pricing = pricing.Convert(item.Pricing, converter)
+		items[i] = item
+	}
+	cart.Items = items
+
+	if cart.Promotion != nil {
+		promotion := *cart.Promotion
+		promotion.ItemsDiscount = converter.Convert(promotion.ItemsDiscount)
+		promotion.Lines = make([]model.LineDiscount, len(cart.Promotion.Lines))
+		for i, line := range cart.Promotion.Lines {
+			line.Amount = converter.Convert(line.Amount)
+			promotion.Lines[i] = line
+		}
+		cart.Promotion = &promotion
+	}
+
+	cart.Tax = convertTaxBreakdown(cart.Tax, converter)
+	return cart
+}
+
+// convertTaxBreakdown shows a tax breakdown in another currency
+func convertTaxBreakdown(tax model.TaxBreakdown, converter money.Converter) model.TaxBreakdown {
+	lines := make([]model.LineTax, len(tax.Lines))
+	for i, line := range tax.Lines {
+		line.UnitPrice = converter.Convert(line.UnitPrice)
+		line.Discount = converter.Convert(line.Discount)
+		line.NetAmount = converter.Convert(line.NetAmount)
+		line.TaxAmount = converter.Convert(line.TaxAmount)
+		line.TaxedPrice = converter.Convert(line.TaxedPrice)
+		lines[i] = line
+	}
+	tax.Lines = lines
+	tax.NetTotal = converter.Convert(tax.NetTotal)
+	tax.TaxTotal = converter.Convert(tax.TaxTotal)
+	tax.Total = converter.Convert(tax.Total)
+	return tax
+}
+
+// convertShippingQuote shows a shipping quote in another currency
+func convertShippingQuote(quote model.ShippingQuote, converter money.Converter) model.ShippingQuote {
+	quote.Subtotal = converter.Convert(quote.Subtotal)
+	quote.FreeShippingThreshold = convertNullAmount(converter, quote.FreeShippingThreshold)
+	if quote.Zone != nil {
+		zone := *quote.Zone
+		zone.FreeShippingThreshold = convertNullAmount(converter, zone.FreeShippingThreshold)
+		quote.Zone = &zone
+	}
+
+	options := make([]model.ShippingOption, len(quote.Options))
+	for i, option := range quote.Options {
+		option.Price = converter.Convert(option.Price)
+		option.Cost = converter.Convert(option.Cost)
+		options[i] = option
+	}
+	quote.Options = options
+	return quote
+}
@@ -0,0 +1,156 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidGiftCardAmount = errors.New("gift card amount must be more than zero with at most two decimal places")
+	ErrInvalidGiftCardExpiry = errors.New("gift card must expire in the future")
+	ErrGiftCardNotFound      = errors.New("gift card not found")
+)
+
+// Gift card codes are four groups of four characters, leaving out 0, 1, I and O so they are
+// easy to read out and type in
+const (
+	giftCardCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	giftCardCodeLength   = 16
+	giftCardCodeAttempts = 3
+)
+
+type GiftCardService struct {
+	giftCardRepo repository.GiftCardRepository
+}
+
+func NewGiftCardService(giftCardRepo repository.GiftCardRepository) *GiftCardService {
+	return &GiftCardService{
+		giftCardRepo: giftCardRepo,
+	}
+}
+
+// IssueGiftCard creates a gift card for an amount under a newly generated code. Cards without an
+// expiry never expire.
+func (s *GiftCardService) IssueGiftCard(ctx context.Context, amount string, expiresAt sql.NullTime) (model.GiftCardDetail, error) {
+	// validate amount and expiry
+	value, err := money.Parse(amount, money.DefaultCurrency)
+	if err != nil || value.Sign() <= 0 {
+		return model.GiftCardDetail{}, ErrInvalidGiftCardAmount
+	}
+	if expiresAt.Valid && !expiresAt.Time.After(time.Now()) {
+		return model.GiftCardDetail{}, ErrInvalidGiftCardExpiry
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	// a clash with an existing code is vanishingly rare, but try another code if it happens
+	for attempt := 1; ; attempt++ {
+		code, err := generateGiftCardCode()
+		if err != nil {
+			return model.GiftCardDetail{}, err
+		}
+
+		giftCard, err := s.giftCardRepo.CreateGiftCard(ctx, model.IssueGiftCardParams{
+			Code:      code,
+			Amount:    value,
+			ExpiresAt: expiresAt,
+			CreatedBy: userId,
+		})
+		if errors.Is(err, sql.ErrNoRows) && attempt < giftCardCodeAttempts {
+			continue
+		}
+		return giftCard, err
+	}
+}
+
+// GetGiftCards gets every gift card, newest first
+func (s *GiftCardService) GetGiftCards(ctx context.Context) ([]model.GiftCard, error) {
+	return s.giftCardRepo.GetGiftCards(ctx)
+}
+
+// GetGiftCard gets a gift card with its ledger
+func (s *GiftCardService) GetGiftCard(ctx context.Context, giftCardId uuid.UUID) (model.GiftCardDetail, error) {
+	giftCard, err := s.giftCardRepo.GetGiftCard(ctx, giftCardId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.GiftCardDetail{}, ErrGiftCardNotFound
+	}
+	return giftCard, err
+}
+
+// SetGiftCardActive switches a gift card on or off
+func (s *GiftCardService) SetGiftCardActive(ctx context.Context, giftCardId uuid.UUID, active bool) (model.GiftCard, error) {
+	giftCard, err := s.giftCardRepo.SetGiftCardActive(ctx, giftCardId, active)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.GiftCard{}, ErrGiftCardNotFound
+	}
+	return giftCard, err
+}
+
+// CheckGiftCardBalance tells the holder of a gift card code what is left on it and whether it can
+// be used at checkout
+func (s *GiftCardService) CheckGiftCardBalance(ctx context.Context, code string) (model.GiftCardBalance, error) {
+	giftCard, err := s.giftCardRepo.GetGiftCardByCode(ctx, normalizeGiftCardCode(code))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.GiftCardBalance{}, ErrGiftCardNotFound
+	}
+	if err != nil {
+		return model.GiftCardBalance{}, err
+	}
+
+	balance, err := money.FromDecimal(giftCard.Balance, money.DefaultCurrency)
+	if err != nil {
+		return model.GiftCardBalance{}, err
+	}
+	expired := giftCard.ExpiresAt.Valid && !time.Now().Before(giftCard.ExpiresAt.Time)
+
+	return model.GiftCardBalance{
+		Code:       giftCard.Code,
+		Balance:    giftCard.Balance,
+		ExpiresAt:  giftCard.ExpiresAt,
+		Redeemable: giftCard.IsActive && !expired && balance.Sign() > 0,
+	}, nil
+}
+
+// generateGiftCardCode picks a random code such as "7KQX-M2RT-9WHC-PD4N". The alphabet has 32
+// characters, so each random byte maps onto it evenly.
+func generateGiftCardCode() (string, error) {
+	random := make([]byte, giftCardCodeLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	var code strings.Builder
+	for i, b := range random {
+		if i > 0 && i%4 == 0 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(giftCardCodeAlphabet[int(b)%len(giftCardCodeAlphabet)])
+	}
+	return code.String(), nil
+}
+
+// normalizeGiftCardCode lets customers type a code in lower case, with spaces or without its dashes
+func normalizeGiftCardCode(code string) string {
+	var characters strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if r != '-' && r != ' ' {
+			characters.WriteRune(r)
+		}
+	}
+
+	compact := characters.String()
+	if len(compact) != giftCardCodeLength {
+		return strings.ToUpper(strings.TrimSpace(code))
+	}
+	return compact[0:4] + "-" + compact[4:8] + "-" + compact[8:12] + "-" + compact[12:16]
+}
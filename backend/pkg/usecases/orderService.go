@@ -0,0 +1,244 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/utils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrUnknownOrderStatus = errors.New("order status must be one of pending, processing, shipped, delivered or cancelled")
+	ErrReasonTooLong      = errors.New("reason must be at most 255 characters")
+	ErrInvalidDateRange   = errors.New("from date must not be after to date")
+)
+
+// orderTransitions lists the statuses an order may move to from each status
+var orderTransitions = map[string][]string{
+	"pending":    {"processing", "cancelled"},
+	"processing": {"shipped", "cancelled"},
+	"shipped":    {"delivered", "cancelled"},
+	"delivered":  {},
+	"cancelled":  {},
+}
+
+// customerCancellableStatuses are the statuses from which customers may cancel their own orders.
+// Admins may cancel from any status that orderTransitions allows.
+var customerCancellableStatuses = []string{"pending", "processing"}
+
+type OrderService struct {
+	orderRepo  repository.OrderRepository
+	refundRepo repository.RefundRepository
+}
+
+func NewOrderService(orderRepo repository.OrderRepository, refundRepo repository.RefundRepository) *OrderService {
+	return &OrderService{
+		orderRepo:  orderRepo,
+		refundRepo: refundRepo,
+	}
+}
+
+// TransitionOrder moves an order to a new status on behalf of the logged-in user
+func (s *OrderService) TransitionOrder(ctx context.Context, orderId uuid.UUID, toStatus string, reason string) (model.Order, error) {
+	// validate status and reason
+	if _, ok := orderTransitions[toStatus]; !ok {
+		return model.Order{}, ErrUnknownOrderStatus
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) > 255 {
+		return model.Order{}, ErrReasonTooLong
+	}
+
+	// cancelling has to restock, so it goes through its own path
+	if toStatus == "cancelled" {
+		return s.AdminCancelOrder(ctx, orderId, reason)
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	// move order
+	order, err := s.orderRepo.TransitionOrderStatus(ctx, model.OrderTransitionParams{
+		OrderID:     orderId,
+		AllowedFrom: allowedFromStatuses(toStatus),
+		ToStatus:    toStatus,
+		ActorID:     userId,
+		Reason:      reason,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Order{}, ErrOrderNotFound
+	}
+	return order, err
+}
+
+// CancelOrder cancels one of the logged-in user's orders while it is still pending or processing
+func (s *OrderService) CancelOrder(ctx context.Context, orderId uuid.UUID, reason string) (model.Order, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	return s.cancelOrder(ctx, model.OrderCancelParams{
+		OrderID:     orderId,
+		OwnerID:     uuid.NullUUID{UUID: userId, Valid: true},
+		AllowedFrom: customerCancellableStatuses,
+		ActorID:     userId,
+		Reason:      reason,
+	})
+}
+
+// AdminCancelOrder cancels any order that has not been delivered yet
+func (s *OrderService) AdminCancelOrder(ctx context.Context, orderId uuid.UUID, reason string) (model.Order, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	return s.cancelOrder(ctx, model.OrderCancelParams{
+		OrderID:     orderId,
+		AllowedFrom: allowedFromStatuses("cancelled"),
+		ActorID:     userId,
+		Reason:      reason,
+	})
+}
+
+func (s *OrderService) cancelOrder(ctx context.Context, params model.OrderCancelParams) (model.Order, error) {
+	params.Reason = strings.TrimSpace(params.Reason)
+	if len(params.Reason) > 255 {
+		return model.Order{}, ErrReasonTooLong
+	}
+
+	order, err := s.orderRepo.CancelOrder(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Order{}, ErrOrderNotFound
+	}
+	return order, err
+}
+
+// GetOrderTimeline gets one of the logged-in user's orders along with its status history
+func (s *OrderService) GetOrderTimeline(ctx context.Context, orderId uuid.UUID) (model.OrderTimeline, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	order, err := s.orderRepo.GetUserOrder(ctx, orderId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.OrderTimeline{}, ErrOrderNotFound
+	}
+	if err != nil {
+		return model.OrderTimeline{}, err
+	}
+
+	history, err := s.orderRepo.GetOrderStatusHistory(ctx, orderId)
+	if err != nil {
+		return model.OrderTimeline{}, err
+	}
+
+	return model.OrderTimeline{
+		Order:   order,
+		History: history,
+	}, nil
+}
+
+// GetOrders gets a page of the logged-in user's orders. Empty status and zero times are not filtered on;
+// createdTo is exclusive.
+func (s *OrderService) GetOrders(
+	ctx context.Context,
+	status string,
+	createdFrom time.Time,
+	createdTo time.Time,
+	pageSize int32,
+	page int32,
+) (model.PaginationResult, error) {
+	// validate filter
+	if status != "" {
+		if _, ok := orderTransitions[status]; !ok {
+			return model.PaginationResult{}, ErrUnknownOrderStatus
+		}
+	}
+	if !createdFrom.IsZero() && !createdTo.IsZero() && createdFrom.After(createdTo) {
+		return model.PaginationResult{}, ErrInvalidDateRange
+	}
+	filter := model.OrderFilter{
+		Status:      sql.NullString{String: status, Valid: status != ""},
+		CreatedFrom: sql.NullTime{Time: createdFrom, Valid: !createdFrom.IsZero()},
+		CreatedTo:   sql.NullTime{Time: createdTo, Valid: !createdTo.IsZero()},
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	// get order count
+	totalCount, err := s.orderRepo.GetUserOrderCount(ctx, userId, filter)
+	if err != nil {
+		return model.PaginationResult{}, err
+	}
+
+	// get orders
+	paginatedOrders, err := utils.Paginate(ctx, totalCount, page, pageSize, func(offset int32, limit int32) (interface{}, error) {
+		return s.orderRepo.GetUserOrders(ctx, userId, filter, offset, limit)
+	})
+	if err != nil {
+		return model.PaginationResult{}, err
+	}
+
+	// return orders
+	return *paginatedOrders, nil
+}
+
+// GetOrder gets one of the logged-in user's orders with its items, status history and refunds
+func (s *OrderService) GetOrder(ctx context.Context, orderId uuid.UUID) (model.OrderDetail, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	order, err := s.orderRepo.GetUserOrder(ctx, orderId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.OrderDetail{}, ErrOrderNotFound
+	}
+	if err != nil {
+		return model.OrderDetail{}, err
+	}
+
+	items, err := s.orderRepo.GetOrderItems(ctx, orderId)
+	if err != nil {
+		return model.OrderDetail{}, err
+	}
+
+	history, err := s.orderRepo.GetOrderStatusHistory(ctx, orderId)
+	if err != nil {
+		return model.OrderDetail{}, err
+	}
+
+	refunds, err := s.refundRepo.GetOrderRefunds(ctx, orderId)
+	if err != nil {
+		return model.OrderDetail{}, err
+	}
+
+	promotion, err := s.orderRepo.GetOrderPromotion(ctx, orderId)
+	if err != nil {
+		return model.OrderDetail{}, err
+	}
+
+	return model.OrderDetail{
+		Order:     order,
+		Items:     items,
+		History:   history,
+		Refunds:   refunds,
+		Promotion: promotion,
+	}, nil
+}
+
+// allowedFromStatuses lists the statuses an order may be in to move to the given status
+func allowedFromStatuses(toStatus string) []string {
+	var statuses []string
+	for from, targets := range orderTransitions {
+		for _, target := range targets {
+			if target == toStatus {
+				statuses = append(statuses, from)
+			}
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,270 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/payment"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPaymentFailed      = errors.New("payment failed")
+	ErrInvalidCardNumber  = errors.New("card number must be between 12 and 19 digits")
+	ErrCardNumberRequired = errors.New("card number is required for card payments")
+	ErrInvalidSignature   = errors.New("invalid webhook signature")
+	ErrPaymentNotFound    = errors.New("payment not found")
+)
+
+// stalePaymentAge is how long a payment attempt may stay pending or authorized before it is taken
+// to have been left unfinished, by a process that died between gateway calls
+const stalePaymentAge = 15 * time.Minute
+
+// paymentStatusRanks orders payment statuses so that events can only move a payment forward.
+// A capture outranks a failure because the provider having taken the money is what counts.
+var paymentStatusRanks = map[string]int{
+	"pending":    0,
+	"authorized": 1,
+	"declined":   2,
+	"failed":     2,
+	"voided":     3,
+	"captured":   3,
+}
+
+type PaymentService struct {
+	paymentRepo    repository.PaymentRepository
+	gateway        payment.PaymentGateway
+	webhookSecrets map[string]string
+}
+
+func NewPaymentService(paymentRepo repository.PaymentRepository, gateway payment.PaymentGateway, webhookSecrets map[string]string) *PaymentService {
+	return &PaymentService{
+		paymentRepo:    paymentRepo,
+		gateway:        gateway,
+		webhookSecrets: webhookSecrets,
+	}
+}
+
+// ValidatePaymentDetails checks the details needed to pay with the given method, before an order is placed
+func (s *PaymentService) ValidatePaymentDetails(paymentMethod string, cardNumber string) error {
+	if paymentMethod != "credit_card" && paymentMethod != "debit_card" {
+		return nil
+	}
+
+	cardNumber = strings.ReplaceAll(cardNumber, " ", "")
+	if cardNumber == "" {
+		return ErrCardNumberRequired
+	}
+	if len(cardNumber) < 12 || len(cardNumber) > 19 {
+		return ErrInvalidCardNumber
+	}
+	for _, c := range cardNumber {
+		if c < '0' || c > '9' {
+			return ErrInvalidCardNumber
+		}
+	}
+	return nil
+}
+
+// PayOrder takes payment for one of the logged-in user's orders through the gateway. A declined
+// payment comes back with the recorded attempt and an error wrapping ErrPaymentFailed.
+func (s *PaymentService) PayOrder(ctx context.Context, orderId uuid.UUID, cardNumber string) (model.Payment, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	beginParams := model.BeginPaymentParams{
+		OrderID:  orderId,
+		OwnerID:  uuid.NullUUID{UUID: userId, Valid: true},
+		Provider: s.gateway.Name(),
+	}
+	order, attempt, err := s.paymentRepo.BeginPayment(ctx, beginParams)
+	if errors.Is(err, repository.ErrPaymentInProgress) {
+		// an unfinished attempt would otherwise block the order for good
+		if err := s.expireStalePayments(ctx, orderId); err != nil {
+			return model.Payment{}, err
+		}
+		order, attempt, err = s.paymentRepo.BeginPayment(ctx, beginParams)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Payment{}, ErrOrderNotFound
+	}
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	if err := s.ValidatePaymentDetails(order.PaymentMethod, cardNumber); err != nil {
+		return s.failPayment(ctx, attempt, "failed", "", err.Error())
+	}
+
+	// reserve the money
+	authorization, err := s.gateway.Authorize(ctx, payment.AuthorizeRequest{
+		Reference:     attempt.ID.String(),
+		Amount:        attempt.Amount,
+		Currency:      attempt.Currency,
+		PaymentMethod: order.PaymentMethod,
+		CardNumber:    strings.ReplaceAll(cardNumber, " ", ""),
+	})
+	if err != nil {
+		return s.failPayment(ctx, attempt, "failed", "", err.Error())
+	}
+	if !authorization.Approved {
+		return s.failPayment(ctx, attempt, "declined", authorization.TransactionID, authorization.Message)
+	}
+
+	// release the reservation if the order was cancelled or paid meanwhile
+	authorized, err := s.paymentRepo.AuthorizePayment(ctx, attempt, authorization.TransactionID)
+	if errors.Is(err, repository.ErrOrderNotPayable) || errors.Is(err, repository.ErrOrderAlreadyPaid) || errors.Is(err, repository.ErrPaymentExpired) {
+		s.voidAuthorization(ctx, authorization.TransactionID)
+		_, updateErr := s.paymentRepo.UpdatePayment(ctx, model.UpdatePaymentParams{
+			ID:                    attempt.ID,
+			Status:                "voided",
+			ProviderTransactionID: authorization.TransactionID,
+			FailureMessage:        err.Error(),
+		})
+		if updateErr != nil {
+			return model.Payment{}, updateErr
+		}
+		return model.Payment{}, err
+	}
+	if err != nil {
+		return model.Payment{}, err
+	}
+	attempt = authorized
+
+	// take it, releasing the reservation if that fails
+	capture, err := s.gateway.Capture(ctx, authorization.TransactionID, attempt.Amount)
+	if err != nil || !capture.Approved {
+		message := capture.Message
+		status := "declined"
+		if err != nil {
+			message = err.Error()
+			status = "failed"
+		}
+		s.voidAuthorization(ctx, authorization.TransactionID)
+		return s.failPayment(ctx, attempt, status, authorization.TransactionID, message)
+	}
+
+	return s.paymentRepo.CapturePayment(ctx, attempt)
+}
+
+// HandleWebhook verifies and applies an event posted by a payment provider, returning what was done with it
+func (s *PaymentService) HandleWebhook(ctx context.Context, provider string, body []byte, signature string) (string, error) {
+	// verify signature
+	if !payment.VerifySignature(s.webhookSecrets[provider], body, signature) {
+		return "", ErrInvalidSignature
+	}
+
+	event, err := payment.ParseWebhookEvent(body)
+	if err != nil {
+		return "", err
+	}
+
+	// only statuses ranked below the reported one may be moved from
+	status := event.PaymentStatus()
+	var allowedFrom []string
+	for from, rank := range paymentStatusRanks {
+		if rank < paymentStatusRanks[status] {
+			allowedFrom = append(allowedFrom, from)
+		}
+	}
+
+	params := model.PaymentEventParams{
+		Provider:      provider,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		TransactionID: event.TransactionID,
+		Payload:       string(body),
+		Status:        status,
+		AllowedFrom:   allowedFrom,
+	}
+
+	// a capture pays the order, or leaves a cancelled one owed a refund; a failure only fails an
+	// order nothing else has paid
+	switch status {
+	case "captured":
+		params.OrderPaymentStatus = "paid"
+		params.OrderPaymentFrom = []string{"pending", "failed"}
+	case "declined", "failed", "voided":
+		params.FailureMessage = event.Message
+		if len(params.FailureMessage) > 255 {
+			params.FailureMessage = params.FailureMessage[:255]
+		}
+		params.OrderPaymentStatus = "failed"
+		params.OrderPaymentFrom = []string{"pending"}
+	}
+
+	outcome, err := s.paymentRepo.ApplyPaymentEvent(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrPaymentNotFound
+	}
+	return outcome, err
+}
+
+// GetOrderPayments gets the payment attempts for an order
+func (s *PaymentService) GetOrderPayments(ctx context.Context, orderId uuid.UUID) ([]model.Payment, error) {
+	return s.paymentRepo.GetOrderPayments(ctx, orderId)
+}
+
+// expireStalePayments gives up on the order's payment attempts that have been pending or
+// authorized for longer than stalePaymentAge, voiding any money they reserved. Attempts are given
+// up on even when the gateway cannot void them, as it releases reservations that are never
+// captured on its own.
+func (s *PaymentService) expireStalePayments(ctx context.Context, orderId uuid.UUID) error {
+	stale, err := s.paymentRepo.GetStalePayments(ctx, orderId, time.Now().Add(-stalePaymentAge))
+	if err != nil {
+		return err
+	}
+
+	for _, attempt := range stale {
+		status := "failed"
+		if attempt.Status == "authorized" && attempt.ProviderTransactionID.Valid && s.voidAuthorization(ctx, attempt.ProviderTransactionID.String) {
+			status = "voided"
+		}
+
+		if err := s.paymentRepo.ExpirePayment(ctx, attempt.ID, status, "payment attempt expired"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// voidAuthorization releases money reserved by the gateway, reporting whether it was released
+func (s *PaymentService) voidAuthorization(ctx context.Context, transactionId string) bool {
+	result, err := s.gateway.Void(ctx, transactionId)
+	if err != nil {
+		log.Printf("Error voiding authorization %s: %s", transactionId, err.Error())
+		return false
+	}
+	if !result.Approved {
+		log.Printf("Error voiding authorization %s: %s", transactionId, result.Message)
+		return false
+	}
+	return true
+}
+
+// failPayment records a failed attempt and marks the order's payment as failed so it can be retried
+func (s *PaymentService) failPayment(ctx context.Context, attempt model.Payment, status string, transactionId string, message string) (model.Payment, error) {
+	if len(message) > 255 {
+		message = message[:255]
+	}
+
+	attempt, err := s.paymentRepo.UpdatePayment(ctx, model.UpdatePaymentParams{
+		ID:                    attempt.ID,
+		Status:                status,
+		ProviderTransactionID: transactionId,
+		FailureMessage:        message,
+		OrderPaymentStatus:    "failed",
+	})
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	return attempt, fmt.Errorf("%w: %s", ErrPaymentFailed, message)
+}
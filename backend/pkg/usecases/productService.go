@@ -6,20 +6,45 @@ import (
 	"errors"
 	"github.com/geraldbahati/ecommerce/internal/database"
 	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/pricing"
 	"github.com/geraldbahati/ecommerce/pkg/repository"
 	"github.com/geraldbahati/ecommerce/pkg/utils"
 	"github.com/google/uuid"
 	"log"
+	"regexp"
 	"sync"
+	"time"
 )
 
+var (
+	ErrInvalidDiscountRate  = errors.New("discount rate must be between 0.0 and 0.9")
+	ErrInvalidSaleWindow    = errors.New("sale must end after it starts")
+	ErrInvalidPriceCurrency = errors.New("prices must be in " + money.DefaultCurrency)
+)
+
+var discountRatePattern = regexp.MustCompile(`^0(\.\d)?$`)
+
+// defaultRecentlyViewedLimit is how many recently viewed products are returned when no limit is given
+const defaultRecentlyViewedLimit = 20
+
 type ProductService struct {
-	productRepo repository.ProductRepository
+	productRepo             repository.ProductRepository
+	recentlyViewedRepo      repository.RecentlyViewedRepository
+	recentlyViewedRetention int32
 }
 
-func NewProductService(productRepo repository.ProductRepository) *ProductService {
+// NewProductService creates a product service that remembers the recentlyViewedRetention products
+// each logged-in user viewed most recently
+func NewProductService(productRepo repository.ProductRepository, recentlyViewedRepo repository.RecentlyViewedRepository, recentlyViewedRetention int) *ProductService {
+	if recentlyViewedRetention < 1 {
+		recentlyViewedRetention = 1
+	}
+
 	return &ProductService{
-		productRepo: productRepo,
+		productRepo:             productRepo,
+		recentlyViewedRepo:      recentlyViewedRepo,
+		recentlyViewedRetention: int32(recentlyViewedRetention),
 	}
 }
 
@@ -37,7 +62,13 @@ func (s *ProductService) GetProducts(ctx context.Context, pageSize int32, page i
 		page,
 		pageSize,
 		func(offset, limit int32) (interface{}, error) {
-			return s.productRepo.GetProducts(ctx, pageSize, page)
+			products, err := s.productRepo.GetProducts(ctx, pageSize, page)
+			if err != nil {
+				return nil, err
+			}
+			pricing.ApplyToProducts(products, time.Now())
+			pricing.ConvertProducts(products, displayCurrency(ctx))
+			return products, nil
 		},
 	)
 	if err != nil {
@@ -48,8 +79,8 @@ func (s *ProductService) GetProducts(ctx context.Context, pageSize int32, page i
 }
 
 // Get a specific product details
-func (s *ProductService) GetProductDetails(ctx context.Context, productID uuid.UUID) (database.Product, error) {
-	return s.productRepo.GetProductById(ctx, productID)
+func (s *ProductService) GetProductDetails(ctx context.Context, productID uuid.UUID) (model.Product, error) {
+	return s.GetProductById(ctx, productID)
 }
 
 type task struct {
@@ -62,7 +93,7 @@ type task struct {
 func (s *ProductService) worker(ctx context.Context, tasks <-chan task, wg *sync.WaitGroup, errChan chan<- error) {
 	defer wg.Done()
 	for t := range tasks {
-		log.Printf("Processing %V: %v", t.taskType, t.value)
+		log.Printf("Processing %v: %v", t.taskType, t.value)
 		switch t.taskType {
 		case "color":
 			err := s.processColor(ctx, t.value, t.productID)
@@ -86,7 +117,7 @@ func (s *ProductService) AddProduct(
 	Name string,
 	Description string,
 	ImageUrl string,
-	Price string,
+	Price money.Money,
 	Stock int32,
 	SubCategoryID string,
 	Brand string,
@@ -94,6 +125,9 @@ func (s *ProductService) AddProduct(
 	Colours []string,
 	Materials []string,
 ) (model.Product, error) {
+	if Price.Currency() != money.DefaultCurrency {
+		return model.Product{}, ErrInvalidPriceCurrency
+	}
 
 	descriptionValue := sql.NullString{}
 	if Description != "" {
@@ -180,7 +214,7 @@ func (s *ProductService) AddProduct(
 	}
 
 	// return created product
-	return newProduct, nil
+	return withPricing(newProduct), nil
 }
 
 // processColor processes product colour
@@ -241,12 +275,10 @@ func (s *ProductService) UpdateProduct(
 	Name string,
 	Description string,
 	ImageUrl string,
-	Price string,
+	Price *money.Money,
 	Stock int32,
 	SubCategoryID string,
 	Brand string,
-	Rating string,
-	ReviewCount int32,
 	DiscountRate string,
 	Keywords string,
 	IsActive bool,
@@ -272,24 +304,22 @@ func (s *ProductService) UpdateProduct(
 		Name = existingProduct.Name
 	}
 
-	if Price == "" {
-		Price = existingProduct.Price
+	price := existingProduct.Price
+	if Price != nil {
+		if Price.Currency() != money.DefaultCurrency {
+			return model.Product{}, ErrInvalidPriceCurrency
+		}
+		price = *Price
 	}
 
 	if Stock == 0 {
 		Stock = existingProduct.Stock
 	}
 
-	if Rating == "" {
-		Rating = existingProduct.Rating
-	}
-
-	if ReviewCount == 0 {
-		ReviewCount = existingProduct.ReviewCount
-	}
-
 	if DiscountRate == "" {
 		DiscountRate = existingProduct.DiscountRate
+	} else if !discountRatePattern.MatchString(DiscountRate) {
+		return model.Product{}, ErrInvalidDiscountRate
 	}
 
 	if Keywords == "" {
@@ -349,12 +379,10 @@ func (s *ProductService) UpdateProduct(
 		Name:          Name,
 		Description:   descriptionValue,
 		ImageUrl:      imageUrlValue,
-		Price:         Price,
+		Price:         price,
 		Stock:         Stock,
 		SubCategoryID: subCategoryIDValue,
 		Brand:         brandValue,
-		Rating:        Rating,
-		ReviewCount:   ReviewCount,
 		DiscountRate:  DiscountRate,
 		Keywords:      keywordsValue,
 		IsActive:      IsActive,
@@ -414,7 +442,7 @@ func (s *ProductService) UpdateProduct(
 	}
 
 	// return updated product
-	return updatedProduct, nil
+	return withPricing(updatedProduct), nil
 }
 
 // Delete and existing product
@@ -422,14 +450,63 @@ func (s *ProductService) DeleteProduct(ctx context.Context, productID uuid.UUID)
 	return s.productRepo.DeleteProduct(ctx, productID)
 }
 
-// Fetches a particular product
-func (s *ProductService) GetProductById(ctx context.Context, id uuid.UUID) (database.Product, error) {
-	return s.productRepo.GetProductById(ctx, id)
+// Fetches a particular product, priced in the currency the request asked for
+func (s *ProductService) GetProductById(ctx context.Context, id uuid.UUID) (model.Product, error) {
+	product, err := s.productRepo.GetProductById(ctx, id)
+	if err != nil {
+		return model.Product{}, err
+	}
+
+	product = withPricing(product)
+	converter := displayCurrency(ctx)
+	product.Price = converter.Convert(product.Price)
+	product.Pricing = pricing.Convert(product.Pricing, converter)
+	return product, nil
+}
+
+// RecordProductView remembers that the logged-in user, if any, viewed a product's page. Failing
+// to do so is only logged, as it should not fail the request.
+func (s *ProductService) RecordProductView(ctx context.Context, productId uuid.UUID) {
+	userId, ok := ctx.Value("userId").(uuid.UUID)
+	if !ok {
+		return
+	}
+	if err := s.recentlyViewedRepo.RecordProductView(ctx, userId, productId, s.recentlyViewedRetention); err != nil {
+		log.Printf("Error recording view of product with id %s: %s", productId.String(), err.Error())
+	}
+}
+
+// GetRecentlyViewedProducts gets the products the logged-in user viewed most recently and that are
+// still sold, most recent first
+func (s *ProductService) GetRecentlyViewedProducts(ctx context.Context, limit int32) ([]model.Product, error) {
+	if limit <= 0 {
+		limit = defaultRecentlyViewedLimit
+	}
+	if limit > s.recentlyViewedRetention {
+		limit = s.recentlyViewedRetention
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	products, err := s.recentlyViewedRepo.GetRecentlyViewedProducts(ctx, userId, limit)
+	if err != nil {
+		return nil, err
+	}
+	pricing.ApplyToProducts(products, time.Now())
+	pricing.ConvertProducts(products, displayCurrency(ctx))
+	return products, nil
 }
 
 // Fetches available products
-func (s *ProductService) GetAvailableProducts(ctx context.Context) ([]database.Product, error) {
-	return s.productRepo.GetAvailableProducts(ctx)
+func (s *ProductService) GetAvailableProducts(ctx context.Context) ([]model.Product, error) {
+	products, err := s.productRepo.GetAvailableProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pricing.ApplyToProducts(products, time.Now())
+	pricing.ConvertProducts(products, displayCurrency(ctx))
+	return products, nil
 }
 
 // Filters products based by category
@@ -453,7 +530,17 @@ func (s *ProductService) GetProductsByCategory(ctx context.Context, categoryIdSt
 		page,
 		pageSize,
 		func(offset, limit int32) (interface{}, error) {
-			return s.productRepo.GetProductsByCategory(ctx, categoryID)
+			products, err := s.productRepo.GetProductsByCategory(ctx, categoryID)
+			if err != nil {
+				return nil, err
+			}
+			now := time.Now()
+			converter := displayCurrency(ctx)
+			for i := range products {
+				products[i].Pricing = pricing.Convert(pricing.Calculate(products[i].Price, products[i].DiscountRate, products[i].SaleStartsAt, products[i].SaleEndsAt, now), converter)
+				products[i].Price = converter.Convert(products[i].Price)
+			}
+			return products, nil
 		},
 	)
 	if err != nil {
@@ -464,8 +551,14 @@ func (s *ProductService) GetProductsByCategory(ctx context.Context, categoryIdSt
 }
 
 // Searches for a particular product
-func (s *ProductService) SearchProducts(ctx context.Context, query sql.NullString) ([]database.Product, error) {
-	return s.productRepo.SearchProducts(ctx, query)
+func (s *ProductService) SearchProducts(ctx context.Context, query sql.NullString) ([]model.Product, error) {
+	products, err := s.productRepo.SearchProducts(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	pricing.ApplyToProducts(products, time.Now())
+	pricing.ConvertProducts(products, displayCurrency(ctx))
+	return products, nil
 }
 
 // Returns a sales trend for the current month
@@ -479,6 +572,13 @@ func (s *ProductService) GetTrendingProducts(ctx context.Context) ([]model.Trend
 	if err != nil {
 		return nil, err
 	}
+
+	now := time.Now()
+	converter := displayCurrency(ctx)
+	for i := range trendingProducts {
+		trendingProducts[i].Pricing = pricing.Convert(pricing.Calculate(trendingProducts[i].Price, trendingProducts[i].DiscountRate, trendingProducts[i].SaleStartsAt, trendingProducts[i].SaleEndsAt, now), converter)
+		trendingProducts[i].Price = converter.Convert(trendingProducts[i].Price)
+	}
 	return trendingProducts, nil
 }
 
@@ -581,3 +681,26 @@ func (s *ProductService) GetProductColours(ctx context.Context, productId uuid.U
 
 	return *paginatedColours, nil
 }
+
+// UpdateProductSale sets a product's discount rate and the window it applies in. Either end of
+// the window may be left open; without both the discount applies until it is changed.
+func (s *ProductService) UpdateProductSale(ctx context.Context, productId uuid.UUID, params model.UpdateProductSaleParams) error {
+	if !discountRatePattern.MatchString(params.DiscountRate) {
+		return ErrInvalidDiscountRate
+	}
+	if params.SaleStartsAt.Valid && params.SaleEndsAt.Valid && !params.SaleEndsAt.Time.After(params.SaleStartsAt.Time) {
+		return ErrInvalidSaleWindow
+	}
+
+	err := s.productRepo.UpdateProductSale(ctx, productId, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrProductNotFound
+	}
+	return err
+}
+
+// withPricing prices a product as it sells right now
+func withPricing(product model.Product) model.Product {
+	product.Pricing = pricing.Calculate(product.Price, product.DiscountRate, product.SaleStartsAt, product.SaleEndsAt, time.Now())
+	return product
+}
@@ -0,0 +1,364 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidPromotionCode   = errors.New("promotion code must be 3 to 50 letters, digits, dashes or underscores")
+	ErrInvalidDiscountType    = errors.New("discount type must be one of percentage, fixed_amount or free_shipping")
+	ErrInvalidDiscountValue   = errors.New("percentage discounts must be above 0 and at most 100, fixed amount discounts above 0")
+	ErrInvalidMinCartValue    = errors.New("minimum cart value must be an amount of zero or more with at most two decimal places")
+	ErrInvalidPromotionWindow = errors.New("promotion must end after it starts")
+	ErrInvalidUsageLimit      = errors.New("usage limits must be greater than zero")
+	ErrInvalidPromotionTarget = errors.New("promotion targets must be a category, sub_category or product")
+	ErrPromotionCodeExists    = errors.New("promotion code already exists")
+	ErrPromotionNotFound      = errors.New("promotion not found")
+	ErrNoCartPromotion        = errors.New("no promotion code is applied to the cart")
+)
+
+// Reasons a promotion code cannot be used on a cart
+var (
+	ErrPromotionInactive      = errors.New("promotion code is not active")
+	ErrPromotionNotStarted    = errors.New("promotion code is not valid yet")
+	ErrPromotionExpired       = errors.New("promotion code has expired")
+	ErrPromotionUsedUp        = errors.New("promotion code has been fully redeemed")
+	ErrPromotionUserLimit     = errors.New("promotion code has already been used the maximum number of times")
+	ErrPromotionMinCartValue  = errors.New("cart total is below the minimum for this promotion code")
+	ErrPromotionNotApplicable = errors.New("promotion code does not apply to any item in the cart")
+)
+
+var promotionRejections = []error{
+	ErrPromotionInactive,
+	ErrPromotionNotStarted,
+	ErrPromotionExpired,
+	ErrPromotionUsedUp,
+	ErrPromotionUserLimit,
+	ErrPromotionMinCartValue,
+	ErrPromotionNotApplicable,
+}
+
+var promotionCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,50}$`)
+
+var discountTypes = map[string]bool{
+	"percentage":    true,
+	"fixed_amount":  true,
+	"free_shipping": true,
+}
+
+var promotionTargetTypes = map[string]bool{
+	"category":     true,
+	"sub_category": true,
+	"product":      true,
+}
+
+type PromotionService struct {
+	promotionRepo repository.PromotionRepository
+}
+
+func NewPromotionService(promotionRepo repository.PromotionRepository) *PromotionService {
+	return &PromotionService{
+		promotionRepo: promotionRepo,
+	}
+}
+
+// CreatePromotion creates a promotion code
+func (s *PromotionService) CreatePromotion(ctx context.Context, params model.CreatePromotionParams) (model.PromotionDetail, error) {
+	// validate code, discount, minimum cart value, window, limits and targets
+	params.Code = normalizePromotionCode(params.Code)
+	if !promotionCodePattern.MatchString(params.Code) {
+		return model.PromotionDetail{}, ErrInvalidPromotionCode
+	}
+	if !discountTypes[params.DiscountType] {
+		return model.PromotionDetail{}, ErrInvalidDiscountType
+	}
+	if params.DiscountType == "free_shipping" {
+		params.DiscountValue = "0.00"
+	} else {
+		value, err := money.Parse(params.DiscountValue, money.DefaultCurrency)
+		if err != nil || value.Sign() <= 0 ||
+			(params.DiscountType == "percentage" && value.Rat().Cmp(big.NewRat(100, 1)) > 0) {
+			return model.PromotionDetail{}, ErrInvalidDiscountValue
+		}
+	}
+	if params.MinCartValue.Valid {
+		if _, err := money.Parse(params.MinCartValue.String, money.DefaultCurrency); err != nil {
+			return model.PromotionDetail{}, ErrInvalidMinCartValue
+		}
+	}
+	if params.StartsAt.Valid && params.EndsAt.Valid && !params.EndsAt.Time.After(params.StartsAt.Time) {
+		return model.PromotionDetail{}, ErrInvalidPromotionWindow
+	}
+	if (params.MaxUses.Valid && params.MaxUses.Int32 < 1) ||
+		(params.MaxUsesPerUser.Valid && params.MaxUsesPerUser.Int32 < 1) {
+		return model.PromotionDetail{}, ErrInvalidUsageLimit
+	}
+	for _, target := range params.Targets {
+		if !promotionTargetTypes[target.TargetType] || target.TargetID == uuid.Nil {
+			return model.PromotionDetail{}, ErrInvalidPromotionTarget
+		}
+	}
+
+	promotion, err := s.promotionRepo.CreatePromotion(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.PromotionDetail{}, ErrPromotionCodeExists
+	}
+	return promotion, err
+}
+
+// GetPromotions gets every promotion, newest first
+func (s *PromotionService) GetPromotions(ctx context.Context) ([]model.Promotion, error) {
+	return s.promotionRepo.GetPromotions(ctx)
+}
+
+// GetPromotion gets a promotion with the products and categories it is limited to
+func (s *PromotionService) GetPromotion(ctx context.Context, promotionId uuid.UUID) (model.PromotionDetail, error) {
+	promotion, err := s.promotionRepo.GetPromotion(ctx, promotionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.PromotionDetail{}, ErrPromotionNotFound
+	}
+	return promotion, err
+}
+
+// SetPromotionActive switches a promotion on or off
+func (s *PromotionService) SetPromotionActive(ctx context.Context, promotionId uuid.UUID, active bool) error {
+	err := s.promotionRepo.SetPromotionActive(ctx, promotionId, active)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPromotionNotFound
+	}
+	return err
+}
+
+// GetPromotionReport sums up the orders placed with a promotion
+func (s *PromotionService) GetPromotionReport(ctx context.Context, promotionId uuid.UUID) (model.PromotionReport, error) {
+	report, err := s.promotionRepo.GetPromotionReport(ctx, promotionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.PromotionReport{}, ErrPromotionNotFound
+	}
+	return report, err
+}
+
+// ApplyPromotion checks a code against the cart's items and, if it can be used, applies it to the cart
+func (s *PromotionService) ApplyPromotion(ctx context.Context, userId uuid.UUID, cartId uuid.UUID, code string, items []model.CartItemDetail) error {
+	promotion, err := s.promotionRepo.GetPromotionByCode(ctx, normalizePromotionCode(code))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPromotionNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.evaluate(ctx, userId, promotion, items); err != nil {
+		return err
+	}
+
+	return s.promotionRepo.SetCartPromotion(ctx, cartId, promotion.ID)
+}
+
+// RemovePromotion takes the promotion code off a cart
+func (s *PromotionService) RemovePromotion(ctx context.Context, cartId uuid.UUID) error {
+	err := s.promotionRepo.RemoveCartPromotion(ctx, cartId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNoCartPromotion
+	}
+	return err
+}
+
+// EvaluateCartPromotion works out the discount the code applied to a cart gives on its items. It
+// returns nil if no code is applied. If the code can no longer be used, the reason is returned as
+// the error along with the promotion, which has no discount and its Error set.
+func (s *PromotionService) EvaluateCartPromotion(ctx context.Context, userId uuid.UUID, cartId uuid.UUID, items []model.CartItemDetail) (*model.AppliedPromotion, error) {
+	promotion, err := s.promotionRepo.GetCartPromotion(ctx, cartId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := s.evaluate(ctx, userId, promotion, items)
+	if IsPromotionRejection(err) {
+		return &model.AppliedPromotion{
+			PromotionID:   promotion.ID,
+			Code:          promotion.Code,
+			Description:   promotion.Description,
+			DiscountType:  promotion.DiscountType,
+			Lines:         []model.LineDiscount{},
+			ItemsDiscount: money.Zero(money.DefaultCurrency),
+			Error:         err.Error(),
+		}, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &applied, nil
+}
+
+// evaluate checks a promotion can be used by the user on the items and works out its discount
+func (s *PromotionService) evaluate(ctx context.Context, userId uuid.UUID, promotion model.PromotionDetail, items []model.CartItemDetail) (model.AppliedPromotion, error) {
+	// check the promotion is live and has uses left
+	now := time.Now()
+	switch {
+	case !promotion.IsActive:
+		return model.AppliedPromotion{}, ErrPromotionInactive
+	case promotion.StartsAt.Valid && now.Before(promotion.StartsAt.Time):
+		return model.AppliedPromotion{}, ErrPromotionNotStarted
+	case promotion.EndsAt.Valid && !now.Before(promotion.EndsAt.Time):
+		return model.AppliedPromotion{}, ErrPromotionExpired
+	case promotion.MaxUses.Valid && promotion.TimesUsed >= promotion.MaxUses.Int32:
+		return model.AppliedPromotion{}, ErrPromotionUsedUp
+	}
+	if promotion.MaxUsesPerUser.Valid {
+		used, err := s.promotionRepo.GetUserRedemptionCount(ctx, promotion.ID, userId)
+		if err != nil {
+			return model.AppliedPromotion{}, err
+		}
+		if used >= int64(promotion.MaxUsesPerUser.Int32) {
+			return model.AppliedPromotion{}, ErrPromotionUserLimit
+		}
+	}
+
+	// total the cart and the items the promotion covers
+	subtotal := money.Zero(money.DefaultCurrency)
+	eligibleTotal := money.Zero(money.DefaultCurrency)
+	var eligible []model.CartItemDetail
+	lineTotals := make(map[uuid.UUID]money.Money, len(items))
+	for _, item := range items {
+		lineTotal := item.UnitPrice.Mul(int64(item.Quantity))
+		lineTotals[item.ProductID] = lineTotal
+		subtotal = subtotal.Add(lineTotal)
+
+		if promotionCovers(promotion.Targets, item) {
+			eligible = append(eligible, item)
+			eligibleTotal = eligibleTotal.Add(lineTotal)
+		}
+	}
+	if promotion.MinCartValue.Valid {
+		if minimum, err := money.FromDecimal(promotion.MinCartValue.String, money.DefaultCurrency); err == nil && subtotal.Cmp(minimum) < 0 {
+			return model.AppliedPromotion{}, ErrPromotionMinCartValue
+		}
+	}
+	if len(eligible) == 0 {
+		return model.AppliedPromotion{}, ErrPromotionNotApplicable
+	}
+
+	applied := model.AppliedPromotion{
+		PromotionID:   promotion.ID,
+		Code:          promotion.Code,
+		Description:   promotion.Description,
+		DiscountType:  promotion.DiscountType,
+		Lines:         []model.LineDiscount{},
+		ItemsDiscount: money.Zero(money.DefaultCurrency),
+	}
+
+	// spread the discount over the items it covers
+	itemsDiscount := money.Zero(money.DefaultCurrency)
+	switch promotion.DiscountType {
+	case "free_shipping":
+		applied.FreeShipping = true
+	case "percentage":
+		percentage, ok := new(big.Rat).SetString(promotion.DiscountValue)
+		if !ok {
+			percentage = new(big.Rat)
+		}
+		rate := new(big.Rat).Quo(percentage, big.NewRat(100, 1))
+		for _, item := range eligible {
+			discount := lineTotals[item.ProductID].MulRat(rate)
+			itemsDiscount = itemsDiscount.Add(discount)
+			applied.Lines = append(applied.Lines, model.LineDiscount{ProductID: item.ProductID, Amount: discount})
+		}
+	case "fixed_amount":
+		// never take off more than the covered items cost. Each item takes its share of the running
+		// total, so the rounded shares always add up to the value and none exceeds its line.
+		value, err := money.FromDecimal(promotion.DiscountValue, money.DefaultCurrency)
+		if err != nil {
+			return model.AppliedPromotion{}, err
+		}
+		value = value.Min(eligibleTotal)
+		covered := money.Zero(money.DefaultCurrency)
+		allocated := money.Zero(money.DefaultCurrency)
+		for _, item := range eligible {
+			covered = covered.Add(lineTotals[item.ProductID])
+			share := allocated
+			if !eligibleTotal.IsZero() {
+				share = value.MulRat(big.NewRat(covered.Cents(), eligibleTotal.Cents()))
+			}
+			discount := share.Sub(allocated)
+			allocated = share
+			itemsDiscount = itemsDiscount.Add(discount)
+			applied.Lines = append(applied.Lines, model.LineDiscount{ProductID: item.ProductID, Amount: discount})
+		}
+	}
+	applied.ItemsDiscount = itemsDiscount
+
+	return applied, nil
+}
+
+// discountedTaxLines lists the cart's items as lines to be taxed, less the promotion's discount
+func discountedTaxLines(items []model.CartItemDetail, applied *model.AppliedPromotion) []model.TaxLine {
+	lines := taxLinesFromCart(items)
+	if applied == nil || applied.Error != "" {
+		return lines
+	}
+
+	discounts := make(map[uuid.UUID]money.Money, len(applied.Lines))
+	for _, line := range applied.Lines {
+		discounts[line.ProductID] = line.Amount
+	}
+	for i := range lines {
+		lines[i].Discount = discounts[lines[i].ProductID]
+	}
+	return lines
+}
+
+// promotionCovers checks whether an item is one a promotion's targets cover. A promotion
+// without targets covers every item.
+func promotionCovers(targets []model.PromotionTarget, item model.CartItemDetail) bool {
+	if len(targets) == 0 {
+		return true
+	}
+
+	for _, target := range targets {
+		switch target.TargetType {
+		case "product":
+			if target.TargetID == item.ProductID {
+				return true
+			}
+		case "sub_category":
+			if item.SubCategoryID.Valid && target.TargetID == item.SubCategoryID.UUID {
+				return true
+			}
+		case "category":
+			if item.CategoryID.Valid && target.TargetID == item.CategoryID.UUID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsPromotionRejection checks whether an error is a reason a promotion code cannot be used
+func IsPromotionRejection(err error) bool {
+	for _, rejection := range promotionRejections {
+		if errors.Is(err, rejection) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePromotionCode trims and upper-cases a code, as codes are stored
+func normalizePromotionCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/google/uuid"
+)
+
+func TestEvaluateFixedAmountSplit(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		unitPrices   []int64
+		quantities   []int32
+		wantDiscount int64
+	}{
+		{"single item", "5.00", []int64{2000}, []int32{1}, 500},
+		{"even split", "6.00", []int64{1000, 1000, 1000}, []int32{1, 1, 1}, 600},
+		{"uneven split", "10.00", []int64{999, 1999, 2999}, []int32{1, 2, 1}, 1000},
+		{"shares that all round up", "0.03", []int64{100, 100, 100, 100, 100}, []int32{1, 1, 1, 1, 1}, 3},
+		{"shares that all round down", "0.02", []int64{100, 100, 100}, []int32{1, 1, 1}, 2},
+		{"one cent over many lines", "0.01", []int64{1, 1, 1, 1}, []int32{1, 1, 1, 1}, 1},
+		{"value capped at the covered items", "50.00", []int64{1250, 750}, []int32{1, 2}, 2750},
+		{"value equal to the covered items", "0.04", []int64{1, 1, 1, 1}, []int32{1, 1, 1, 1}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]model.CartItemDetail, len(tt.unitPrices))
+			for i, price := range tt.unitPrices {
+				items[i] = model.CartItemDetail{
+					CartItem:  model.CartItem{ProductID: uuid.New(), Quantity: tt.quantities[i]},
+					UnitPrice: money.New(price, money.DefaultCurrency),
+				}
+			}
+			promotion := model.PromotionDetail{Promotion: model.Promotion{
+				ID:            uuid.New(),
+				Code:          "SAVE",
+				DiscountType:  "fixed_amount",
+				DiscountValue: tt.value,
+				IsActive:      true,
+			}}
+
+			applied, err := (&PromotionService{}).evaluate(context.Background(), uuid.New(), promotion, items)
+			if err != nil {
+				t.Fatalf("evaluate() error = %v", err)
+			}
+
+			if applied.ItemsDiscount.Cents() != tt.wantDiscount {
+				t.Errorf("ItemsDiscount = %d cents, want %d", applied.ItemsDiscount.Cents(), tt.wantDiscount)
+			}
+			if len(applied.Lines) != len(items) {
+				t.Fatalf("got %d line discounts, want %d", len(applied.Lines), len(items))
+			}
+			sum := money.Zero(money.DefaultCurrency)
+			for i, line := range applied.Lines {
+				lineTotal := items[i].UnitPrice.Mul(int64(items[i].Quantity))
+				if line.Amount.Sign() < 0 || line.Amount.Cmp(lineTotal) > 0 {
+					t.Errorf("line %d discount = %s, want between 0 and %s", i, line.Amount, lineTotal)
+				}
+				sum = sum.Add(line.Amount)
+			}
+			if sum.Cents() != tt.wantDiscount {
+				t.Errorf("line discounts add up to %d cents, want %d", sum.Cents(), tt.wantDiscount)
+			}
+		})
+	}
+}
+
+func TestEvaluateFixedAmountOnlyCoversTargets(t *testing.T) {
+	covered := model.CartItemDetail{
+		CartItem:  model.CartItem{ProductID: uuid.New(), Quantity: 1},
+		UnitPrice: money.New(300, money.DefaultCurrency),
+	}
+	other := model.CartItemDetail{
+		CartItem:  model.CartItem{ProductID: uuid.New(), Quantity: 1},
+		UnitPrice: money.New(5000, money.DefaultCurrency),
+	}
+	promotion := model.PromotionDetail{
+		Promotion: model.Promotion{
+			ID:            uuid.New(),
+			Code:          "SAVE",
+			DiscountType:  "fixed_amount",
+			DiscountValue: "10.00",
+			IsActive:      true,
+		},
+		Targets: []model.PromotionTarget{{TargetType: "product", TargetID: covered.ProductID}},
+	}
+
+	applied, err := (&PromotionService{}).evaluate(context.Background(), uuid.New(), promotion, []model.CartItemDetail{covered, other})
+	if err != nil {
+		t.Fatalf("evaluate() error = %v", err)
+	}
+	if applied.ItemsDiscount.Cents() != 300 {
+		t.Errorf("ItemsDiscount = %d cents, want 300", applied.ItemsDiscount.Cents())
+	}
+	if len(applied.Lines) != 1 || applied.Lines[0].ProductID != covered.ProductID {
+		t.Errorf("Lines = %+v, want a single line for the covered product", applied.Lines)
+	}
+}
@@ -0,0 +1,223 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/pricing"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultRecommendationLimit and maxRecommendationLimit bound how many products are recommended
+	defaultRecommendationLimit = 10
+	maxRecommendationLimit     = 50
+
+	// minCoPurchaseOrders is how many orders two products must share before one is recommended
+	// with the other, so a single order does not decide recommendations
+	minCoPurchaseOrders = 2
+
+	// minWishlistUsers is how many users must have saved two products before one is recommended
+	// with the other
+	minWishlistUsers = 2
+
+	// coPurchasesPerProduct and wishlistAffinitiesPerProduct are how many bought-together and
+	// saved-together products are kept for each product
+	coPurchasesPerProduct        = maxRecommendationLimit
+	wishlistAffinitiesPerProduct = maxRecommendationLimit
+)
+
+type RecommendationService struct {
+	recommendationRepo repository.RecommendationRepository
+	productRepo        repository.ProductRepository
+}
+
+func NewRecommendationService(recommendationRepo repository.RecommendationRepository, productRepo repository.ProductRepository) *RecommendationService {
+	return &RecommendationService{
+		recommendationRepo: recommendationRepo,
+		productRepo:        productRepo,
+	}
+}
+
+// GetProductRecommendations gets the products customers most often bought together with a
+// product. When there are not enough of those, the rest are the bestsellers of the product's
+// sub-category.
+func (s *RecommendationService) GetProductRecommendations(ctx context.Context, productId uuid.UUID, limit int32) ([]model.RecommendedProduct, error) {
+	limit = recommendationLimit(limit)
+
+	// get product
+	product, err := s.productRepo.GetProductById(ctx, productId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProductNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// get products bought together with it
+	products, err := s.recommendationRepo.GetCoPurchasedProducts(ctx, productId, limit)
+	if err != nil {
+		return nil, err
+	}
+	recommendations := appendRecommendations(nil, products, model.RecommendationReasonBoughtTogether, limit)
+
+	// top up with sub-category bestsellers not already recommended
+	if int32(len(recommendations)) < limit && product.SubCategoryID.Valid {
+		bestsellers, err := s.recommendationRepo.GetSubCategoryBestsellers(ctx, product.SubCategoryID.UUID, productId, limit)
+		if err != nil {
+			return nil, err
+		}
+		recommendations = appendRecommendations(recommendations, bestsellers, model.RecommendationReasonBestseller, limit)
+	}
+
+	return priceRecommendations(ctx, recommendations), nil
+}
+
+// GetAlsoSavedProducts gets the products most often saved to wishlists by the people who saved a
+// product
+func (s *RecommendationService) GetAlsoSavedProducts(ctx context.Context, productId uuid.UUID, limit int32) ([]model.RecommendedProduct, error) {
+	limit = recommendationLimit(limit)
+
+	// check product exists
+	if _, err := s.productRepo.GetProductById(ctx, productId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	// get products saved together with it
+	products, err := s.recommendationRepo.GetAlsoSavedProducts(ctx, productId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := appendRecommendations(nil, products, model.RecommendationReasonSavedTogether, limit)
+	return priceRecommendations(ctx, recommendations), nil
+}
+
+// GetUserRecommendations gets products for the logged-in user based on what people who saved the
+// same products, or the products the user recently viewed, also saved. When there are not enough
+// of those, the rest are the products saved by the most people. Products the user already saved
+// or bought are never recommended.
+func (s *RecommendationService) GetUserRecommendations(ctx context.Context, limit int32) ([]model.RecommendedProduct, error) {
+	limit = recommendationLimit(limit)
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	// get products saved together with the user's saved and recently viewed products
+	products, err := s.recommendationRepo.GetUserRecommendedProducts(ctx, userId, limit)
+	if err != nil {
+		return nil, err
+	}
+	recommendations := appendRecommendations(nil, products, model.RecommendationReasonSavedTogether, limit)
+
+	// top up with the most saved products not already recommended
+	if int32(len(recommendations)) < limit {
+		popular, err := s.recommendationRepo.GetPopularWishlistedProducts(ctx, userId, limit)
+		if err != nil {
+			return nil, err
+		}
+		recommendations = appendRecommendations(recommendations, popular, model.RecommendationReasonPopularSaved, limit)
+	}
+
+	return priceRecommendations(ctx, recommendations), nil
+}
+
+// RefreshRecommendations recomputes which products are bought together from the orders so far,
+// and which are saved together from the wishlists
+func (s *RecommendationService) RefreshRecommendations(ctx context.Context) error {
+	started := time.Now()
+	pairs, err := s.recommendationRepo.RefreshCoPurchases(ctx, minCoPurchaseOrders, coPurchasesPerProduct)
+	if err != nil {
+		log.Printf("Error refreshing product recommendations: %s", err.Error())
+		return err
+	}
+
+	savedPairs, err := s.recommendationRepo.RefreshWishlistAffinities(ctx, minWishlistUsers, wishlistAffinitiesPerProduct)
+	if err != nil {
+		log.Printf("Error refreshing wishlist recommendations: %s", err.Error())
+		return err
+	}
+
+	log.Printf("Refreshed product recommendations: %d bought together and %d saved together product pairs in %s", pairs, savedPairs, time.Since(started))
+	return nil
+}
+
+// StartRefreshing refreshes recommendations now and then every interval, in the background, until
+// ctx is done
+func (s *RecommendationService) StartRefreshing(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			// errors are logged, and the next tick tries again
+			_ = s.RefreshRecommendations(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// recommendationLimit applies the default and maximum number of recommendations to limit
+func recommendationLimit(limit int32) int32 {
+	if limit <= 0 {
+		return defaultRecommendationLimit
+	}
+	if limit > maxRecommendationLimit {
+		return maxRecommendationLimit
+	}
+	return limit
+}
+
+// appendRecommendations adds the products not already recommended, for a reason, until there are
+// limit recommendations
+func appendRecommendations(recommendations []model.RecommendedProduct, products []model.Product, reason string, limit int32) []model.RecommendedProduct {
+	seen := make(map[uuid.UUID]bool, len(recommendations))
+	for _, r := range recommendations {
+		seen[r.ID] = true
+	}
+
+	for _, p := range products {
+		if int32(len(recommendations)) == limit {
+			break
+		}
+		if seen[p.ID] {
+			continue
+		}
+		seen[p.ID] = true
+		recommendations = append(recommendations, model.RecommendedProduct{
+			Product: p,
+			Reason:  reason,
+		})
+	}
+	return recommendations
+}
+
+// priceRecommendations applies current sales to the recommended products and converts their
+// prices to the display currency
+func priceRecommendations(ctx context.Context, recommendations []model.RecommendedProduct) []model.RecommendedProduct {
+	products := make([]model.Product, len(recommendations))
+	for i, r := range recommendations {
+		products[i] = r.Product
+	}
+
+	pricing.ApplyToProducts(products, time.Now())
+	pricing.ConvertProducts(products, displayCurrency(ctx))
+
+	for i := range recommendations {
+		recommendations[i].Product = products[i]
+	}
+	return recommendations
+}
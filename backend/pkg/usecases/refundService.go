@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/payment"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRefundFailed        = errors.New("refund failed")
+	ErrRefundNotFound      = errors.New("refund not found")
+	ErrInvalidAmount       = errors.New("amount must be a positive number with at most two decimal places and eight digits before the point")
+	ErrInvalidRefundItems  = errors.New("refund items must have a positive quantity and appear once each")
+	ErrInvalidRefundMethod = errors.New("refund method must be one of original or store_credit")
+)
+
+type RefundService struct {
+	refundRepo repository.RefundRepository
+	gateway    payment.PaymentGateway
+}
+
+func NewRefundService(refundRepo repository.RefundRepository, gateway payment.PaymentGateway) *RefundService {
+	return &RefundService{
+		refundRepo: refundRepo,
+		gateway:    gateway,
+	}
+}
+
+// IssueRefund refunds some or all of an order on behalf of the logged-in user, either to the
+// payment method that was charged or, by method store_credit, to the customer's store credit.
+// Without an amount the refund covers the given items, or whatever is left when there are none.
+// A declined refund stays in the ledger as failed and comes back with an error wrapping ErrRefundFailed.
+func (s *RefundService) IssueRefund(ctx context.Context, orderId uuid.UUID, amount string, items []model.RefundItemParams, reason string, method string) (model.RefundDetail, error) {
+	// validate method, amount, items and reason
+	if method == "" {
+		method = "original"
+	}
+	if method != "original" && method != "store_credit" {
+		return model.RefundDetail{}, ErrInvalidRefundMethod
+	}
+	var value money.Money
+	if amount = strings.TrimSpace(amount); amount != "" {
+		var err error
+		value, err = money.Parse(amount, money.DefaultCurrency)
+		if err != nil || value.Sign() <= 0 {
+			return model.RefundDetail{}, ErrInvalidAmount
+		}
+	}
+	seen := make(map[uuid.UUID]bool, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 || seen[item.OrderItemID] {
+			return model.RefundDetail{}, ErrInvalidRefundItems
+		}
+		seen[item.OrderItemID] = true
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) > 255 {
+		return model.RefundDetail{}, ErrReasonTooLong
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	// record the refund before the money moves
+	detail, err := s.refundRepo.CreateRefund(ctx, model.CreateRefundParams{
+		OrderID:   orderId,
+		Amount:    value,
+		Items:     items,
+		Reason:    reason,
+		CreatedBy: userId,
+		Method:    method,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.RefundDetail{}, ErrOrderNotFound
+	}
+	if err != nil {
+		return model.RefundDetail{}, err
+	}
+
+	refund, err := s.processRefund(ctx, detail.ID)
+	detail.Refund = refund
+	return detail, err
+}
+
+// ProcessRefund settles a pending refund, such as one recorded for a received return, through the
+// gateway or to store credit
+func (s *RefundService) ProcessRefund(ctx context.Context, refundId uuid.UUID) (model.Refund, error) {
+	return s.processRefund(ctx, refundId)
+}
+
+// GetOrderRefunds gets the refund ledger of an order
+func (s *RefundService) GetOrderRefunds(ctx context.Context, orderId uuid.UUID) ([]model.RefundDetail, error) {
+	return s.refundRepo.GetOrderRefunds(ctx, orderId)
+}
+
+// processRefund claims a pending refund and settles it through the gateway, or adds it to the
+// customer's store credit
+func (s *RefundService) processRefund(ctx context.Context, refundId uuid.UUID) (model.Refund, error) {
+	pending, err := s.refundRepo.GetRefund(ctx, refundId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Refund{}, ErrRefundNotFound
+	}
+	if err != nil {
+		return model.Refund{}, err
+	}
+	if pending.Method == "store_credit" {
+		return s.refundRepo.CreditRefundToStoreCredit(ctx, refundId)
+	}
+
+	refund, captured, err := s.refundRepo.StartRefund(ctx, refundId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Refund{}, ErrRefundNotFound
+	}
+	if err != nil {
+		return model.Refund{}, err
+	}
+
+	// give the money back in the currency it was paid in
+	amount := refund.Amount.String()
+	if refund.SettlementAmount.Valid {
+		amount = refund.SettlementAmount.String
+	}
+	result, err := s.gateway.Refund(ctx, captured.ProviderTransactionID.String, amount)
+	if err != nil || !result.Approved {
+		message := result.Message
+		if err != nil {
+			message = err.Error()
+		}
+		if len(message) > 255 {
+			message = message[:255]
+		}
+
+		refund, finishErr := s.refundRepo.FinishRefund(ctx, refundId, false, result.TransactionID, message)
+		if finishErr != nil {
+			return model.Refund{}, finishErr
+		}
+		return refund, fmt.Errorf("%w: %s", ErrRefundFailed, message)
+	}
+
+	return s.refundRepo.FinishRefund(ctx, refundId, true, result.TransactionID, "")
+}
@@ -0,0 +1,171 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/utils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrReturnNotFound      = errors.New("return request not found")
+	ErrInvalidReturnReason = errors.New("reason must be between 1 and 255 characters")
+	ErrInvalidReturnItems  = errors.New("items must list each order item once with a positive quantity")
+	ErrUnknownReturnStatus = errors.New("return status must be one of requested, approved, rejected or received")
+)
+
+var returnStatuses = map[string]bool{
+	"requested": true,
+	"approved":  true,
+	"rejected":  true,
+	"received":  true,
+}
+
+type ReturnService struct {
+	returnRepo       repository.ReturnRepository
+	orderRepo        repository.OrderRepository
+	returnWindowDays int
+}
+
+func NewReturnService(returnRepo repository.ReturnRepository, orderRepo repository.OrderRepository, returnWindowDays int) *ReturnService {
+	return &ReturnService{
+		returnRepo:       returnRepo,
+		orderRepo:        orderRepo,
+		returnWindowDays: returnWindowDays,
+	}
+}
+
+// RequestReturn opens a return for items of one of the logged-in user's delivered orders
+func (s *ReturnService) RequestReturn(
+	ctx context.Context,
+	orderId uuid.UUID,
+	reason string,
+	items []model.ReturnItemParams,
+) (model.ReturnRequestDetail, error) {
+	// validate reason and items
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > 255 {
+		return model.ReturnRequestDetail{}, ErrInvalidReturnReason
+	}
+	if len(items) == 0 {
+		return model.ReturnRequestDetail{}, ErrInvalidReturnItems
+	}
+	seen := make(map[uuid.UUID]bool, len(items))
+	for _, item := range items {
+		if item.Quantity < 1 || seen[item.OrderItemID] {
+			return model.ReturnRequestDetail{}, ErrInvalidReturnItems
+		}
+		seen[item.OrderItemID] = true
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	// create return request
+	returnRequest, err := s.returnRepo.CreateReturnRequest(ctx, model.CreateReturnRequestParams{
+		OrderID:        orderId,
+		UserID:         userId,
+		Reason:         reason,
+		Items:          items,
+		DeliveredAfter: time.Now().AddDate(0, 0, -s.returnWindowDays),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ReturnRequestDetail{}, ErrOrderNotFound
+	}
+	return returnRequest, err
+}
+
+// GetOrderReturns gets the return requests of one of the logged-in user's orders
+func (s *ReturnService) GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]model.ReturnRequestDetail, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	// make sure the order is theirs
+	if _, err := s.orderRepo.GetUserOrder(ctx, orderId, userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return s.returnRepo.GetOrderReturnRequests(ctx, orderId)
+}
+
+// GetReturnRequests gets a page of return requests in the given status
+func (s *ReturnService) GetReturnRequests(ctx context.Context, status string, pageSize int32, page int32) (model.PaginationResult, error) {
+	if status == "" {
+		status = "requested"
+	}
+	if !returnStatuses[status] {
+		return model.PaginationResult{}, ErrUnknownReturnStatus
+	}
+
+	// get return request count
+	totalCount, err := s.returnRepo.GetReturnRequestCountByStatus(ctx, status)
+	if err != nil {
+		return model.PaginationResult{}, err
+	}
+
+	// get return requests
+	paginatedRequests, err := utils.Paginate(ctx, totalCount, page, pageSize, func(offset int32, limit int32) (interface{}, error) {
+		return s.returnRepo.GetReturnRequestsByStatus(ctx, status, offset, limit)
+	})
+	if err != nil {
+		return model.PaginationResult{}, err
+	}
+
+	// return return requests
+	return *paginatedRequests, nil
+}
+
+// GetReturnRequest gets a return request with its items and refund
+func (s *ReturnService) GetReturnRequest(ctx context.Context, returnId uuid.UUID) (model.ReturnRequestDetail, error) {
+	returnRequest, err := s.returnRepo.GetReturnRequest(ctx, returnId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ReturnRequestDetail{}, ErrReturnNotFound
+	}
+	return returnRequest, err
+}
+
+// ApproveReturn accepts a requested return so the customer can send the goods back
+func (s *ReturnService) ApproveReturn(ctx context.Context, returnId uuid.UUID, note string) (model.ReturnRequest, error) {
+	return s.reviewReturn(ctx, returnId, "approved", note)
+}
+
+// RejectReturn turns down a requested return
+func (s *ReturnService) RejectReturn(ctx context.Context, returnId uuid.UUID, note string) (model.ReturnRequest, error) {
+	return s.reviewReturn(ctx, returnId, "rejected", note)
+}
+
+// ReceiveReturn records that the goods of an approved return arrived, restocking them and creating the refund
+func (s *ReturnService) ReceiveReturn(ctx context.Context, returnId uuid.UUID, note string) (model.ReturnRequestDetail, error) {
+	note = strings.TrimSpace(note)
+	if len(note) > 255 {
+		return model.ReturnRequestDetail{}, ErrReasonTooLong
+	}
+
+	returnRequest, err := s.returnRepo.ReceiveReturnRequest(ctx, returnId, note)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ReturnRequestDetail{}, ErrReturnNotFound
+	}
+	return returnRequest, err
+}
+
+func (s *ReturnService) reviewReturn(ctx context.Context, returnId uuid.UUID, status string, note string) (model.ReturnRequest, error) {
+	note = strings.TrimSpace(note)
+	if len(note) > 255 {
+		return model.ReturnRequest{}, ErrReasonTooLong
+	}
+
+	returnRequest, err := s.returnRepo.ReviewReturnRequest(ctx, returnId, status, note)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ReturnRequest{}, ErrReturnNotFound
+	}
+	return returnRequest, err
+}
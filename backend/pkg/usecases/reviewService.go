@@ -0,0 +1,281 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Review sort orders
+const (
+	ReviewSortNewest  = "newest"
+	ReviewSortHelpful = "helpful"
+	ReviewSortHighest = "highest"
+	ReviewSortLowest  = "lowest"
+)
+
+const (
+	maxReviewCommentLength = 1000
+	maxReportReasonLength  = 255
+)
+
+var (
+	ErrInvalidRating       = errors.New("rating must be between 1 and 5")
+	ErrCommentTooLong      = errors.New("comment must be at most 1000 characters")
+	ErrInvalidReviewSort   = errors.New("sort must be newest, helpful, highest or lowest")
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrInvalidReviewStatus = errors.New("status must be pending, approved or rejected")
+	ErrInvalidModeration   = errors.New("reviews can only be approved or rejected")
+	ErrReportReasonTooLong = errors.New("reason must be at most 255 characters")
+	ErrVoteNotFound        = errors.New("you have not voted on this review")
+)
+
+type ReviewService struct {
+	reviewRepo      repository.ReviewRepository
+	bannedWords     []string
+	reportThreshold int64
+}
+
+// NewReviewService creates a review service that flags reviews using any of the banned words or
+// phrases for moderators' attention, matching regardless of case and punctuation, and takes
+// published reviews down for moderation once they have reportThreshold reports.
+func NewReviewService(reviewRepo repository.ReviewRepository, bannedWords []string, reportThreshold int) *ReviewService {
+	normalized := make([]string, 0, len(bannedWords))
+	for _, word := range bannedWords {
+		if word = normalizeWords(word); word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+
+	if reportThreshold < 1 {
+		reportThreshold = 1
+	}
+
+	return &ReviewService{
+		reviewRepo:      reviewRepo,
+		bannedWords:     normalized,
+		reportThreshold: int64(reportThreshold),
+	}
+}
+
+// CreateReview adds the logged-in user's review of a product. It is not shown until a moderator
+// approves it.
+func (s *ReviewService) CreateReview(ctx context.Context, params model.SaveReviewParams) (model.ProductReview, error) {
+	// validate rating and comment
+	params, err := validateReview(params)
+	if err != nil {
+		return model.ProductReview{}, err
+	}
+	params.FlaggedWords = s.findBannedWords(params.Comment)
+
+	// get user id from context
+	params.UserID = ctx.Value("userId").(uuid.UUID)
+
+	review, err := s.reviewRepo.CreateReview(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ProductReview{}, ErrProductNotFound
+	}
+	return review, err
+}
+
+// UpdateReview changes the rating and comment of one of the logged-in user's reviews. The review
+// is hidden again until a moderator approves the change.
+func (s *ReviewService) UpdateReview(ctx context.Context, reviewId uuid.UUID, params model.SaveReviewParams) (model.ProductReview, error) {
+	// validate rating and comment
+	params, err := validateReview(params)
+	if err != nil {
+		return model.ProductReview{}, err
+	}
+	params.FlaggedWords = s.findBannedWords(params.Comment)
+
+	// get user id from context
+	params.UserID = ctx.Value("userId").(uuid.UUID)
+
+	review, err := s.reviewRepo.UpdateReview(ctx, reviewId, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ProductReview{}, ErrReviewNotFound
+	}
+	return review, err
+}
+
+// DeleteReview deletes one of the logged-in user's reviews
+func (s *ReviewService) DeleteReview(ctx context.Context, reviewId uuid.UUID) error {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	err := s.reviewRepo.DeleteReview(ctx, userId, reviewId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrReviewNotFound
+	}
+	return err
+}
+
+// GetProductReviews gets a product's published reviews, newest first unless sorted by helpfulness
+// or by highest or lowest rating
+func (s *ReviewService) GetProductReviews(ctx context.Context, productId uuid.UUID, sort string, pageSize int32, page int32) (model.PaginationResult, error) {
+	// validate sort
+	if sort == "" {
+		sort = ReviewSortNewest
+	}
+	if sort != ReviewSortNewest && sort != ReviewSortHelpful && sort != ReviewSortHighest && sort != ReviewSortLowest {
+		return model.PaginationResult{}, ErrInvalidReviewSort
+	}
+
+	// get review count
+	totalCount, err := s.reviewRepo.GetProductReviewCount(ctx, productId)
+	if err != nil {
+		return model.PaginationResult{}, err
+	}
+
+	// get reviews
+	paginatedReviews, err := utils.Paginate(ctx, totalCount, page, pageSize, func(offset int32, limit int32) (interface{}, error) {
+		return s.reviewRepo.GetProductReviews(ctx, productId, sort, offset, limit)
+	})
+	if err != nil {
+		return model.PaginationResult{}, err
+	}
+
+	// return reviews
+	return *paginatedReviews, nil
+}
+
+// GetModerationQueue gets the reviews with a status, pending by default, with those using banned
+// words or reported since they were last moderated first and then the oldest
+func (s *ReviewService) GetModerationQueue(ctx context.Context, status string, flaggedOnly bool, pageSize int32, page int32) (model.PaginationResult, error) {
+	// validate status
+	if status == "" {
+		status = model.ReviewStatusPending
+	}
+	if status != model.ReviewStatusPending && status != model.ReviewStatusApproved && status != model.ReviewStatusRejected {
+		return model.PaginationResult{}, ErrInvalidReviewStatus
+	}
+
+	// get review count
+	totalCount, err := s.reviewRepo.GetModerationReviewCount(ctx, status, flaggedOnly)
+	if err != nil {
+		return model.PaginationResult{}, err
+	}
+
+	// get reviews
+	paginatedReviews, err := utils.Paginate(ctx, totalCount, page, pageSize, func(offset int32, limit int32) (interface{}, error) {
+		return s.reviewRepo.GetModerationReviews(ctx, status, flaggedOnly, offset, limit)
+	})
+	if err != nil {
+		return model.PaginationResult{}, err
+	}
+
+	// return reviews
+	return *paginatedReviews, nil
+}
+
+// ModerateReview approves or rejects a review on behalf of the logged-in admin
+func (s *ReviewService) ModerateReview(ctx context.Context, reviewId uuid.UUID, status string) (model.ProductReview, error) {
+	if status != model.ReviewStatusApproved && status != model.ReviewStatusRejected {
+		return model.ProductReview{}, ErrInvalidModeration
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	review, err := s.reviewRepo.ModerateReview(ctx, model.ModerateReviewParams{
+		ReviewID:    reviewId,
+		Status:      status,
+		ModeratedBy: userId,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ProductReview{}, ErrReviewNotFound
+	}
+	return review, err
+}
+
+// VoteReview records whether the logged-in user found a review helpful, replacing any earlier vote
+func (s *ReviewService) VoteReview(ctx context.Context, reviewId uuid.UUID, helpful bool) error {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	err := s.reviewRepo.VoteReview(ctx, model.ReviewVoteParams{
+		ReviewID: reviewId,
+		UserID:   userId,
+		Helpful:  helpful,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrReviewNotFound
+	}
+	return err
+}
+
+// DeleteReviewVote withdraws the logged-in user's vote on a review
+func (s *ReviewService) DeleteReviewVote(ctx context.Context, reviewId uuid.UUID) error {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	err := s.reviewRepo.DeleteReviewVote(ctx, userId, reviewId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrVoteNotFound
+	}
+	return err
+}
+
+// ReportReview reports a review as abusive on behalf of the logged-in user. Enough reports send the
+// review back to the moderation queue.
+func (s *ReviewService) ReportReview(ctx context.Context, reviewId uuid.UUID, reason string) error {
+	// validate reason
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > maxReportReasonLength {
+		return ErrReportReasonTooLong
+	}
+
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	err := s.reviewRepo.ReportReview(ctx, model.ReportReviewParams{
+		ReviewID: reviewId,
+		UserID:   userId,
+		Reason:   reason,
+	}, s.reportThreshold)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrReviewNotFound
+	}
+	return err
+}
+
+// findBannedWords lists the banned words and phrases a comment uses
+func (s *ReviewService) findBannedWords(comment string) []string {
+	text := " " + normalizeWords(comment) + " "
+
+	var found []string
+	for _, word := range s.bannedWords {
+		if strings.Contains(text, " "+word+" ") {
+			found = append(found, word)
+		}
+	}
+	return found
+}
+
+// normalizeWords lower-cases text and keeps only its words, separated by single spaces
+func normalizeWords(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(words, " ")
+}
+
+func validateReview(params model.SaveReviewParams) (model.SaveReviewParams, error) {
+	if params.Rating < 1 || params.Rating > 5 {
+		return params, ErrInvalidRating
+	}
+
+	params.Comment = strings.TrimSpace(params.Comment)
+	if utf8.RuneCountInString(params.Comment) > maxReviewCommentLength {
+		return params, ErrCommentTooLong
+	}
+	return params, nil
+}
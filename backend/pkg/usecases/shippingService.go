@@ -0,0 +1,276 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/postal"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidShippingZone      = errors.New("zone name must be between 1 and 100 characters, country is required and the postal code prefix must be at most 20 characters")
+	ErrShippingZoneExists       = errors.New("a shipping zone already exists for this country and postal code prefix")
+	ErrShippingZoneNotFound     = errors.New("shipping zone not found")
+	ErrInvalidShippingRate      = errors.New("rate name must be between 1 and 100 characters and estimated days must be greater than zero")
+	ErrInvalidShippingMethod    = errors.New("shipping method must be lowercase letters, digits and underscores, at most 50 characters")
+	ErrInvalidWeightBand        = errors.New("weights must not be negative and the maximum weight must be above the minimum weight")
+	ErrShippingRateNotFound     = errors.New("shipping rate not found")
+	ErrInvalidProductShipping   = errors.New("weight must not be negative and dimensions must be greater than zero")
+	ErrInvalidShippingPrice     = errors.New("price and free shipping threshold must be amounts of zero or more in " + money.DefaultCurrency + " with at most two decimal places")
+	ErrShippingMethodNotOffered = errors.New("shipping method is not available for this cart")
+	ErrNoShippingToDestination  = errors.New("the cart cannot be shipped to this address")
+	ErrProductNotFound          = errors.New("product not found")
+)
+
+var shippingMethodPattern = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+// volumetricDivisor turns a parcel's volume in cubic centimetres into the weight in grams it is
+// charged at, the usual 5000 cm³ per kilogram
+const volumetricDivisor = 5
+
+type ShippingService struct {
+	shippingRepo repository.ShippingRepository
+}
+
+func NewShippingService(shippingRepo repository.ShippingRepository) *ShippingService {
+	return &ShippingService{
+		shippingRepo: shippingRepo,
+	}
+}
+
+// QuoteShipping lists the ways the items can be shipped to the given shipping address, or the
+// user's default one if none is given, as QuoteShippingTo does. A user without a shipping
+// address gets no options.
+func (s *ShippingService) QuoteShipping(ctx context.Context, userId uuid.UUID, addressId uuid.NullUUID, items []model.CartItemDetail, subtotal money.Money) (model.ShippingQuote, error) {
+	// find where the user ships to
+	destination, err := s.shippingRepo.GetUserShippingDestination(ctx, userId, addressId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ShippingQuote{
+			WeightGrams: billableWeight(items),
+			Subtotal:    subtotal,
+			Options:     []model.ShippingOption{},
+		}, nil
+	}
+	if err != nil {
+		return model.ShippingQuote{}, err
+	}
+
+	return s.QuoteShippingTo(ctx, destination, items, subtotal)
+}
+
+// QuoteShippingTo lists the ways the items can be shipped to a destination, cheapest first. The
+// cheapest option is free once the subtotal reaches the zone's free shipping threshold. A
+// destination in no zone gets no options.
+func (s *ShippingService) QuoteShippingTo(ctx context.Context, destination model.ShippingDestination, items []model.CartItemDetail, subtotal money.Money) (model.ShippingQuote, error) {
+	quote := model.ShippingQuote{
+		WeightGrams: billableWeight(items),
+		Subtotal:    subtotal,
+		Options:     []model.ShippingOption{},
+	}
+	destination.PostalCode = normalizePostalCode(destination.PostalCode)
+	quote.Destination = &destination
+
+	// find the zone the destination is in
+	zone, err := s.shippingRepo.MatchShippingZone(ctx, destination)
+	if errors.Is(err, sql.ErrNoRows) {
+		return quote, nil
+	}
+	if err != nil {
+		return model.ShippingQuote{}, err
+	}
+	quote.Zone = &zone
+	quote.FreeShippingThreshold = zone.FreeShippingThreshold
+
+	// price each method at the weight band the items fall in
+	rates, err := s.shippingRepo.GetShippingRatesForWeight(ctx, zone.ID, quote.WeightGrams)
+	if err != nil {
+		return model.ShippingQuote{}, err
+	}
+	for _, rate := range rates {
+		quote.Options = append(quote.Options, model.ShippingOption{
+			Method:        rate.Method,
+			Name:          rate.Name,
+			Price:         rate.Price,
+			Cost:          rate.Price,
+			EstimatedDays: rate.EstimatedDays,
+		})
+	}
+	sort.SliceStable(quote.Options, func(i, j int) bool {
+		return quote.Options[i].Price.Cmp(quote.Options[j].Price) < 0
+	})
+
+	// waive the cheapest option over the threshold
+	if zone.FreeShippingThreshold.Valid {
+		threshold, err := money.FromDecimal(zone.FreeShippingThreshold.String, money.DefaultCurrency)
+		if err != nil {
+			return model.ShippingQuote{}, err
+		}
+		if subtotal.Cmp(threshold) >= 0 {
+			return s.WaiveCheapestOption(quote), nil
+		}
+	}
+
+	return quote, nil
+}
+
+// WaiveCheapestOption makes the cheapest option of a quote free
+func (s *ShippingService) WaiveCheapestOption(quote model.ShippingQuote) model.ShippingQuote {
+	if len(quote.Options) == 0 {
+		return quote
+	}
+
+	options := make([]model.ShippingOption, len(quote.Options))
+	copy(options, quote.Options)
+	options[0].Cost = money.Zero(options[0].Cost.Currency())
+	options[0].FreeShipping = true
+	quote.Options = options
+	return quote
+}
+
+// ChooseShippingOption picks the option for a method from a quote, or the cheapest option if no
+// method is given. The returned option is nil if the quote has no options and no method was given.
+func (s *ShippingService) ChooseShippingOption(quote model.ShippingQuote, method string) (*model.ShippingOption, error) {
+	if method == "" {
+		if len(quote.Options) == 0 {
+			return nil, nil
+		}
+		return &quote.Options[0], nil
+	}
+
+	for i := range quote.Options {
+		if quote.Options[i].Method == method {
+			return &quote.Options[i], nil
+		}
+	}
+	return nil, ErrShippingMethodNotOffered
+}
+
+// HasShippingZones reports whether shipping is set up at all. Until a zone is set up, orders are
+// placed without shipping.
+func (s *ShippingService) HasShippingZones(ctx context.Context) (bool, error) {
+	return s.shippingRepo.HasShippingZones(ctx)
+}
+
+// GetShippingZones gets every shipping zone with its rates
+func (s *ShippingService) GetShippingZones(ctx context.Context) ([]model.ShippingZoneDetail, error) {
+	return s.shippingRepo.GetShippingZones(ctx)
+}
+
+// CreateShippingZone creates a zone for a country, or the part of it whose postal codes start
+// with a prefix
+func (s *ShippingService) CreateShippingZone(ctx context.Context, params model.CreateShippingZoneParams) (model.ShippingZone, error) {
+	// validate name, country, prefix and threshold
+	params.Name = strings.TrimSpace(params.Name)
+	params.Country = postal.NormalizeCountry(params.Country)
+	params.PostalCodePrefix = normalizePostalCode(params.PostalCodePrefix)
+	if params.Name == "" || len(params.Name) > 100 || params.Country == "" || len(params.Country) > 100 ||
+		len(params.PostalCodePrefix) > 20 {
+		return model.ShippingZone{}, ErrInvalidShippingZone
+	}
+	if params.FreeShippingThreshold.Valid {
+		if _, err := money.Parse(params.FreeShippingThreshold.String, money.DefaultCurrency); err != nil {
+			return model.ShippingZone{}, ErrInvalidShippingPrice
+		}
+	}
+
+	zone, err := s.shippingRepo.CreateShippingZone(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ShippingZone{}, ErrShippingZoneExists
+	}
+	return zone, err
+}
+
+// DeleteShippingZone deletes a shipping zone along with its rates
+func (s *ShippingService) DeleteShippingZone(ctx context.Context, zoneId uuid.UUID) error {
+	err := s.shippingRepo.DeleteShippingZone(ctx, zoneId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrShippingZoneNotFound
+	}
+	return err
+}
+
+// CreateShippingRate adds the price of a method for a weight band to a zone
+func (s *ShippingService) CreateShippingRate(ctx context.Context, params model.CreateShippingRateParams) (model.ShippingRate, error) {
+	// validate method, name, weight band, price and delivery estimate
+	if !shippingMethodPattern.MatchString(params.Method) {
+		return model.ShippingRate{}, ErrInvalidShippingMethod
+	}
+	params.Name = strings.TrimSpace(params.Name)
+	if params.Name == "" || len(params.Name) > 100 ||
+		(params.EstimatedDays.Valid && params.EstimatedDays.Int32 < 1) {
+		return model.ShippingRate{}, ErrInvalidShippingRate
+	}
+	if params.MinWeightGrams < 0 ||
+		(params.MaxWeightGrams.Valid && params.MaxWeightGrams.Int32 <= params.MinWeightGrams) {
+		return model.ShippingRate{}, ErrInvalidWeightBand
+	}
+	if params.Price.Sign() < 0 || params.Price.Currency() != money.DefaultCurrency {
+		return model.ShippingRate{}, ErrInvalidShippingPrice
+	}
+
+	rate, err := s.shippingRepo.CreateShippingRate(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ShippingRate{}, ErrShippingZoneNotFound
+	}
+	return rate, err
+}
+
+// DeleteShippingRate deletes a shipping rate
+func (s *ShippingService) DeleteShippingRate(ctx context.Context, rateId uuid.UUID) error {
+	err := s.shippingRepo.DeleteShippingRate(ctx, rateId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrShippingRateNotFound
+	}
+	return err
+}
+
+// UpdateProductShipping sets the weight and dimensions a product ships at
+func (s *ShippingService) UpdateProductShipping(ctx context.Context, productId uuid.UUID, params model.UpdateProductShippingParams) error {
+	if params.WeightGrams < 0 ||
+		(params.LengthCm.Valid && params.LengthCm.Int32 < 1) ||
+		(params.WidthCm.Valid && params.WidthCm.Int32 < 1) ||
+		(params.HeightCm.Valid && params.HeightCm.Int32 < 1) {
+		return ErrInvalidProductShipping
+	}
+
+	err := s.shippingRepo.UpdateProductShipping(ctx, productId, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrProductNotFound
+	}
+	return err
+}
+
+// billableWeight adds up the weight the items are charged at. Each unit counts at its actual
+// weight or, when it has all three dimensions, its volumetric weight if that is more.
+func billableWeight(items []model.CartItemDetail) int32 {
+	var total int64
+	for _, item := range items {
+		weight := int64(item.WeightGrams)
+		if item.LengthCm.Valid && item.WidthCm.Valid && item.HeightCm.Valid {
+			volume := int64(item.LengthCm.Int32) * int64(item.WidthCm.Int32) * int64(item.HeightCm.Int32)
+			if volumetric := volume / volumetricDivisor; volumetric > weight {
+				weight = volumetric
+			}
+		}
+		total += weight * int64(item.Quantity)
+	}
+
+	if total > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(total)
+}
+
+// normalizePostalCode drops spaces and upper-cases a postal code so that prefixes compare cleanly
+func normalizePostalCode(postalCode string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(postalCode), " ", ""))
+}
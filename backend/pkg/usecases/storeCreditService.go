@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/money"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidStoreCreditAmount = errors.New("store credit adjustment must be a non-zero amount with at most two decimal places")
+
+type StoreCreditService struct {
+	storeCreditRepo repository.StoreCreditRepository
+}
+
+func NewStoreCreditService(storeCreditRepo repository.StoreCreditRepository) *StoreCreditService {
+	return &StoreCreditService{
+		storeCreditRepo: storeCreditRepo,
+	}
+}
+
+// GetMyStoreCredit gets the logged-in user's store credit balance and ledger
+func (s *StoreCreditService) GetMyStoreCredit(ctx context.Context) (model.StoreCredit, error) {
+	// get user id from context
+	userId := ctx.Value("userId").(uuid.UUID)
+
+	return s.storeCreditRepo.GetStoreCredit(ctx, userId)
+}
+
+// GetStoreCredit gets a user's store credit balance and ledger
+func (s *StoreCreditService) GetStoreCredit(ctx context.Context, userId uuid.UUID) (model.StoreCredit, error) {
+	storeCredit, err := s.storeCreditRepo.GetStoreCredit(ctx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.StoreCredit{}, ErrUserNotFound
+	}
+	return storeCredit, err
+}
+
+// AdjustStoreCredit adds to a user's store credit, or takes from it when the amount is negative,
+// on behalf of the logged-in user
+func (s *StoreCreditService) AdjustStoreCredit(ctx context.Context, userId uuid.UUID, amount string, note string) (model.StoreCreditTransaction, error) {
+	// validate amount and note
+	amount = strings.TrimSpace(amount)
+	negative := strings.HasPrefix(amount, "-")
+	value, err := money.Parse(strings.TrimPrefix(amount, "-"), money.DefaultCurrency)
+	if err != nil || value.IsZero() {
+		return model.StoreCreditTransaction{}, ErrInvalidStoreCreditAmount
+	}
+	if negative {
+		value = money.Zero(money.DefaultCurrency).Sub(value)
+	}
+	note = strings.TrimSpace(note)
+	if len(note) > 255 {
+		return model.StoreCreditTransaction{}, ErrReasonTooLong
+	}
+
+	// get admin id from context
+	adminId := ctx.Value("userId").(uuid.UUID)
+
+	transaction, err := s.storeCreditRepo.AdjustStoreCredit(ctx, model.AdjustStoreCreditParams{
+		UserID:    userId,
+		Amount:    value,
+		Note:      note,
+		CreatedBy: adminId,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.StoreCreditTransaction{}, ErrUserNotFound
+	}
+	return transaction, err
+}
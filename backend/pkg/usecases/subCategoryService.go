@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/pricing"
 	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/tax"
 	"github.com/geraldbahati/ecommerce/pkg/utils"
 	"github.com/google/uuid"
 	"log"
+	"time"
 )
 
 type SubCategoryService struct {
@@ -28,7 +31,16 @@ func (s *SubCategoryService) CreateSubCategory(
 	description string,
 	imageUrl string,
 	seoKeywords string,
+	taxClass string,
 ) (model.SubCategory, error) {
+	// validate tax class
+	if taxClass == "" {
+		taxClass = tax.DefaultTaxClass
+	}
+	if !taxClassPattern.MatchString(taxClass) {
+		return model.SubCategory{}, ErrInvalidTaxClass
+	}
+
 	// create sub category model
 	descriptionValue := sql.NullString{}
 	if description != "" {
@@ -55,6 +67,7 @@ func (s *SubCategoryService) CreateSubCategory(
 		Description: descriptionValue,
 		ImageUrl:    imageUrlValue,
 		SeoKeywords: seoKeywordsValue,
+		TaxClass:    taxClass,
 	}
 
 	// log the sub category creation
@@ -93,7 +106,13 @@ func (s *SubCategoryService) GetProductsBySubCategory(ctx context.Context, subCa
 		page,
 		pageSize,
 		func(offset, limit int32) (interface{}, error) {
-			return s.subCategoryRepo.GetProductBySubCategory(ctx, subCategoryIdUUIDValue, offset, limit)
+			products, err := s.subCategoryRepo.GetProductBySubCategory(ctx, subCategoryIdUUIDValue, offset, limit)
+			if err != nil {
+				return nil, err
+			}
+			pricing.ApplyToProducts(products, time.Now())
+			pricing.ConvertProducts(products, displayCurrency(ctx))
+			return products, nil
 		},
 	)
 	if err != nil {
@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/geraldbahati/ecommerce/pkg/model"
+	"github.com/geraldbahati/ecommerce/pkg/postal"
+	"github.com/geraldbahati/ecommerce/pkg/repository"
+	"github.com/geraldbahati/ecommerce/pkg/tax"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidTaxRate      = errors.New("rate must be a decimal from 0 up to 1 with at most four decimal places")
+	ErrInvalidTaxClass     = errors.New("tax class must be lowercase letters, digits and underscores, at most 50 characters")
+	ErrInvalidTaxLocation  = errors.New("country is required and country and region must be at most 100 characters")
+	ErrInvalidTaxName      = errors.New("tax name must be between 1 and 50 characters")
+	ErrTaxRateNotFound     = errors.New("tax rate not found")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrSubCategoryNotFound = errors.New("sub category not found")
+)
+
+var (
+	taxRatePattern  = regexp.MustCompile(`^0(\.\d{1,4})?$`)
+	taxClassPattern = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+)
+
+type TaxService struct {
+	taxRepo    repository.TaxRepository
+	calculator tax.TaxCalculator
+}
+
+func NewTaxService(taxRepo repository.TaxRepository, calculator tax.TaxCalculator) *TaxService {
+	return &TaxService{
+		taxRepo:    taxRepo,
+		calculator: calculator,
+	}
+}
+
+// QuoteTax works out the tax on the given lines for a user, at the rates for where they ship to:
+// the given shipping address, or their default one if none is given
+func (s *TaxService) QuoteTax(ctx context.Context, userId uuid.UUID, addressId uuid.NullUUID, lines []model.TaxLine) (model.TaxBreakdown, error) {
+	profile, err := s.taxRepo.GetUserTaxProfile(ctx, userId, addressId)
+	if err != nil {
+		return model.TaxBreakdown{}, err
+	}
+
+	return s.calculator.Calculate(ctx, tax.Request{
+		Location: profile.Location,
+		Exempt:   profile.Exempt,
+		Lines:    lines,
+	})
+}
+
+// QuoteTaxAt works out the tax on the given lines for a user shipping to the given location,
+// such as an address given at checkout rather than picked from their address book
+func (s *TaxService) QuoteTaxAt(ctx context.Context, userId uuid.UUID, location model.TaxLocation, lines []model.TaxLine) (model.TaxBreakdown, error) {
+	profile, err := s.taxRepo.GetUserTaxProfile(ctx, userId, uuid.NullUUID{})
+	if err != nil {
+		return model.TaxBreakdown{}, err
+	}
+
+	return s.calculator.Calculate(ctx, tax.Request{
+		Location: &location,
+		Exempt:   profile.Exempt,
+		Lines:    lines,
+	})
+}
+
+// GetTaxRates gets every tax rate
+func (s *TaxService) GetTaxRates(ctx context.Context) ([]model.TaxRate, error) {
+	return s.taxRepo.GetTaxRates(ctx)
+}
+
+// SaveTaxRate sets the rate for a country, or a region of it, and tax class
+func (s *TaxService) SaveTaxRate(ctx context.Context, params model.SaveTaxRateParams) (model.TaxRate, error) {
+	// known countries and regions are kept by code, as addresses are
+	params.Region = postal.NormalizeState(params.Country, params.Region)
+	params.Country = postal.NormalizeCountry(params.Country)
+
+	// validate location, class, name and rate
+	if params.Country == "" || len(params.Country) > 100 || len(params.Region) > 100 {
+		return model.TaxRate{}, ErrInvalidTaxLocation
+	}
+	if params.TaxClass == "" {
+		params.TaxClass = tax.DefaultTaxClass
+	}
+	if !taxClassPattern.MatchString(params.TaxClass) {
+		return model.TaxRate{}, ErrInvalidTaxClass
+	}
+	params.Name = strings.TrimSpace(params.Name)
+	if params.Name == "" || len(params.Name) > 50 {
+		return model.TaxRate{}, ErrInvalidTaxName
+	}
+	if !taxRatePattern.MatchString(params.Rate) {
+		return model.TaxRate{}, ErrInvalidTaxRate
+	}
+
+	return s.taxRepo.SaveTaxRate(ctx, params)
+}
+
+// DeleteTaxRate deletes a tax rate
+func (s *TaxService) DeleteTaxRate(ctx context.Context, taxRateId uuid.UUID) error {
+	err := s.taxRepo.DeleteTaxRate(ctx, taxRateId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTaxRateNotFound
+	}
+	return err
+}
+
+// SetUserTaxExempt flags or unflags a customer as exempt from tax
+func (s *TaxService) SetUserTaxExempt(ctx context.Context, userId uuid.UUID, exempt bool) error {
+	err := s.taxRepo.SetUserTaxExempt(ctx, userId, exempt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+// SetSubCategoryTaxClass sets the tax class of the products in a sub category
+func (s *TaxService) SetSubCategoryTaxClass(ctx context.Context, subCategoryId uuid.UUID, taxClass string) error {
+	if !taxClassPattern.MatchString(taxClass) {
+		return ErrInvalidTaxClass
+	}
+
+	err := s.taxRepo.SetSubCategoryTaxClass(ctx, subCategoryId, taxClass)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSubCategoryNotFound
+	}
+	return err
+}
+
+// taxLinesFromCart lists the cart's items as lines to be taxed
+func taxLinesFromCart(items []model.CartItemDetail) []model.TaxLine {
+	lines := make([]model.TaxLine, len(items))
+	for i, item := range items {
+		lines[i] = model.TaxLine{
+			ProductID: item.ProductID,
+			TaxClass:  item.TaxClass,
+			UnitPrice: item.UnitPrice,
+			Quantity:  item.Quantity,
+		}
+	}
+	return lines
+}
@@ -8,3 +8,7 @@ import (
 func SetUserIdInContext(ctx context.Context, userId uuid.UUID) context.Context {
 	return context.WithValue(ctx, "userId", userId)
 }
+
+func SetUserRoleInContext(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, "userRole", role)
+}
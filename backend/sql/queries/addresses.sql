@@ -0,0 +1,120 @@
+-- name: LockAddressBook :one
+SELECT id FROM users
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CreateShippingAddress :one
+INSERT INTO shipping_addresses (id, user_id, street, city, state, country, postal_code, is_default, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+RETURNING *;
+
+-- name: UpdateShippingAddress :one
+UPDATE shipping_addresses
+SET street = $3,
+    city = $4,
+    state = $5,
+    country = $6,
+    postal_code = $7,
+    last_updated = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: ClearDefaultShippingAddress :exec
+UPDATE shipping_addresses
+SET is_default = FALSE,
+    last_updated = NOW()
+WHERE user_id = $1 AND is_default;
+
+-- name: SetDefaultShippingAddress :one
+UPDATE shipping_addresses
+SET is_default = TRUE,
+    last_updated = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: PromoteOldestShippingAddress :exec
+UPDATE shipping_addresses
+SET is_default = TRUE,
+    last_updated = NOW()
+WHERE id = (
+    SELECT id FROM shipping_addresses
+    WHERE user_id = $1
+    ORDER BY created_at, id
+    LIMIT 1
+);
+
+-- name: DeleteShippingAddress :one
+DELETE FROM shipping_addresses
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: GetShippingAddress :one
+SELECT * FROM shipping_addresses
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetDefaultShippingAddress :one
+SELECT * FROM shipping_addresses
+WHERE user_id = $1 AND is_default;
+
+-- name: ListShippingAddresses :many
+SELECT * FROM shipping_addresses
+WHERE user_id = $1
+ORDER BY is_default DESC, created_at, id;
+
+-- name: CreateBillingAddress :one
+INSERT INTO billing_addresses (id, user_id, street, city, state, country, postal_code, is_default, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+RETURNING *;
+
+-- name: UpdateBillingAddress :one
+UPDATE billing_addresses
+SET street = $3,
+    city = $4,
+    state = $5,
+    country = $6,
+    postal_code = $7,
+    last_updated = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: ClearDefaultBillingAddress :exec
+UPDATE billing_addresses
+SET is_default = FALSE,
+    last_updated = NOW()
+WHERE user_id = $1 AND is_default;
+
+-- name: SetDefaultBillingAddress :one
+UPDATE billing_addresses
+SET is_default = TRUE,
+    last_updated = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: PromoteOldestBillingAddress :exec
+UPDATE billing_addresses
+SET is_default = TRUE,
+    last_updated = NOW()
+WHERE id = (
+    SELECT id FROM billing_addresses
+    WHERE user_id = $1
+    ORDER BY created_at, id
+    LIMIT 1
+);
+
+-- name: DeleteBillingAddress :one
+DELETE FROM billing_addresses
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: GetBillingAddress :one
+SELECT * FROM billing_addresses
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetDefaultBillingAddress :one
+SELECT * FROM billing_addresses
+WHERE user_id = $1 AND is_default;
+
+-- name: ListBillingAddresses :many
+SELECT * FROM billing_addresses
+WHERE user_id = $1
+ORDER BY is_default DESC, created_at, id;
\ No newline at end of file
@@ -0,0 +1,53 @@
+-- name: GetOrCreateShoppingCart :one
+INSERT INTO shopping_carts (id, user_id, created_at, last_updated, total_items, total_price)
+VALUES ($1, $2, NOW(), NULL, 0, 0.0)
+ON CONFLICT (user_id) DO UPDATE SET
+    user_id = EXCLUDED.user_id
+RETURNING *;
+
+-- name: GetShoppingCartByUserID :one
+SELECT * FROM shopping_carts
+WHERE user_id = $1;
+
+-- name: GetCartItem :one
+SELECT * FROM cart_items
+WHERE shopping_cart_id = $1 AND product_id = $2;
+
+-- name: UpsertCartItem :one
+INSERT INTO cart_items (id, shopping_cart_id, product_id, quantity, created_at, last_updated)
+VALUES ($1, $2, $3, $4, NOW(), NULL)
+ON CONFLICT (shopping_cart_id, product_id) DO UPDATE SET
+    quantity = EXCLUDED.quantity
+RETURNING *;
+
+-- name: DeleteCartItem :execrows
+DELETE FROM cart_items
+WHERE shopping_cart_id = $1 AND product_id = $2;
+
+-- name: ListCartItems :many
+SELECT ci.id, ci.shopping_cart_id, ci.product_id, ci.quantity, ci.created_at, ci.last_updated,
+       p.name AS product_name, p.image_url, p.price, p.discount_rate, p.stock, p.is_active,
+       COALESCE(sc.tax_class, 'standard')::VARCHAR AS tax_class,
+       p.weight_grams, p.length_cm, p.width_cm, p.height_cm, p.sub_category_id, sc.category_id,
+       p.sale_starts_at, p.sale_ends_at
+FROM cart_items ci
+    INNER JOIN products p ON ci.product_id = p.id
+    LEFT JOIN sub_categories sc ON p.sub_category_id = sc.id
+WHERE ci.shopping_cart_id = $1
+ORDER BY ci.created_at;
+
+-- name: UpdateShoppingCartTotals :one
+UPDATE shopping_carts SET
+    total_items = $2,
+    total_price = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: LockShoppingCart :one
+SELECT * FROM shopping_carts
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ClearCartItems :exec
+DELETE FROM cart_items
+WHERE shopping_cart_id = $1;
\ No newline at end of file
@@ -0,0 +1,37 @@
+-- name: UpsertCurrency :one
+INSERT INTO currencies (code, name, symbol, rounding_increment, rounding_mode, is_active, created_at, last_updated)
+VALUES ($1, $2, $3, $4, $5, $6, NOW(), NULL)
+ON CONFLICT (code) DO UPDATE SET
+    name = EXCLUDED.name,
+    symbol = EXCLUDED.symbol,
+    rounding_increment = EXCLUDED.rounding_increment,
+    rounding_mode = EXCLUDED.rounding_mode,
+    is_active = EXCLUDED.is_active,
+    last_updated = NOW()
+RETURNING *;
+
+-- name: ListCurrencies :many
+SELECT c.code, c.name, c.symbol, c.rounding_increment, c.rounding_mode, c.is_active, c.created_at, c.last_updated,
+       er.rate, er.source, er.updated_at AS rate_updated_at
+FROM currencies c
+    LEFT JOIN exchange_rates er ON er.currency_code = c.code
+WHERE c.is_active OR NOT sqlc.arg(active_only)::BOOLEAN
+ORDER BY c.code;
+
+-- name: GetCurrency :one
+SELECT c.code, c.name, c.symbol, c.rounding_increment, c.rounding_mode, c.is_active, c.created_at, c.last_updated,
+       er.rate, er.source, er.updated_at AS rate_updated_at
+FROM currencies c
+    LEFT JOIN exchange_rates er ON er.currency_code = c.code
+WHERE c.code = $1;
+
+-- name: UpsertExchangeRate :one
+INSERT INTO exchange_rates (currency_code, rate, source, updated_at)
+SELECT c.code, $2, $3, NOW()
+FROM currencies c
+WHERE c.code = $1
+ON CONFLICT (currency_code) DO UPDATE SET
+    rate = EXCLUDED.rate,
+    source = EXCLUDED.source,
+    updated_at = NOW()
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,71 @@
+-- name: CreateGiftCard :one
+INSERT INTO gift_cards (id, code, initial_balance, balance, expires_at, is_active, created_by, created_at, last_updated)
+VALUES ($1, $2, $3, $3, $4, TRUE, $5, NOW(), NULL)
+ON CONFLICT (code) DO NOTHING
+RETURNING *;
+
+-- name: GetGiftCardByID :one
+SELECT * FROM gift_cards
+WHERE id = $1;
+
+-- name: GetGiftCardByCode :one
+SELECT * FROM gift_cards
+WHERE code = $1;
+
+-- name: ListGiftCards :many
+SELECT * FROM gift_cards
+ORDER BY created_at DESC;
+
+-- name: LockGiftCardsByCode :many
+SELECT * FROM gift_cards
+WHERE code = ANY(sqlc.arg(codes)::VARCHAR[])
+ORDER BY code
+FOR UPDATE;
+
+-- name: LockGiftCard :one
+SELECT * FROM gift_cards
+WHERE id = $1
+FOR UPDATE;
+
+-- name: DebitGiftCard :one
+UPDATE gift_cards SET
+    balance = balance - sqlc.arg(amount),
+    last_updated = NOW()
+WHERE id = sqlc.arg(id)
+    AND is_active
+    AND (expires_at IS NULL OR expires_at > NOW())
+    AND balance >= sqlc.arg(amount)
+RETURNING *;
+
+-- name: CreditGiftCard :one
+UPDATE gift_cards SET
+    balance = balance + sqlc.arg(amount),
+    last_updated = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetGiftCardActive :one
+UPDATE gift_cards SET
+    is_active = $2,
+    last_updated = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateGiftCardTransaction :one
+INSERT INTO gift_card_transactions (id, gift_card_id, amount, balance_after, reason, order_id, refund_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+RETURNING *;
+
+-- name: ListGiftCardTransactions :many
+SELECT * FROM gift_card_transactions
+WHERE gift_card_id = $1
+ORDER BY created_at;
+
+-- name: ListOrderGiftCardBalances :many
+SELECT gct.gift_card_id, gc.code, (-SUM(gct.amount))::DECIMAL(10, 2) AS amount
+FROM gift_card_transactions gct
+    INNER JOIN gift_cards gc ON gct.gift_card_id = gc.id
+WHERE gct.order_id = $1 AND gct.reason IN ('redeem', 'restore')
+GROUP BY gct.gift_card_id, gc.code
+HAVING SUM(gct.amount) < 0
+ORDER BY gc.code;
\ No newline at end of file
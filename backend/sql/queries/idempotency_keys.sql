@@ -0,0 +1,28 @@
+-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (user_id, idempotency_key, request_method, request_path, request_hash, response_status, response_body, created_at, completed_at)
+VALUES ($1, $2, $3, $4, $5, NULL, NULL, NOW(), NULL)
+ON CONFLICT (user_id, idempotency_key) DO UPDATE SET
+    request_method = EXCLUDED.request_method,
+    request_path = EXCLUDED.request_path,
+    request_hash = EXCLUDED.request_hash,
+    response_status = NULL,
+    response_body = NULL,
+    created_at = NOW(),
+    completed_at = NULL
+WHERE idempotency_keys.created_at < sqlc.arg(expired_before)::TIMESTAMP
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE user_id = $1 AND idempotency_key = $2;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys SET
+    response_status = $3,
+    response_body = $4,
+    completed_at = NOW()
+WHERE user_id = $1 AND idempotency_key = $2;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE user_id = $1 AND idempotency_key = $2;
\ No newline at end of file
@@ -0,0 +1,98 @@
+-- name: CreateOrder :one
+INSERT INTO orders (id, user_id, order_status, payment_status, payment_method, shipping_address, billing_address, total_price, created_at, last_updated, shipping_method, shipping_cost, currency, exchange_rate, settlement_total)
+VALUES ($1, $2, 'pending', 'pending', $3, $4, $5, 0.0, NOW(), NULL, $6, $7, $8, $9, NULL)
+RETURNING *;
+
+-- name: CreateOrderItem :one
+INSERT INTO order_items (id, order_id, product_id, quantity, unit_price, taxed_price, created_at, last_updated, tax_rate, tax_amount, discount_amount)
+VALUES ($1, $2, $3, $4, $5, $6, NOW(), NULL, $7, $8, $9)
+RETURNING *;
+
+-- name: UpdateOrderTotal :one
+UPDATE orders SET
+    total_price = COALESCE((
+        SELECT SUM(oi.taxed_price)
+        FROM order_items oi
+        WHERE oi.order_id = orders.id
+    ), 0) + shipping_cost
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateOrderSettlementTotal :one
+UPDATE orders SET
+    settlement_total = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateOrderTenders :one
+UPDATE orders SET
+    gift_card_amount = $2,
+    store_credit_amount = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: GetUserOrder :one
+SELECT * FROM orders
+WHERE id = $1 AND user_id = $2;
+
+-- name: LockOrder :one
+SELECT * FROM orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateOrderStatus :one
+UPDATE orders SET
+    order_status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateOrderStatusHistory :one
+INSERT INTO order_status_history (id, order_id, from_status, to_status, actor_id, reason, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+RETURNING *;
+
+-- name: ListOrderStatusHistory :many
+SELECT * FROM order_status_history
+WHERE order_id = $1
+ORDER BY created_at;
+
+-- name: ListUserOrders :many
+SELECT * FROM orders
+WHERE user_id = sqlc.arg(user_id)
+    AND (sqlc.narg(order_status)::VARCHAR IS NULL OR order_status = sqlc.narg(order_status))
+    AND (sqlc.narg(created_from)::TIMESTAMP IS NULL OR created_at >= sqlc.narg(created_from))
+    AND (sqlc.narg(created_to)::TIMESTAMP IS NULL OR created_at < sqlc.narg(created_to))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountUserOrders :one
+SELECT COUNT(*) FROM orders
+WHERE user_id = sqlc.arg(user_id)
+    AND (sqlc.narg(order_status)::VARCHAR IS NULL OR order_status = sqlc.narg(order_status))
+    AND (sqlc.narg(created_from)::TIMESTAMP IS NULL OR created_at >= sqlc.narg(created_from))
+    AND (sqlc.narg(created_to)::TIMESTAMP IS NULL OR created_at < sqlc.narg(created_to));
+
+-- name: ListOrderItemsWithProducts :many
+SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, oi.unit_price, oi.taxed_price, oi.tax_rate, oi.tax_amount, oi.discount_amount, oi.created_at, oi.last_updated,
+       p.name AS product_name, p.image_url
+FROM order_items oi
+    INNER JOIN products p ON oi.product_id = p.id
+WHERE oi.order_id = $1
+ORDER BY oi.created_at;
+
+-- name: UpdateOrderPaymentStatus :one
+UPDATE orders SET
+    payment_status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: RestockOrderItems :exec
+UPDATE products SET
+    stock = products.stock + oi.quantity
+FROM order_items oi
+WHERE oi.order_id = $1 AND products.id = oi.product_id;
+
+-- name: UpdateOrderPaymentStatusFrom :execrows
+UPDATE orders SET
+    payment_status = sqlc.arg(payment_status)
+WHERE id = sqlc.arg(id) AND payment_status = ANY(sqlc.arg(from_statuses)::VARCHAR[]);
\ No newline at end of file
@@ -0,0 +1,67 @@
+-- name: CreatePayment :one
+INSERT INTO payments (id, order_id, provider, payment_method, amount, status, provider_transaction_id, failure_message, created_at, last_updated, currency)
+VALUES ($1, $2, $3, $4, $5, 'pending', NULL, NULL, NOW(), NULL, $6)
+RETURNING *;
+
+-- name: UpdatePayment :one
+UPDATE payments SET
+    status = sqlc.arg(status),
+    provider_transaction_id = COALESCE(sqlc.narg(provider_transaction_id), provider_transaction_id),
+    failure_message = sqlc.narg(failure_message),
+    last_updated = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CountActivePayments :one
+SELECT COUNT(*) FROM payments
+WHERE order_id = $1 AND status IN ('pending', 'authorized');
+
+-- name: ListOrderPayments :many
+SELECT * FROM payments
+WHERE order_id = $1
+ORDER BY created_at;
+
+-- name: GetPaymentByTransaction :one
+SELECT * FROM payments
+WHERE provider = $1 AND provider_transaction_id = $2;
+
+-- name: LockPaymentByTransaction :one
+SELECT * FROM payments
+WHERE provider = $1 AND provider_transaction_id = $2
+FOR UPDATE;
+
+-- name: CreatePaymentEvent :execrows
+INSERT INTO payment_events (id, provider, event_id, event_type, provider_transaction_id, payload, outcome, received_at, processed_at)
+VALUES ($1, $2, $3, $4, $5, $6, NULL, NOW(), NULL)
+ON CONFLICT (provider, event_id) DO NOTHING;
+
+-- name: MarkPaymentEventProcessed :exec
+UPDATE payment_events SET
+    outcome = $3,
+    processed_at = NOW()
+WHERE provider = $1 AND event_id = $2;
+
+-- name: GetCapturedPayment :one
+SELECT * FROM payments
+WHERE order_id = $1 AND status = 'captured'
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: LockPayment :one
+SELECT * FROM payments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListStalePayments :many
+SELECT * FROM payments
+WHERE order_id = sqlc.arg(order_id)
+    AND status IN ('pending', 'authorized')
+    AND created_at < sqlc.arg(started_before)
+ORDER BY created_at;
+
+-- name: ExpirePayment :execrows
+UPDATE payments SET
+    status = $2,
+    failure_message = $3,
+    last_updated = NOW()
+WHERE id = $1 AND status IN ('pending', 'authorized');
\ No newline at end of file
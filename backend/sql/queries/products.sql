@@ -3,8 +3,9 @@ SELECT * FROM products
 WHERE name ILIKE '%' || $1 || '%' OR keywords ILIKE '%' || $1 || '%';
 
 -- name: GetSalesTrends :many
-SELECT DATE_TRUNC('month', created_at) AS month, SUM(price) AS total_sales
+SELECT DATE_TRUNC('month', created_at)::TIMESTAMP AS month, SUM(total_price)::DECIMAL AS total_sales
 FROM orders
+WHERE order_status <> 'cancelled'
 GROUP BY month
 ORDER BY month;
 
@@ -13,6 +14,23 @@ SELECT id FROM products
 WHERE stock > 0
 AND (last_updated > NOW() - INTERVAL '1 DAY');
 
+-- name: LockProductsForCheckout :many
+SELECT id, name, stock, is_active, price, discount_rate, sale_starts_at, sale_ends_at,
+       COALESCE((
+           SELECT sc.tax_class
+           FROM sub_categories sc
+           WHERE sc.id = products.sub_category_id
+       ), 'standard')::VARCHAR AS tax_class
+FROM products
+WHERE id = ANY(sqlc.arg(product_ids)::UUID[])
+ORDER BY id
+FOR UPDATE;
+
+-- name: DecrementProductStock :exec
+UPDATE products SET
+    stock = stock - $2
+WHERE id = $1;
+
 -- Product structure changes
 --CREATE TABLE products (
 -- id UUID PRIMARY KEY,
@@ -48,11 +66,9 @@ UPDATE products SET
     stock = $6,
     sub_category_id = $7,
     brand = $8,
-    rating = $9,
-    review_count = $10,
-    discount_rate = $11,
-    keywords = $12,
-    is_active = $13,
+    discount_rate = $9,
+    keywords = $10,
+    is_active = $11,
     last_updated = NOW()
 WHERE id = $1
 RETURNING *;
@@ -118,7 +134,10 @@ SELECT
     sc.name AS sub_category_name,
     c.id AS category_id,
     c.name AS category_name,
-    tp.sales_volume
+    tp.sales_volume,
+    p.discount_rate,
+    p.sale_starts_at,
+    p.sale_ends_at
 FROM
     TrendingProducts tp
         JOIN products p ON tp.product_id = p.id
@@ -153,11 +172,31 @@ SELECT
     sc.name AS sub_category_name,
     c.id AS category_id,
     c.name AS category_name,
-    tp.sales_volume
+    tp.sales_volume,
+    p.discount_rate,
+    p.sale_starts_at,
+    p.sale_ends_at
 FROM
     TrendingProducts tp
         JOIN products p ON tp.product_id = p.id
         JOIN sub_categories sc ON p.sub_category_id = sc.id
         JOIN categories c ON sc.category_id = c.id
 ORDER BY
-    tp.sales_volume DESC;
\ No newline at end of file
+    tp.sales_volume DESC;
+
+-- name: UpdateProductSale :execrows
+UPDATE products SET
+    discount_rate = $2,
+    sale_starts_at = $3,
+    sale_ends_at = $4,
+    last_updated = NOW()
+WHERE id = $1;
+
+-- name: UpdateProductShipping :execrows
+UPDATE products SET
+    weight_grams = $2,
+    length_cm = $3,
+    width_cm = $4,
+    height_cm = $5,
+    last_updated = NOW()
+WHERE id = $1;
\ No newline at end of file
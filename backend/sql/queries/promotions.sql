@@ -0,0 +1,91 @@
+-- name: CreatePromotion :one
+INSERT INTO promotions (id, code, description, discount_type, discount_value, min_cart_value, starts_at, ends_at, max_uses, max_uses_per_user, times_used, is_active, created_at, last_updated)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 0, TRUE, NOW(), NULL)
+ON CONFLICT (code) DO NOTHING
+RETURNING *;
+
+-- name: CreatePromotionTarget :exec
+INSERT INTO promotion_targets (promotion_id, target_type, target_id)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: ListPromotions :many
+SELECT * FROM promotions
+ORDER BY created_at DESC;
+
+-- name: GetPromotion :one
+SELECT * FROM promotions
+WHERE id = $1;
+
+-- name: GetPromotionByCode :one
+SELECT * FROM promotions
+WHERE code = $1;
+
+-- name: ListPromotionTargets :many
+SELECT * FROM promotion_targets
+WHERE promotion_id = $1
+ORDER BY target_type, target_id;
+
+-- name: SetPromotionActive :execrows
+UPDATE promotions SET
+    is_active = $2,
+    last_updated = NOW()
+WHERE id = $1;
+
+-- name: LockPromotion :one
+SELECT * FROM promotions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: IncrementPromotionUses :exec
+UPDATE promotions SET
+    times_used = times_used + 1
+WHERE id = $1;
+
+-- name: DecrementPromotionUses :exec
+UPDATE promotions SET
+    times_used = times_used - 1
+WHERE id = $1 AND times_used > 0;
+
+-- name: CountUserPromotionRedemptions :one
+SELECT COUNT(*) FROM promotion_redemptions
+WHERE promotion_id = $1 AND user_id = $2;
+
+-- name: CreatePromotionRedemption :one
+INSERT INTO promotion_redemptions (id, promotion_id, order_id, user_id, code, discount_amount, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+RETURNING *;
+
+-- name: GetOrderPromotionRedemption :one
+SELECT * FROM promotion_redemptions
+WHERE order_id = $1;
+
+-- name: DeleteOrderPromotionRedemption :one
+DELETE FROM promotion_redemptions
+WHERE order_id = $1
+RETURNING *;
+
+-- name: GetPromotionReport :one
+SELECT COUNT(r.id) AS redemptions,
+       COUNT(DISTINCT r.user_id) AS customers,
+       COALESCE(SUM(r.discount_amount), 0)::DECIMAL(12, 2) AS discount_total,
+       COALESCE(SUM(o.total_price) FILTER (WHERE o.order_status <> 'cancelled'), 0)::DECIMAL(12, 2) AS revenue
+FROM promotion_redemptions r
+    INNER JOIN orders o ON r.order_id = o.id
+WHERE r.promotion_id = $1;
+
+-- name: SetCartPromotion :exec
+INSERT INTO cart_promotions (shopping_cart_id, promotion_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (shopping_cart_id) DO UPDATE SET
+    promotion_id = EXCLUDED.promotion_id,
+    created_at = EXCLUDED.created_at;
+
+-- name: GetCartPromotion :one
+SELECT p.* FROM promotions p
+    INNER JOIN cart_promotions cp ON cp.promotion_id = p.id
+WHERE cp.shopping_cart_id = $1;
+
+-- name: DeleteCartPromotion :execrows
+DELETE FROM cart_promotions
+WHERE shopping_cart_id = $1;
\ No newline at end of file
@@ -0,0 +1,25 @@
+-- name: UpsertRecentlyViewedProduct :exec
+INSERT INTO recently_viewed_products (user_id, product_id, last_viewed_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (user_id, product_id) DO UPDATE
+SET last_viewed_at = EXCLUDED.last_viewed_at;
+
+-- name: TrimRecentlyViewedProducts :exec
+DELETE FROM recently_viewed_products
+WHERE user_id = sqlc.arg(user_id)
+    AND product_id NOT IN (
+        SELECT rv.product_id
+        FROM recently_viewed_products rv
+        WHERE rv.user_id = sqlc.arg(user_id)
+        ORDER BY rv.last_viewed_at DESC, rv.product_id
+        LIMIT sqlc.arg(retain)
+    );
+
+-- name: ListRecentlyViewedProducts :many
+SELECT p.*
+FROM recently_viewed_products rv
+    JOIN products p ON p.id = rv.product_id
+WHERE rv.user_id = $1
+    AND p.is_active = TRUE
+ORDER BY rv.last_viewed_at DESC, p.id
+LIMIT $2;
\ No newline at end of file
@@ -0,0 +1,196 @@
+-- name: LockProductCoPurchases :exec
+LOCK TABLE product_co_purchases IN EXCLUSIVE MODE;
+
+-- name: DeleteProductCoPurchases :exec
+DELETE FROM product_co_purchases;
+
+-- name: InsertProductCoPurchases :execrows
+WITH purchases AS (
+    SELECT oi.order_id, oi.product_id
+    FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+    WHERE o.order_status <> 'cancelled'
+),
+product_orders AS (
+    SELECT product_id, COUNT(*) AS order_count
+    FROM purchases
+    GROUP BY product_id
+),
+pairs AS (
+    SELECT a.product_id, b.product_id AS related_product_id, COUNT(*) AS order_count
+    FROM purchases a
+        JOIN purchases b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+    GROUP BY a.product_id, b.product_id
+    HAVING COUNT(*) >= sqlc.arg(min_orders)::INT
+),
+scored AS (
+    -- cosine similarity of the two products' sets of orders
+    SELECT pairs.product_id,
+        pairs.related_product_id,
+        pairs.order_count,
+        pairs.order_count / SQRT(pa.order_count * pb.order_count) AS score
+    FROM pairs
+        JOIN product_orders pa ON pa.product_id = pairs.product_id
+        JOIN product_orders pb ON pb.product_id = pairs.related_product_id
+),
+ranked AS (
+    SELECT scored.*,
+        ROW_NUMBER() OVER (PARTITION BY scored.product_id ORDER BY scored.score DESC, scored.order_count DESC, scored.related_product_id) AS rank
+    FROM scored
+)
+INSERT INTO product_co_purchases (product_id, related_product_id, order_count, score, computed_at)
+SELECT product_id, related_product_id, order_count, score, NOW()
+FROM ranked
+WHERE rank <= sqlc.arg(per_product)::INT;
+
+-- name: ListCoPurchasedProducts :many
+SELECT p.*
+FROM product_co_purchases cp
+    JOIN products p ON p.id = cp.related_product_id
+WHERE cp.product_id = $1
+    AND p.is_active = TRUE
+    AND p.stock > 0
+ORDER BY cp.score DESC, cp.order_count DESC, p.id
+LIMIT $2;
+
+-- name: ListSubCategoryBestsellers :many
+SELECT p.*
+FROM products p
+    LEFT JOIN (
+        SELECT oi.product_id, SUM(oi.quantity) AS sales_volume
+        FROM order_items oi
+            JOIN orders o ON o.id = oi.order_id
+        WHERE o.order_status <> 'cancelled'
+            AND o.created_at > NOW() - INTERVAL '3 months'
+        GROUP BY oi.product_id
+    ) s ON s.product_id = p.id
+WHERE p.sub_category_id = sqlc.arg(sub_category_id)
+    AND p.id <> sqlc.arg(product_id)
+    AND p.is_active = TRUE
+    AND p.stock > 0
+ORDER BY COALESCE(s.sales_volume, 0) DESC, p.rating DESC, p.id
+LIMIT sqlc.arg('limit');
+
+-- name: LockProductWishlistAffinities :exec
+LOCK TABLE product_wishlist_affinities IN EXCLUSIVE MODE;
+
+-- name: DeleteProductWishlistAffinities :exec
+DELETE FROM product_wishlist_affinities;
+
+-- name: InsertProductWishlistAffinities :execrows
+WITH saves AS (
+    SELECT DISTINCT w.user_id, wi.product_id
+    FROM wishlist_items wi
+        JOIN wishlists w ON w.id = wi.wishlist_id
+),
+product_users AS (
+    SELECT product_id, COUNT(*) AS user_count
+    FROM saves
+    GROUP BY product_id
+),
+pairs AS (
+    SELECT a.product_id, b.product_id AS related_product_id, COUNT(*) AS user_count
+    FROM saves a
+        JOIN saves b ON b.user_id = a.user_id AND b.product_id <> a.product_id
+    GROUP BY a.product_id, b.product_id
+    HAVING COUNT(*) >= sqlc.arg(min_users)::INT
+),
+scored AS (
+    -- cosine similarity of the two products' sets of users
+    SELECT pairs.product_id,
+        pairs.related_product_id,
+        pairs.user_count,
+        pairs.user_count / SQRT(pa.user_count * pb.user_count) AS score
+    FROM pairs
+        JOIN product_users pa ON pa.product_id = pairs.product_id
+        JOIN product_users pb ON pb.product_id = pairs.related_product_id
+),
+ranked AS (
+    SELECT scored.*,
+        ROW_NUMBER() OVER (PARTITION BY scored.product_id ORDER BY scored.score DESC, scored.user_count DESC, scored.related_product_id) AS rank
+    FROM scored
+)
+INSERT INTO product_wishlist_affinities (product_id, related_product_id, user_count, score, computed_at)
+SELECT product_id, related_product_id, user_count, score, NOW()
+FROM ranked
+WHERE rank <= sqlc.arg(per_product)::INT;
+
+-- name: ListAlsoSavedProducts :many
+SELECT p.*
+FROM product_wishlist_affinities wa
+    JOIN products p ON p.id = wa.related_product_id
+WHERE wa.product_id = $1
+    AND p.is_active = TRUE
+    AND p.stock > 0
+ORDER BY wa.score DESC, wa.user_count DESC, p.id
+LIMIT $2;
+
+-- name: ListUserRecommendedProducts :many
+WITH saved AS (
+    SELECT DISTINCT wi.product_id
+    FROM wishlist_items wi
+        JOIN wishlists w ON w.id = wi.wishlist_id
+    WHERE w.user_id = sqlc.arg(user_id)
+),
+bought AS (
+    SELECT DISTINCT oi.product_id
+    FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+    WHERE o.user_id = sqlc.arg(user_id)
+        AND o.order_status <> 'cancelled'
+),
+viewed AS (
+    SELECT rv.product_id, ROW_NUMBER() OVER (ORDER BY rv.last_viewed_at DESC, rv.product_id) AS recency
+    FROM recently_viewed_products rv
+    WHERE rv.user_id = sqlc.arg(user_id)
+),
+seeds AS (
+    -- saved products count as much as the last product viewed, and older views count for less
+    SELECT product_id, MAX(weight) AS weight
+    FROM (
+        SELECT product_id, 1 / recency::DOUBLE PRECISION AS weight
+        FROM viewed
+        UNION ALL
+        SELECT product_id, 1::DOUBLE PRECISION AS weight
+        FROM saved
+    ) s
+    GROUP BY product_id
+),
+scored AS (
+    SELECT wa.related_product_id AS product_id, SUM(wa.score * seeds.weight) AS score
+    FROM seeds
+        JOIN product_wishlist_affinities wa ON wa.product_id = seeds.product_id
+    GROUP BY wa.related_product_id
+)
+SELECT p.*
+FROM scored
+    JOIN products p ON p.id = scored.product_id
+WHERE p.is_active = TRUE
+    AND p.stock > 0
+    AND p.id NOT IN (SELECT product_id FROM saved)
+    AND p.id NOT IN (SELECT product_id FROM bought)
+ORDER BY scored.score DESC, p.id
+LIMIT sqlc.arg('limit');
+
+-- name: ListProductsNotSavedOrBought :many
+SELECT p.*
+FROM products p
+WHERE p.id = ANY(sqlc.arg(product_ids)::UUID[])
+    AND p.is_active = TRUE
+    AND p.stock > 0
+    AND NOT EXISTS (
+        SELECT 1
+        FROM wishlist_items wi
+            JOIN wishlists w ON w.id = wi.wishlist_id
+        WHERE w.user_id = sqlc.arg(user_id)
+            AND wi.product_id = p.id
+    )
+    AND NOT EXISTS (
+        SELECT 1
+        FROM order_items oi
+            JOIN orders o ON o.id = oi.order_id
+        WHERE o.user_id = sqlc.arg(user_id)
+            AND o.order_status <> 'cancelled'
+            AND oi.product_id = p.id
+    )
+ORDER BY p.rating DESC, p.id;
\ No newline at end of file
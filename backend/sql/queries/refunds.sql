@@ -0,0 +1,85 @@
+-- name: CreateRefund :one
+INSERT INTO refunds (id, order_id, return_request_id, amount, status, reason, created_at, last_updated, payment_id, created_by, settlement_amount, method)
+VALUES ($1, $2, $3, $4, 'pending', $5, NOW(), NULL, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetRefund :one
+SELECT * FROM refunds
+WHERE id = $1;
+
+-- name: GetRefundByReturnRequest :one
+SELECT * FROM refunds
+WHERE return_request_id = $1;
+
+-- name: CreateRefundItem :one
+INSERT INTO refund_items (id, refund_id, order_item_id, quantity, amount, created_at)
+VALUES ($1, $2, $3, $4, $5, NOW())
+RETURNING *;
+
+-- name: LockRefund :one
+SELECT * FROM refunds
+WHERE id = $1
+FOR UPDATE;
+
+-- name: StartRefund :one
+UPDATE refunds SET
+    status = 'processing',
+    payment_id = $2,
+    last_updated = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: FinishRefund :one
+UPDATE refunds SET
+    status = $2,
+    provider_refund_id = $3,
+    failure_message = $4,
+    last_updated = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListOrderRefunds :many
+SELECT * FROM refunds
+WHERE order_id = $1
+ORDER BY created_at;
+
+-- name: ListRefundItems :many
+SELECT * FROM refund_items
+WHERE refund_id = $1
+ORDER BY created_at;
+
+-- name: GetOrderRefundedAmount :one
+SELECT COALESCE(SUM(amount), 0)::DECIMAL(10, 2) AS refunded
+FROM refunds
+WHERE order_id = $1 AND status IN ('pending', 'processing', 'succeeded');
+
+-- name: GetOrderRefundedAmountByMethod :one
+SELECT COALESCE(SUM(amount), 0)::DECIMAL(10, 2) AS refunded
+FROM refunds
+WHERE order_id = $1 AND method = $2 AND status IN ('pending', 'processing', 'succeeded');
+
+-- name: GetOrderRefundedSettlementAmount :one
+SELECT COALESCE(SUM(COALESCE(settlement_amount, amount)), 0)::DECIMAL(10, 2) AS refunded
+FROM refunds
+WHERE order_id = $1 AND method = 'original' AND status IN ('pending', 'processing', 'succeeded');
+
+-- name: ListRefundableOrderItems :many
+SELECT oi.id, oi.quantity, oi.taxed_price,
+       COALESCE(SUM(ri.quantity) FILTER (WHERE r.status IN ('pending', 'processing', 'succeeded')), 0)::INT AS refunded_quantity
+FROM order_items oi
+    LEFT JOIN refund_items ri ON ri.order_item_id = oi.id
+    LEFT JOIN refunds r ON ri.refund_id = r.id
+WHERE oi.order_id = $1
+GROUP BY oi.id;
+
+-- name: SyncOrderRefundStatus :exec
+UPDATE orders SET
+    payment_status = CASE
+        WHEN (
+            SELECT COALESCE(SUM(r.amount), 0)
+            FROM refunds r
+            WHERE r.order_id = orders.id AND r.status = 'succeeded'
+        ) >= orders.total_price THEN 'refunded'
+        ELSE 'partially_refunded'
+    END
+WHERE id = $1;
\ No newline at end of file
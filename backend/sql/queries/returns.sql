@@ -0,0 +1,70 @@
+-- name: CreateReturnRequest :one
+INSERT INTO return_requests (id, order_id, user_id, status, reason, admin_note, created_at, last_updated)
+VALUES ($1, $2, $3, 'requested', $4, NULL, NOW(), NULL)
+RETURNING *;
+
+-- name: CreateReturnRequestItem :one
+INSERT INTO return_request_items (id, return_request_id, order_item_id, quantity, created_at)
+VALUES ($1, $2, $3, $4, NOW())
+RETURNING *;
+
+-- name: GetReturnRequestByID :one
+SELECT * FROM return_requests
+WHERE id = $1;
+
+-- name: LockReturnRequest :one
+SELECT * FROM return_requests
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateReturnRequestStatus :one
+UPDATE return_requests SET
+    status = sqlc.arg(status),
+    admin_note = COALESCE(sqlc.narg(admin_note), admin_note),
+    last_updated = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListReturnRequestItems :many
+SELECT rri.id, rri.return_request_id, rri.order_item_id, rri.quantity, rri.created_at, oi.product_id
+FROM return_request_items rri
+    INNER JOIN order_items oi ON rri.order_item_id = oi.id
+WHERE rri.return_request_id = $1
+ORDER BY rri.created_at;
+
+-- name: ListOrderReturnRequests :many
+SELECT * FROM return_requests
+WHERE order_id = $1
+ORDER BY created_at;
+
+-- name: ListReturnRequestsByStatus :many
+SELECT * FROM return_requests
+WHERE status = $1
+ORDER BY created_at
+LIMIT $2 OFFSET $3;
+
+-- name: CountReturnRequestsByStatus :one
+SELECT COUNT(*) FROM return_requests
+WHERE status = $1;
+
+-- name: ListReturnableOrderItems :many
+SELECT oi.id, oi.product_id, oi.quantity,
+       COALESCE(SUM(rri.quantity) FILTER (WHERE rr.status <> 'rejected'), 0)::INT AS returned_quantity
+FROM order_items oi
+    LEFT JOIN return_request_items rri ON rri.order_item_id = oi.id
+    LEFT JOIN return_requests rr ON rri.return_request_id = rr.id
+WHERE oi.order_id = $1
+GROUP BY oi.id;
+
+-- name: GetOrderDeliveredAt :one
+SELECT created_at FROM order_status_history
+WHERE order_id = $1 AND to_status = 'delivered'
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: RestockReturnRequestItems :exec
+UPDATE products SET
+    stock = products.stock + rri.quantity
+FROM return_request_items rri
+    INNER JOIN order_items oi ON rri.order_item_id = oi.id
+WHERE rri.return_request_id = $1 AND products.id = oi.product_id;
\ No newline at end of file
@@ -0,0 +1,147 @@
+-- name: CreateReview :one
+INSERT INTO reviews (id, user_id, product_id, rating, comment, verified_purchase, flagged_words, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+ON CONFLICT (user_id, product_id) DO NOTHING
+RETURNING *;
+
+-- name: UpdateReview :one
+UPDATE reviews
+SET rating = $3,
+    comment = $4,
+    verified_purchase = $5,
+    flagged_words = $6,
+    status = 'pending',
+    moderated_by = NULL,
+    moderated_at = NULL,
+    last_updated = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: ModerateReview :one
+UPDATE reviews
+SET status = $2,
+    moderated_by = $3,
+    moderated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkVerifiedPurchases :exec
+UPDATE reviews r
+SET verified_purchase = TRUE
+FROM orders o
+    JOIN order_items oi ON oi.order_id = o.id
+WHERE o.id = $1
+    AND r.user_id = o.user_id
+    AND r.product_id = oi.product_id
+    AND NOT r.verified_purchase;
+
+-- name: DeleteReview :one
+DELETE FROM reviews
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: GetReview :one
+SELECT * FROM reviews
+WHERE id = $1;
+
+-- name: ListProductReviews :many
+SELECT r.*, u.username, v.helpful_count, v.unhelpful_count
+FROM reviews r
+    JOIN users u ON u.id = r.user_id
+    CROSS JOIN LATERAL (
+        SELECT COUNT(*) FILTER (WHERE rv.helpful) AS helpful_count,
+            COUNT(*) FILTER (WHERE NOT rv.helpful) AS unhelpful_count
+        FROM review_votes rv
+        WHERE rv.review_id = r.id
+    ) v
+WHERE r.product_id = sqlc.arg(product_id)
+    AND r.status = 'approved'
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::VARCHAR = 'helpful' THEN wilson_lower_bound(v.helpful_count, v.unhelpful_count) END DESC,
+    CASE WHEN sqlc.arg(sort)::VARCHAR = 'highest' THEN r.rating END DESC,
+    CASE WHEN sqlc.arg(sort)::VARCHAR = 'lowest' THEN r.rating END,
+    r.created_at DESC,
+    r.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountProductReviews :one
+SELECT COUNT(*) FROM reviews
+WHERE product_id = $1
+    AND status = 'approved';
+
+-- name: ListModerationReviews :many
+SELECT r.*, u.username, p.name AS product_name, reports.report_count
+FROM reviews r
+    JOIN users u ON u.id = r.user_id
+    JOIN products p ON p.id = r.product_id
+    CROSS JOIN LATERAL (
+        SELECT COUNT(*) AS report_count
+        FROM review_reports rr
+        WHERE rr.review_id = r.id
+            AND (r.moderated_at IS NULL OR rr.created_at > r.moderated_at)
+    ) reports
+WHERE r.status = sqlc.arg(status)
+    AND (NOT sqlc.arg(flagged_only)::BOOLEAN OR r.flagged_words IS NOT NULL OR reports.report_count > 0)
+ORDER BY r.flagged_words IS NULL AND reports.report_count = 0, r.created_at, r.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountModerationReviews :one
+SELECT COUNT(*) FROM reviews r
+WHERE r.status = sqlc.arg(status)
+    AND (NOT sqlc.arg(flagged_only)::BOOLEAN OR r.flagged_words IS NOT NULL OR EXISTS (
+        SELECT 1
+        FROM review_reports rr
+        WHERE rr.review_id = r.id
+            AND (r.moderated_at IS NULL OR rr.created_at > r.moderated_at)
+    ));
+
+-- name: UpsertReviewVote :exec
+INSERT INTO review_votes (review_id, user_id, helpful, created_at)
+VALUES ($1, $2, $3, NOW())
+ON CONFLICT (review_id, user_id) DO UPDATE
+SET helpful = EXCLUDED.helpful,
+    last_updated = NOW();
+
+-- name: DeleteReviewVote :execrows
+DELETE FROM review_votes
+WHERE review_id = $1 AND user_id = $2;
+
+-- name: CreateReviewReport :one
+INSERT INTO review_reports (id, review_id, user_id, reason, created_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (review_id, user_id) DO NOTHING
+RETURNING *;
+
+-- name: CountOpenReviewReports :one
+SELECT COUNT(*)
+FROM review_reports rr
+    JOIN reviews r ON r.id = rr.review_id
+WHERE rr.review_id = $1
+    AND (r.moderated_at IS NULL OR rr.created_at > r.moderated_at);
+
+-- name: RequeueReview :one
+UPDATE reviews
+SET status = 'pending'
+WHERE id = $1 AND status = 'approved'
+RETURNING *;
+
+-- name: HasReceivedProduct :one
+SELECT EXISTS (
+    SELECT 1
+    FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+    WHERE o.user_id = $1
+        AND oi.product_id = $2
+        AND o.order_status = 'delivered'
+);
+
+-- name: LockReviewedProduct :one
+SELECT is_active FROM products
+WHERE id = $1
+FOR UPDATE;
+
+-- name: RefreshProductRating :exec
+UPDATE products
+SET rating = COALESCE((SELECT ROUND(AVG(r.rating), 1) FROM reviews r WHERE r.product_id = products.id AND r.status = 'approved'), 0),
+    review_count = (SELECT COUNT(*) FROM reviews r WHERE r.product_id = products.id AND r.status = 'approved')
+WHERE id = $1;
\ No newline at end of file
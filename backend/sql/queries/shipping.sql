@@ -0,0 +1,54 @@
+-- name: CreateShippingZone :one
+INSERT INTO shipping_zones (id, name, country, postal_code_prefix, free_shipping_threshold, created_at, last_updated)
+VALUES ($1, $2, $3, $4, $5, NOW(), NULL)
+ON CONFLICT (country, postal_code_prefix) DO NOTHING
+RETURNING *;
+
+-- name: ListShippingZones :many
+SELECT * FROM shipping_zones
+ORDER BY country, postal_code_prefix;
+
+-- name: HasShippingZones :one
+SELECT EXISTS (SELECT 1 FROM shipping_zones);
+
+-- name: DeleteShippingZone :execrows
+DELETE FROM shipping_zones
+WHERE id = $1;
+
+-- name: MatchShippingZone :one
+SELECT * FROM shipping_zones
+WHERE LOWER(country) = LOWER(sqlc.arg(country))
+    AND sqlc.arg(postal_code)::VARCHAR LIKE postal_code_prefix || '%'
+ORDER BY LENGTH(postal_code_prefix) DESC
+LIMIT 1;
+
+-- name: CreateShippingRate :one
+INSERT INTO shipping_rates (id, zone_id, method, name, min_weight_grams, max_weight_grams, price, estimated_days, created_at)
+SELECT $1, sz.id, $3, $4, $5, $6, $7, $8, NOW()
+FROM shipping_zones sz
+WHERE sz.id = $2
+RETURNING *;
+
+-- name: ListZoneShippingRates :many
+SELECT * FROM shipping_rates
+WHERE zone_id = $1
+ORDER BY method, min_weight_grams;
+
+-- name: ListShippingRatesForWeight :many
+SELECT DISTINCT ON (method) * FROM shipping_rates
+WHERE zone_id = sqlc.arg(zone_id)
+    AND min_weight_grams <= sqlc.arg(weight_grams)::INT
+    AND (max_weight_grams IS NULL OR max_weight_grams > sqlc.arg(weight_grams)::INT)
+ORDER BY method, min_weight_grams DESC;
+
+-- name: DeleteShippingRate :execrows
+DELETE FROM shipping_rates
+WHERE id = $1;
+
+-- name: GetUserShippingDestination :one
+SELECT country, state, postal_code
+FROM shipping_addresses
+WHERE user_id = sqlc.arg(user_id)
+    AND (sqlc.narg(address_id)::UUID IS NULL OR id = sqlc.narg(address_id))
+ORDER BY is_default DESC, created_at
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,38 @@
+-- name: GetStoreCreditAccount :one
+SELECT * FROM store_credit_accounts
+WHERE user_id = $1;
+
+-- name: LockStoreCreditAccount :one
+SELECT * FROM store_credit_accounts
+WHERE user_id = $1
+FOR UPDATE;
+
+-- name: CreditStoreCredit :one
+INSERT INTO store_credit_accounts (user_id, balance, created_at, last_updated)
+VALUES ($1, $2, NOW(), NULL)
+ON CONFLICT (user_id) DO UPDATE SET
+    balance = store_credit_accounts.balance + EXCLUDED.balance,
+    last_updated = NOW()
+RETURNING *;
+
+-- name: DebitStoreCredit :one
+UPDATE store_credit_accounts SET
+    balance = balance - sqlc.arg(amount),
+    last_updated = NOW()
+WHERE user_id = sqlc.arg(user_id) AND balance >= sqlc.arg(amount)
+RETURNING *;
+
+-- name: CreateStoreCreditTransaction :one
+INSERT INTO store_credit_transactions (id, user_id, amount, balance_after, reason, order_id, refund_id, note, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+RETURNING *;
+
+-- name: ListStoreCreditTransactions :many
+SELECT * FROM store_credit_transactions
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetOrderStoreCreditAmount :one
+SELECT (-COALESCE(SUM(amount), 0))::DECIMAL(10, 2) AS amount
+FROM store_credit_transactions
+WHERE order_id = $1 AND reason IN ('redeem', 'restore');
\ No newline at end of file
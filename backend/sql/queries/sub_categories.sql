@@ -1,15 +1,15 @@
 -- name: CreateSubCategory :one
-INSERT INTO sub_categories (id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated)
-VALUES ($1, $2, $3, $4, $5, $6, true, NOW(), NOW())
+INSERT INTO sub_categories (id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated, tax_class)
+VALUES ($1, $2, $3, $4, $5, $6, true, NOW(), NOW(), $7)
 RETURNING *;
 
 -- name: GetSubCategory :one
-SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated
+SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated, tax_class
 FROM sub_categories
 WHERE id = $1;
 
 -- name: ListAllSubCategories :many
-SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated
+SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated, tax_class
 FROM sub_categories;
 
 -- name: UpdateSubCategory :one
@@ -19,6 +19,7 @@ UPDATE sub_categories SET
     description = $4,
     image_url = $5,
     SEO_keywords = $6,
+    tax_class = $7,
     last_updated = NOW()
 WHERE id = $1
 RETURNING *;
@@ -28,7 +29,7 @@ DELETE FROM sub_categories
 WHERE id = $1;
 
 -- name: GetSubCategoryByCategory :many
-SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated
+SELECT id, category_id, name, description, image_url, SEO_keywords, is_active, created_at, last_updated, tax_class
 FROM sub_categories
 WHERE category_id = $1
 ORDER BY created_at DESC
@@ -50,4 +51,10 @@ WHERE sub_category_id = $1;
 -- name: GetSubCategoryCountByCategory :one
 SELECT COUNT(*)
 FROM sub_categories
-WHERE category_id = $1;
\ No newline at end of file
+WHERE category_id = $1;
+
+-- name: SetSubCategoryTaxClass :execrows
+UPDATE sub_categories SET
+    tax_class = $2,
+    last_updated = NOW()
+WHERE id = $1;
\ No newline at end of file
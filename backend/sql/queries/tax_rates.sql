@@ -0,0 +1,36 @@
+-- name: ListApplicableTaxRates :many
+SELECT * FROM tax_rates
+WHERE LOWER(country) = LOWER(sqlc.arg(country))
+    AND (region = '' OR LOWER(region) = LOWER(sqlc.arg(region)))
+ORDER BY tax_class, region DESC;
+
+-- name: ListTaxRates :many
+SELECT * FROM tax_rates
+ORDER BY country, region, tax_class;
+
+-- name: UpsertTaxRate :one
+INSERT INTO tax_rates (id, country, region, tax_class, name, rate, prices_include_tax, created_at, last_updated)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NULL)
+ON CONFLICT (country, region, tax_class) DO UPDATE SET
+    name = EXCLUDED.name,
+    rate = EXCLUDED.rate,
+    prices_include_tax = EXCLUDED.prices_include_tax,
+    last_updated = NOW()
+RETURNING *;
+
+-- name: DeleteTaxRate :execrows
+DELETE FROM tax_rates
+WHERE id = $1;
+
+-- name: GetUserTaxProfile :one
+SELECT u.tax_exempt, sa.country, sa.state
+FROM users u
+    LEFT JOIN LATERAL (
+        SELECT s.country, s.state
+        FROM shipping_addresses s
+        WHERE s.user_id = u.id
+            AND (sqlc.narg(address_id)::UUID IS NULL OR s.id = sqlc.narg(address_id))
+        ORDER BY s.is_default DESC, s.created_at
+        LIMIT 1
+    ) sa ON TRUE
+WHERE u.id = sqlc.arg(id);
\ No newline at end of file
@@ -201,4 +201,9 @@ SELECT * FROM users
 WHERE id = (
     SELECT user_id FROM refresh_tokens
     WHERE token = $1
-);
\ No newline at end of file
+);
+
+-- name: SetUserTaxExempt :execrows
+UPDATE users SET
+    tax_exempt = $2
+WHERE id = $1;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE order_items
+    ADD COLUMN unit_price DECIMAL(10, 2) NOT NULL DEFAULT 0.0;
+
+-- 'credit_card' does not fit in the original VARCHAR(10)
+ALTER TABLE orders
+    ALTER COLUMN payment_method TYPE VARCHAR(20);
+
+-- +goose Down
+ALTER TABLE orders
+    ALTER COLUMN payment_method TYPE VARCHAR(10);
+
+ALTER TABLE order_items
+    DROP COLUMN unit_price;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE order_status_history (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    from_status VARCHAR(10) NULL,
+    to_status VARCHAR(10) NOT NULL,
+    actor_id UUID NULL,
+    reason VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users (id) ON DELETE SET NULL,
+    CHECK (to_status IN ('pending', 'processing', 'shipped', 'delivered', 'cancelled'))
+);
+
+CREATE INDEX idx_order_status_history_order_id ON order_status_history(order_id);
+
+-- +goose Down
+DROP INDEX idx_order_status_history_order_id;
+
+DROP TABLE order_status_history;
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- +goose Up
+ALTER TABLE orders
+    ALTER COLUMN payment_status TYPE VARCHAR(20);
+
+ALTER TABLE orders
+    DROP CONSTRAINT orders_payment_status_check;
+
+ALTER TABLE orders
+    ADD CONSTRAINT orders_payment_status_check CHECK (payment_status IN ('pending', 'paid', 'failed', 'refund_pending', 'refunded'));
+
+-- +goose Down
+UPDATE orders SET payment_status = 'paid'
+WHERE payment_status IN ('refund_pending', 'refunded');
+
+ALTER TABLE orders
+    DROP CONSTRAINT orders_payment_status_check;
+
+ALTER TABLE orders
+    ADD CONSTRAINT orders_payment_status_check CHECK (payment_status IN ('pending', 'paid', 'failed'));
+
+ALTER TABLE orders
+    ALTER COLUMN payment_status TYPE VARCHAR(10);
\ No newline at end of file
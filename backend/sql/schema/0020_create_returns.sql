@@ -0,0 +1,56 @@
+-- +goose Up
+CREATE TABLE return_requests (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'requested',
+    reason VARCHAR(255) NOT NULL,
+    admin_note VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_updated TIMESTAMP NULL,
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CHECK (status IN ('requested', 'approved', 'rejected', 'received'))
+);
+
+CREATE TABLE return_request_items (
+    id UUID PRIMARY KEY,
+    return_request_id UUID NOT NULL,
+    order_item_id UUID NOT NULL,
+    quantity INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (return_request_id) REFERENCES return_requests (id) ON DELETE CASCADE,
+    FOREIGN KEY (order_item_id) REFERENCES order_items (id) ON DELETE CASCADE,
+    UNIQUE (return_request_id, order_item_id),
+    CHECK (quantity > 0)
+);
+
+CREATE TABLE refunds (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    return_request_id UUID NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    reason VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_updated TIMESTAMP NULL,
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (return_request_id) REFERENCES return_requests (id) ON DELETE SET NULL,
+    CHECK (amount >= 0),
+    CHECK (status IN ('pending', 'succeeded', 'failed'))
+);
+
+CREATE INDEX idx_return_requests_order_id ON return_requests(order_id);
+CREATE INDEX idx_return_requests_status ON return_requests(status);
+CREATE INDEX idx_return_request_items_order_item_id ON return_request_items(order_item_id);
+CREATE INDEX idx_refunds_order_id ON refunds(order_id);
+
+-- +goose Down
+DROP INDEX idx_refunds_order_id;
+DROP INDEX idx_return_request_items_order_item_id;
+DROP INDEX idx_return_requests_status;
+DROP INDEX idx_return_requests_order_id;
+
+DROP TABLE refunds;
+DROP TABLE return_request_items;
+DROP TABLE return_requests;
\ No newline at end of file
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE payments (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    payment_method VARCHAR(20) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    provider_transaction_id VARCHAR(100) NULL,
+    failure_message VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_updated TIMESTAMP NULL,
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+    CHECK (status IN ('pending', 'authorized', 'captured', 'declined', 'failed', 'voided'))
+);
+
+CREATE INDEX idx_payments_order_id ON payments(order_id);
+CREATE UNIQUE INDEX idx_payments_provider_transaction_id ON payments(provider, provider_transaction_id);
+
+-- +goose Down
+DROP INDEX idx_payments_provider_transaction_id;
+DROP INDEX idx_payments_order_id;
+
+DROP TABLE payments;
\ No newline at end of file
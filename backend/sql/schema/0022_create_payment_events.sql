@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE payment_events (
+    id UUID PRIMARY KEY,
+    provider VARCHAR(20) NOT NULL,
+    event_id VARCHAR(100) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    provider_transaction_id VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    outcome VARCHAR(20) NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP NULL,
+    UNIQUE (provider, event_id)
+);
+
+-- +goose Down
+DROP TABLE payment_events;
\ No newline at end of file
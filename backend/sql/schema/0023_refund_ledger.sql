@@ -0,0 +1,62 @@
+-- +goose Up
+ALTER TABLE refunds
+    ADD COLUMN payment_id UUID NULL REFERENCES payments (id) ON DELETE SET NULL,
+    ADD COLUMN provider_refund_id VARCHAR(100) NULL,
+    ADD COLUMN failure_message VARCHAR(255) NULL,
+    ADD COLUMN created_by UUID NULL REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE refunds
+    DROP CONSTRAINT refunds_status_check;
+
+ALTER TABLE refunds
+    ADD CONSTRAINT refunds_status_check CHECK (status IN ('pending', 'processing', 'succeeded', 'failed'));
+
+CREATE TABLE refund_items (
+    id UUID PRIMARY KEY,
+    refund_id UUID NOT NULL,
+    order_item_id UUID NOT NULL,
+    quantity INT NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (refund_id) REFERENCES refunds (id) ON DELETE CASCADE,
+    FOREIGN KEY (order_item_id) REFERENCES order_items (id) ON DELETE CASCADE,
+    UNIQUE (refund_id, order_item_id),
+    CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_refund_items_order_item_id ON refund_items(order_item_id);
+
+ALTER TABLE orders
+    DROP CONSTRAINT orders_payment_status_check;
+
+ALTER TABLE orders
+    ADD CONSTRAINT orders_payment_status_check CHECK (payment_status IN ('pending', 'paid', 'failed', 'refund_pending', 'partially_refunded', 'refunded'));
+
+-- +goose Down
+UPDATE orders SET payment_status = 'refunded'
+WHERE payment_status = 'partially_refunded';
+
+ALTER TABLE orders
+    DROP CONSTRAINT orders_payment_status_check;
+
+ALTER TABLE orders
+    ADD CONSTRAINT orders_payment_status_check CHECK (payment_status IN ('pending', 'paid', 'failed', 'refund_pending', 'refunded'));
+
+DROP INDEX idx_refund_items_order_item_id;
+
+DROP TABLE refund_items;
+
+UPDATE refunds SET status = 'pending'
+WHERE status = 'processing';
+
+ALTER TABLE refunds
+    DROP CONSTRAINT refunds_status_check;
+
+ALTER TABLE refunds
+    ADD CONSTRAINT refunds_status_check CHECK (status IN ('pending', 'succeeded', 'failed'));
+
+ALTER TABLE refunds
+    DROP COLUMN created_by,
+    DROP COLUMN failure_message,
+    DROP COLUMN provider_refund_id,
+    DROP COLUMN payment_id;
\ No newline at end of file
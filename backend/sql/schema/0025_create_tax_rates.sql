@@ -0,0 +1,36 @@
+-- +goose Up
+CREATE TABLE tax_rates (
+    id UUID PRIMARY KEY,
+    country VARCHAR(100) NOT NULL,
+    region VARCHAR(100) NOT NULL DEFAULT '',
+    tax_class VARCHAR(50) NOT NULL DEFAULT 'standard',
+    name VARCHAR(50) NOT NULL,
+    rate DECIMAL(6, 4) NOT NULL CHECK (rate >= 0 AND rate < 1),
+    prices_include_tax BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_updated TIMESTAMP NULL,
+    UNIQUE (country, region, tax_class)
+);
+
+ALTER TABLE sub_categories
+    ADD COLUMN tax_class VARCHAR(50) NOT NULL DEFAULT 'standard';
+
+ALTER TABLE users
+    ADD COLUMN tax_exempt BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE order_items
+    ADD COLUMN tax_rate DECIMAL(6, 4) NOT NULL DEFAULT 0.0,
+    ADD COLUMN tax_amount DECIMAL(10, 2) NOT NULL DEFAULT 0.0;
+
+-- +goose Down
+ALTER TABLE order_items
+    DROP COLUMN tax_amount,
+    DROP COLUMN tax_rate;
+
+ALTER TABLE users
+    DROP COLUMN tax_exempt;
+
+ALTER TABLE sub_categories
+    DROP COLUMN tax_class;
+
+DROP TABLE tax_rates;
\ No newline at end of file
@@ -0,0 +1,50 @@
+-- +goose Up
+ALTER TABLE products
+    ADD COLUMN weight_grams INT NOT NULL DEFAULT 0 CHECK (weight_grams >= 0),
+    ADD COLUMN length_cm INT NULL CHECK (length_cm > 0),
+    ADD COLUMN width_cm INT NULL CHECK (width_cm > 0),
+    ADD COLUMN height_cm INT NULL CHECK (height_cm > 0);
+
+CREATE TABLE shipping_zones (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    country VARCHAR(100) NOT NULL,
+    postal_code_prefix VARCHAR(20) NOT NULL DEFAULT '',
+    free_shipping_threshold DECIMAL(10, 2) NULL CHECK (free_shipping_threshold >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_updated TIMESTAMP NULL,
+    UNIQUE (country, postal_code_prefix)
+);
+
+CREATE TABLE shipping_rates (
+    id UUID PRIMARY KEY,
+    zone_id UUID NOT NULL REFERENCES shipping_zones(id) ON DELETE CASCADE,
+    method VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    min_weight_grams INT NOT NULL DEFAULT 0 CHECK (min_weight_grams >= 0),
+    max_weight_grams INT NULL,
+    price DECIMAL(10, 2) NOT NULL CHECK (price >= 0),
+    estimated_days INT NULL CHECK (estimated_days > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (max_weight_grams IS NULL OR max_weight_grams > min_weight_grams)
+);
+
+CREATE INDEX idx_shipping_rates_zone_id ON shipping_rates(zone_id);
+
+ALTER TABLE orders
+    ADD COLUMN shipping_method VARCHAR(50) NULL,
+    ADD COLUMN shipping_cost DECIMAL(10, 2) NOT NULL DEFAULT 0.0;
+
+-- +goose Down
+ALTER TABLE orders
+    DROP COLUMN shipping_cost,
+    DROP COLUMN shipping_method;
+
+DROP TABLE shipping_rates;
+DROP TABLE shipping_zones;
+
+ALTER TABLE products
+    DROP COLUMN height_cm,
+    DROP COLUMN width_cm,
+    DROP COLUMN length_cm,
+    DROP COLUMN weight_grams;
\ No newline at end of file
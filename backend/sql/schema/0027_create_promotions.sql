@@ -0,0 +1,62 @@
+-- +goose Up
+CREATE TABLE promotions (
+    id UUID PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255) NULL,
+    discount_type VARCHAR(20) NOT NULL,
+    discount_value DECIMAL(10, 2) NOT NULL DEFAULT 0.0 CHECK (discount_value >= 0),
+    min_cart_value DECIMAL(10, 2) NULL CHECK (min_cart_value >= 0),
+    starts_at TIMESTAMP NULL,
+    ends_at TIMESTAMP NULL,
+    max_uses INT NULL CHECK (max_uses > 0),
+    max_uses_per_user INT NULL CHECK (max_uses_per_user > 0),
+    times_used INT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_updated TIMESTAMP NULL,
+    CHECK (discount_type IN ('percentage', 'fixed_amount', 'free_shipping')),
+    CHECK (discount_type <> 'percentage' OR discount_value <= 100),
+    CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE TABLE promotion_targets (
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL,
+    target_id UUID NOT NULL,
+    PRIMARY KEY (promotion_id, target_type, target_id),
+    CHECK (target_type IN ('category', 'sub_category', 'product'))
+);
+
+CREATE TABLE cart_promotions (
+    shopping_cart_id UUID PRIMARY KEY REFERENCES shopping_carts(id) ON DELETE CASCADE,
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE promotion_redemptions (
+    id UUID PRIMARY KEY,
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    discount_amount DECIMAL(10, 2) NOT NULL CHECK (discount_amount >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_promotion_redemptions_promotion_id ON promotion_redemptions(promotion_id);
+CREATE INDEX idx_promotion_redemptions_user_id ON promotion_redemptions(user_id);
+
+ALTER TABLE order_items
+    ADD COLUMN discount_amount DECIMAL(10, 2) NOT NULL DEFAULT 0.0;
+
+-- +goose Down
+ALTER TABLE order_items
+    DROP COLUMN discount_amount;
+
+DROP INDEX idx_promotion_redemptions_user_id;
+DROP INDEX idx_promotion_redemptions_promotion_id;
+
+DROP TABLE promotion_redemptions;
+DROP TABLE cart_promotions;
+DROP TABLE promotion_targets;
+DROP TABLE promotions;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE products
+    ADD COLUMN sale_starts_at TIMESTAMP NULL,
+    ADD COLUMN sale_ends_at TIMESTAMP NULL,
+    ADD CONSTRAINT products_sale_window_check CHECK (sale_ends_at > sale_starts_at);
+
+-- +goose Down
+ALTER TABLE products
+    DROP CONSTRAINT products_sale_window_check,
+    DROP COLUMN sale_ends_at,
+    DROP COLUMN sale_starts_at;
\ No newline at end of file
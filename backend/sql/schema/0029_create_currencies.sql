@@ -0,0 +1,51 @@
+-- +goose Up
+CREATE TABLE currencies (
+    code CHAR(3) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    symbol VARCHAR(10) NOT NULL DEFAULT '',
+    rounding_increment DECIMAL(10, 2) NOT NULL DEFAULT 0.01 CHECK (rounding_increment > 0),
+    rounding_mode VARCHAR(10) NOT NULL DEFAULT 'half_up',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_updated TIMESTAMP NULL,
+    CHECK (code ~ '^[A-Z]{3}$'),
+    CHECK (rounding_mode IN ('half_up', 'up', 'down'))
+);
+
+CREATE TABLE exchange_rates (
+    currency_code CHAR(3) PRIMARY KEY,
+    rate DECIMAL(18, 8) NOT NULL CHECK (rate > 0),
+    source VARCHAR(10) NOT NULL DEFAULT 'manual',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (currency_code) REFERENCES currencies (code) ON DELETE CASCADE,
+    CHECK (source IN ('manual', 'csv'))
+);
+
+INSERT INTO currencies (code, name, symbol) VALUES ('USD', 'US Dollar', '$');
+INSERT INTO exchange_rates (currency_code, rate) VALUES ('USD', 1);
+
+ALTER TABLE orders
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD COLUMN exchange_rate DECIMAL(18, 8) NOT NULL DEFAULT 1 CHECK (exchange_rate > 0),
+    ADD COLUMN settlement_total DECIMAL(10, 2) NULL;
+
+ALTER TABLE payments
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE refunds
+    ADD COLUMN settlement_amount DECIMAL(10, 2) NULL CHECK (settlement_amount >= 0);
+
+-- +goose Down
+ALTER TABLE refunds
+    DROP COLUMN settlement_amount;
+
+ALTER TABLE payments
+    DROP COLUMN currency;
+
+ALTER TABLE orders
+    DROP COLUMN settlement_total,
+    DROP COLUMN exchange_rate,
+    DROP COLUMN currency;
+
+DROP TABLE exchange_rates;
+DROP TABLE currencies;
\ No newline at end of file
@@ -0,0 +1,90 @@
+-- +goose Up
+CREATE TABLE gift_cards (
+    id UUID PRIMARY KEY,
+    code VARCHAR(32) NOT NULL UNIQUE,
+    initial_balance DECIMAL(10, 2) NOT NULL CHECK (initial_balance > 0),
+    balance DECIMAL(10, 2) NOT NULL,
+    expires_at TIMESTAMP NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NULL REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_updated TIMESTAMP NULL,
+    CHECK (balance >= 0 AND balance <= initial_balance)
+);
+
+CREATE TABLE store_credit_accounts (
+    user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    balance DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_updated TIMESTAMP NULL
+);
+
+ALTER TABLE orders
+    ADD COLUMN gift_card_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (gift_card_amount >= 0),
+    ADD COLUMN store_credit_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (store_credit_amount >= 0);
+
+ALTER TABLE refunds
+    ADD COLUMN method VARCHAR(20) NOT NULL DEFAULT 'original',
+    ADD CONSTRAINT refunds_method_check CHECK (method IN ('original', 'store_credit', 'gift_card'));
+
+CREATE TABLE gift_card_transactions (
+    id UUID PRIMARY KEY,
+    gift_card_id UUID NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount <> 0),
+    balance_after DECIMAL(10, 2) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    order_id UUID NULL,
+    refund_id UUID NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (gift_card_id) REFERENCES gift_cards (id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE SET NULL,
+    FOREIGN KEY (refund_id) REFERENCES refunds (id) ON DELETE SET NULL,
+    CHECK (reason IN ('issue', 'redeem', 'restore'))
+);
+
+CREATE INDEX idx_gift_card_transactions_gift_card_id ON gift_card_transactions(gift_card_id);
+CREATE INDEX idx_gift_card_transactions_order_id ON gift_card_transactions(order_id);
+
+CREATE TABLE store_credit_transactions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount <> 0),
+    balance_after DECIMAL(10, 2) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    order_id UUID NULL,
+    refund_id UUID NULL,
+    note VARCHAR(255) NULL,
+    created_by UUID NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE SET NULL,
+    FOREIGN KEY (refund_id) REFERENCES refunds (id) ON DELETE SET NULL,
+    FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL,
+    CHECK (reason IN ('refund', 'adjustment', 'redeem', 'restore'))
+);
+
+CREATE INDEX idx_store_credit_transactions_user_id ON store_credit_transactions(user_id, created_at);
+CREATE INDEX idx_store_credit_transactions_order_id ON store_credit_transactions(order_id);
+
+-- +goose Down
+DROP INDEX idx_store_credit_transactions_order_id;
+DROP INDEX idx_store_credit_transactions_user_id;
+DROP TABLE store_credit_transactions;
+
+DROP INDEX idx_gift_card_transactions_order_id;
+DROP INDEX idx_gift_card_transactions_gift_card_id;
+DROP TABLE gift_card_transactions;
+
+DELETE FROM refunds
+WHERE method <> 'original';
+
+ALTER TABLE refunds
+    DROP CONSTRAINT refunds_method_check,
+    DROP COLUMN method;
+
+ALTER TABLE orders
+    DROP COLUMN store_credit_amount,
+    DROP COLUMN gift_card_amount;
+
+DROP TABLE store_credit_accounts;
+DROP TABLE gift_cards;
\ No newline at end of file
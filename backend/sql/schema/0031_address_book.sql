@@ -0,0 +1,47 @@
+-- +goose Up
+ALTER TABLE shipping_addresses
+    ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ADD COLUMN last_updated TIMESTAMP NULL;
+
+ALTER TABLE billing_addresses
+    ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ADD COLUMN last_updated TIMESTAMP NULL;
+
+-- every user with addresses starts with one of each type as their default
+UPDATE shipping_addresses SET is_default = TRUE
+WHERE id IN (SELECT DISTINCT ON (user_id) id FROM shipping_addresses ORDER BY user_id, id);
+
+UPDATE billing_addresses SET is_default = TRUE
+WHERE id IN (SELECT DISTINCT ON (user_id) id FROM billing_addresses ORDER BY user_id, id);
+
+CREATE INDEX shipping_addresses_user_id_idx ON shipping_addresses (user_id);
+CREATE UNIQUE INDEX shipping_addresses_user_default_idx ON shipping_addresses (user_id) WHERE is_default;
+CREATE INDEX billing_addresses_user_id_idx ON billing_addresses (user_id);
+CREATE UNIQUE INDEX billing_addresses_user_default_idx ON billing_addresses (user_id) WHERE is_default;
+
+-- orders keep a copy of the address they were placed with, which can be longer than free text
+ALTER TABLE orders
+    ALTER COLUMN shipping_address TYPE TEXT,
+    ALTER COLUMN billing_address TYPE TEXT;
+
+-- +goose Down
+ALTER TABLE orders
+    ALTER COLUMN shipping_address TYPE VARCHAR(100) USING LEFT(shipping_address, 100),
+    ALTER COLUMN billing_address TYPE VARCHAR(100) USING LEFT(billing_address, 100);
+
+DROP INDEX billing_addresses_user_default_idx;
+DROP INDEX billing_addresses_user_id_idx;
+DROP INDEX shipping_addresses_user_default_idx;
+DROP INDEX shipping_addresses_user_id_idx;
+
+ALTER TABLE billing_addresses
+    DROP COLUMN last_updated,
+    DROP COLUMN created_at,
+    DROP COLUMN is_default;
+
+ALTER TABLE shipping_addresses
+    DROP COLUMN last_updated,
+    DROP COLUMN created_at,
+    DROP COLUMN is_default;
\ No newline at end of file
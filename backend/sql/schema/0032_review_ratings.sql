@@ -0,0 +1,32 @@
+-- +goose Up
+-- ratings were never checked, so clamp any out of range before the check is added
+UPDATE reviews
+SET rating = LEAST(GREATEST(rating, 1), 5)
+WHERE rating < 1 OR rating > 5;
+
+ALTER TABLE reviews
+    ALTER COLUMN comment TYPE VARCHAR(1000),
+    ADD CONSTRAINT reviews_rating_check CHECK (rating >= 1 AND rating <= 5);
+
+CREATE INDEX idx_reviews_product_id_created_at ON reviews (product_id, created_at DESC);
+
+-- ratings were set by hand until now, so bring them in line with the reviews
+WITH totals AS (
+    SELECT p.id, COALESCE(ROUND(AVG(r.rating), 1), 0) AS rating, COUNT(r.id)::INT AS review_count
+    FROM products p
+        LEFT JOIN reviews r ON r.product_id = p.id
+    GROUP BY p.id
+)
+UPDATE products
+SET rating = totals.rating,
+    review_count = totals.review_count
+FROM totals
+WHERE products.id = totals.id
+    AND (products.rating <> totals.rating OR products.review_count <> totals.review_count);
+
+-- +goose Down
+DROP INDEX idx_reviews_product_id_created_at;
+
+ALTER TABLE reviews
+    DROP CONSTRAINT reviews_rating_check,
+    ALTER COLUMN comment TYPE VARCHAR(100) USING LEFT(comment, 100);
\ No newline at end of file
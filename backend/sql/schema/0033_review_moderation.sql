@@ -0,0 +1,46 @@
+-- +goose Up
+-- reviews written so far already count towards ratings, so they are added as approved
+ALTER TABLE reviews
+    ADD COLUMN status VARCHAR(10) NOT NULL DEFAULT 'approved',
+    ADD COLUMN verified_purchase BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN flagged_words VARCHAR(255) NULL,
+    ADD COLUMN moderated_by UUID NULL,
+    ADD COLUMN moderated_at TIMESTAMP NULL,
+    ADD CONSTRAINT reviews_status_check CHECK (status IN ('pending', 'approved', 'rejected')),
+    ADD CONSTRAINT reviews_moderated_by_fkey FOREIGN KEY (moderated_by) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE reviews
+    ALTER COLUMN status SET DEFAULT 'pending';
+
+CREATE INDEX idx_reviews_status_created_at ON reviews (status, created_at);
+
+-- marking past purchases is not an edit, so leave last_updated alone
+ALTER TABLE reviews DISABLE TRIGGER update_reviews_last_updated;
+
+UPDATE reviews r
+SET verified_purchase = TRUE
+WHERE EXISTS (
+    SELECT 1
+    FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+    WHERE o.user_id = r.user_id
+        AND oi.product_id = r.product_id
+        AND o.order_status = 'delivered'
+);
+
+ALTER TABLE reviews ENABLE TRIGGER update_reviews_last_updated;
+
+-- +goose Down
+-- only approved reviews count towards ratings, so the rest go with moderation
+DELETE FROM reviews WHERE status <> 'approved';
+
+DROP INDEX idx_reviews_status_created_at;
+
+ALTER TABLE reviews
+    DROP CONSTRAINT reviews_moderated_by_fkey,
+    DROP CONSTRAINT reviews_status_check,
+    DROP COLUMN moderated_at,
+    DROP COLUMN moderated_by,
+    DROP COLUMN flagged_words,
+    DROP COLUMN verified_purchase,
+    DROP COLUMN status;
\ No newline at end of file
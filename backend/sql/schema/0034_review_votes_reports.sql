@@ -0,0 +1,46 @@
+-- +goose Up
+CREATE TABLE review_votes (
+    review_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    helpful BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_updated TIMESTAMP NULL,
+    PRIMARY KEY (review_id, user_id),
+    FOREIGN KEY (review_id) REFERENCES reviews (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE review_reports (
+    id UUID PRIMARY KEY,
+    review_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    reason VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (review_id) REFERENCES reviews (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (review_id, user_id)
+);
+
+CREATE INDEX idx_review_reports_review_id_created_at ON review_reports (review_id, created_at);
+
+-- lower bound of the 95% Wilson score interval for the share of helpful votes, so a review
+-- with a handful of votes does not outrank one with many mostly helpful votes
+-- +goose StatementBegin
+CREATE FUNCTION wilson_lower_bound(positive BIGINT, negative BIGINT) RETURNS DOUBLE PRECISION AS $$
+    SELECT CASE
+        WHEN positive + negative = 0 THEN 0
+        ELSE ((positive + 1.9208) / (positive + negative)
+            - 1.96 * SQRT((positive * negative) / (positive + negative)::NUMERIC + 0.9604) / (positive + negative))
+            / (1 + 3.8416 / (positive + negative))
+    END::DOUBLE PRECISION;
+$$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION wilson_lower_bound(BIGINT, BIGINT);
+
+DROP INDEX idx_review_reports_review_id_created_at;
+
+DROP TABLE review_reports;
+
+DROP TABLE review_votes;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- +goose Up
+-- a user's most recently viewed products are read, and trimmed, in last_viewed_at order
+CREATE INDEX recently_viewed_products_user_id_last_viewed_at_idx ON recently_viewed_products (user_id, last_viewed_at DESC);
+
+DROP INDEX recently_viewed_products_user_id_idx;
+
+-- +goose Down
+CREATE INDEX recently_viewed_products_user_id_idx ON recently_viewed_products (user_id);
+
+DROP INDEX recently_viewed_products_user_id_last_viewed_at_idx;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- +goose Up
+-- how often products are bought in the same order, recomputed periodically from order_items
+CREATE TABLE product_co_purchases (
+    product_id UUID NOT NULL,
+    related_product_id UUID NOT NULL,
+    order_count INT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, related_product_id),
+    FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE,
+    FOREIGN KEY (related_product_id) REFERENCES products (id) ON DELETE CASCADE,
+    CHECK (product_id <> related_product_id)
+);
+
+CREATE INDEX idx_product_co_purchases_product_id_score ON product_co_purchases (product_id, score DESC);
+
+-- +goose Down
+DROP INDEX idx_product_co_purchases_product_id_score;
+
+DROP TABLE product_co_purchases;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- +goose Up
+-- how often products are saved by the same users, recomputed periodically from wishlist_items
+CREATE TABLE product_wishlist_affinities (
+    product_id UUID NOT NULL,
+    related_product_id UUID NOT NULL,
+    user_count INT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, related_product_id),
+    FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE,
+    FOREIGN KEY (related_product_id) REFERENCES products (id) ON DELETE CASCADE,
+    CHECK (product_id <> related_product_id)
+);
+
+CREATE INDEX idx_product_wishlist_affinities_product_id_score ON product_wishlist_affinities (product_id, score DESC);
+
+-- +goose Down
+DROP INDEX idx_product_wishlist_affinities_product_id_score;
+
+DROP TABLE product_wishlist_affinities;
\ No newline at end of file